@@ -0,0 +1,51 @@
+package main
+
+import (
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/cmd/podman/libpodruntime"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	autoRestartCommand     cliconfig.AutoRestartValues
+	autoRestartDescription = `
+        podman container autorestart
+
+        Restart a container by its restart policy once it backed off after exiting.
+        Started automatically when a container with a restart policy exits.
+`
+	_autoRestartCommand = &cobra.Command{
+		Use:    "autorestart [flags] CONTAINER",
+		Short:  "restart a container by its restart policy",
+		Long:   autoRestartDescription,
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			autoRestartCommand.InputArgs = args
+			autoRestartCommand.GlobalFlags = MainGlobalOpts
+			autoRestartCommand.Remote = remoteclient
+			return autoRestartCmd(&autoRestartCommand)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("must provide the name or ID of one container")
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	autoRestartCommand.Command = _autoRestartCommand
+	autoRestartCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func autoRestartCmd(c *cliconfig.AutoRestartValues) error {
+	runtime, err := libpodruntime.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrap(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	return runtime.RunScheduledRestart(getContext(), c.InputArgs[0])
+}
@@ -0,0 +1,92 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/cmd/podman/shared"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	autoUpdateCommand     cliconfig.AutoUpdateValues
+	autoUpdateDescription = `Auto update containers according to their auto-update policy.
+
+  Auto-update policies are specified with the "io.containers.autoupdate" label.
+  Containers with the "image" policy are updated when the image in the registry differs from the local one.
+  The new image is pulled and the systemd unit of the container, recorded in the "PODMAN_SYSTEMD_UNIT" label, is restarted.`
+	_autoUpdateCommand = &cobra.Command{
+		Use:   "auto-update [flags]",
+		Short: "Auto update containers according to their auto-update policy",
+		Args:  noSubArgs,
+		Long:  autoUpdateDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			autoUpdateCommand.InputArgs = args
+			autoUpdateCommand.GlobalFlags = MainGlobalOpts
+			autoUpdateCommand.Remote = remoteclient
+			return autoUpdateCmd(&autoUpdateCommand)
+		},
+		Example: `podman auto-update
+  podman auto-update --dry-run`,
+	}
+)
+
+func init() {
+	autoUpdateCommand.Command = _autoUpdateCommand
+	autoUpdateCommand.SetHelpTemplate(HelpTemplate())
+	autoUpdateCommand.SetUsageTemplate(UsageTemplate())
+	flags := autoUpdateCommand.Flags()
+	flags.StringVar(&autoUpdateCommand.Authfile, "authfile", shared.GetAuthFile(""), "Path to the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
+	flags.BoolVar(&autoUpdateCommand.DryRun, "dry-run", false, "Only list the containers that would be updated")
+}
+
+func autoUpdateCmd(c *cliconfig.AutoUpdateValues) error {
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	reports, failures := runtime.AutoUpdate(c)
+	if c.DryRun {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		if _, err := fmt.Fprintln(w, "UNIT\tCONTAINER\tIMAGE\tUPDATE"); err != nil {
+			return err
+		}
+		for _, r := range reports {
+			update := "false"
+			if r.Updated {
+				update = "pending"
+			}
+			if _, err := fmt.Fprintf(w, "%s\t%s (%s)\t%s\t%s\n", r.Unit, r.ContainerName, r.ContainerID[:12], r.Image, update); err != nil {
+				return err
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	} else {
+		// print each restarted unit once
+		restarted := make(map[string]bool)
+		for _, r := range reports {
+			if r.Updated && r.Unit != "" && !restarted[r.Unit] {
+				restarted[r.Unit] = true
+				fmt.Println(r.Unit)
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		for _, err := range failures[:len(failures)-1] {
+			outputError(err)
+		}
+		return failures[len(failures)-1]
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package cliconfig
 
 import (
+	"net"
+
 	"github.com/spf13/cobra"
 )
 
@@ -41,6 +43,12 @@ type MainFlags struct {
 	RemoteConfigFilePath string
 }
 
+type AutoUpdateValues struct {
+	PodmanCommand
+	Authfile string
+	DryRun   bool
+}
+
 type AttachValues struct {
 	PodmanCommand
 	DetachKeys string
@@ -155,7 +163,9 @@ type GenerateKubeValues struct {
 
 type GenerateSystemdValues struct {
 	PodmanCommand
+	Files         bool
 	Name          bool
+	New           bool
 	RestartPolicy string
 	StopTimeout   int
 }
@@ -245,6 +255,8 @@ type LogsValues struct {
 	Details    bool
 	Follow     bool
 	Since      string
+	Until      string
+	Stream     string
 	Tail       uint64
 	Timestamps bool
 	Latest     bool
@@ -258,6 +270,38 @@ type MountValues struct {
 	Latest  bool
 }
 
+type NetworkCreateValues struct {
+	PodmanCommand
+	Driver   string
+	Gateway  net.IP
+	Internal bool
+	IPRange  net.IPNet
+	Network  net.IPNet
+}
+
+type NetworkListValues struct {
+	PodmanCommand
+	Filter string
+	Quiet  bool
+}
+
+type NetworkRmValues struct {
+	PodmanCommand
+	Force bool
+}
+
+type NetworkInspectValues struct {
+	PodmanCommand
+}
+
+type NetworkConnectValues struct {
+	PodmanCommand
+}
+
+type NetworkDisconnectValues struct {
+	PodmanCommand
+}
+
 type PauseValues struct {
 	PodmanCommand
 	All bool
@@ -267,28 +311,58 @@ type HealthCheckValues struct {
 	PodmanCommand
 }
 
+type HealthCheckScheduleValues struct {
+	PodmanCommand
+}
+
+type LogForwardValues struct {
+	PodmanCommand
+	Since string
+}
+
+type LogRotateValues struct {
+	PodmanCommand
+}
+
+type AutoRestartValues struct {
+	PodmanCommand
+}
+
 type KubePlayValues struct {
 	PodmanCommand
 	Authfile        string
 	CertDir         string
+	ConfigMaps      []string
 	Creds           string
+	Down            bool
 	Quiet           bool
 	SignaturePolicy string
 	TlsVerify       bool
+	Volumes         bool
 }
 
 type PodCreateValues struct {
 	PodmanCommand
+	AddHost      []string
+	BlkioWeight  string
 	CgroupParent string
+	CPUs         float64
+	DNS          []string
+	DNSSearch    []string
+	Hostname     string
 	Infra        bool
 	InfraImage   string
 	InfraCommand string
 	LabelFile    []string
 	Labels       []string
+	Memory       string
 	Name         string
+	NoHosts      bool
+	PidsLimit    int64
 	PodIDFile    string
 	Publish      []string
 	Share        string
+	Volume       []string
 }
 
 type PodInspectValues struct {
@@ -303,6 +377,17 @@ type PodKillValues struct {
 	Latest bool
 }
 
+type PodLogsValues struct {
+	PodmanCommand
+	Follow     bool
+	Since      string
+	Until      string
+	Stream     string
+	Tail       uint64
+	Timestamps bool
+	Latest     bool
+}
+
 type PodPauseValues struct {
 	PodmanCommand
 	All    bool
@@ -442,11 +527,13 @@ type RestoreValues struct {
 
 type RmValues struct {
 	PodmanCommand
-	All     bool
-	Force   bool
-	Latest  bool
-	Storage bool
-	Volumes bool
+	All      bool
+	Force    bool
+	Ignore   bool
+	Latest   bool
+	Storage  bool
+	Volumes  bool
+	CIDFiles []string
 }
 
 type RmiValues struct {
@@ -514,9 +601,11 @@ type StatsValues struct {
 
 type StopValues struct {
 	PodmanCommand
-	All     bool
-	Latest  bool
-	Timeout uint
+	All      bool
+	Ignore   bool
+	Latest   bool
+	Timeout  uint
+	CIDFiles []string
 }
 
 type TopValues struct {
@@ -568,6 +657,16 @@ type VolumeCreateValues struct {
 	Label  []string
 	Opt    []string
 }
+
+type VolumeExportValues struct {
+	PodmanCommand
+	Output string
+}
+
+type VolumeImportValues struct {
+	PodmanCommand
+}
+
 type VolumeInspectValues struct {
 	PodmanCommand
 	All    bool
@@ -614,6 +713,10 @@ type SystemMigrateValues struct {
 	PodmanCommand
 }
 
+type SystemBootValues struct {
+	PodmanCommand
+}
+
 type SystemDfValues struct {
 	PodmanCommand
 	Verbose bool
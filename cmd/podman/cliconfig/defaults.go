@@ -14,6 +14,8 @@ var (
 	DefaultHealthCheckStartPeriod = "0s"
 	// DefaultHealthCheckTimeout default value
 	DefaultHealthCheckTimeout = "30s"
+	// DefaultHealthCheckOnFailure default value
+	DefaultHealthCheckOnFailure = "none"
 	// DefaultImageVolume default value
 	DefaultImageVolume = "bind"
 	// DefaultShmSize default value
@@ -11,10 +11,12 @@ const remoteclient = false
 // Commands that the local client implements
 func getMainCommands() []*cobra.Command {
 	rootCommands := []*cobra.Command{
+		_autoUpdateCommand,
 		_playCommand,
 		_loginCommand,
 		_logoutCommand,
 		_mountCommand,
+		_networkCommand,
 		_refreshCommand,
 		_searchCommand,
 		_statsCommand,
@@ -39,7 +41,10 @@ func getImageSubCommands() []*cobra.Command {
 func getContainerSubCommands() []*cobra.Command {
 
 	return []*cobra.Command{
+		_autoRestartCommand,
 		_cleanupCommand,
+		_logForwardCommand,
+		_logRotateCommand,
 		_mountCommand,
 		_refreshCommand,
 		_runlabelCommand,
@@ -69,6 +74,7 @@ func getSystemSubCommands() []*cobra.Command {
 		_renumberCommand,
 		_dfSystemCommand,
 		_migrateCommand,
+		_bootCommand,
 	}
 }
 
@@ -76,5 +82,6 @@ func getSystemSubCommands() []*cobra.Command {
 func getHealthcheckSubCommands() []*cobra.Command {
 	return []*cobra.Command{
 		_healthcheckrunCommand,
+		_healthcheckScheduleCommand,
 	}
 }
@@ -39,22 +39,42 @@ func shortID(id string) string {
 
 // checkAllAndLatest checks that --all and --latest are used correctly
 func checkAllAndLatest(c *cobra.Command, args []string, ignoreArgLen bool) error {
+	return checkAllLatestAndCIDFile(c, args, ignoreArgLen, false)
+}
+
+// checkAllLatestAndCIDFile checks that --all, --latest and, if cidfile is
+// set, --cidfile are neither combined with each other nor with arguments
+func checkAllLatestAndCIDFile(c *cobra.Command, args []string, ignoreArgLen bool, cidfile bool) error {
 	argLen := len(args)
 	if c.Flags().Lookup("all") == nil || c.Flags().Lookup("latest") == nil {
 		return errors.New("unable to lookup values for 'latest' or 'all'")
 	}
 	all, _ := c.Flags().GetBool("all")
 	latest, _ := c.Flags().GetBool("latest")
+	specifiedCIDFile := false
+	if cidfile {
+		cidFiles, err := c.Flags().GetStringArray("cidfile")
+		if err != nil {
+			return errors.New("unable to lookup values for 'cidfile'")
+		}
+		specifiedCIDFile = len(cidFiles) > 0
+	}
 	if all && latest {
 		return errors.Errorf("--all and --latest cannot be used together")
 	}
+	if specifiedCIDFile && (all || latest) {
+		return errors.Errorf("--all, --latest and --cidfile cannot be used together")
+	}
 	if ignoreArgLen {
 		return nil
 	}
+	if specifiedCIDFile && argLen > 0 {
+		return errors.Errorf("no arguments are needed with --cidfile")
+	}
 	if (all || latest) && argLen > 0 {
 		return errors.Errorf("no arguments are needed with --all or --latest")
 	}
-	if argLen < 1 && !all && !latest {
+	if argLen < 1 && !all && !latest && !specifiedCIDFile {
 		return errors.Errorf("you must provide at least one name or id")
 	}
 	return nil
@@ -255,6 +275,10 @@ func getCreateFlags(c *cliconfig.PodmanCommand) {
 		"health-interval", cliconfig.DefaultHealthCheckInterval,
 		"set an interval for the healthchecks (a value of disable results in no automatic timer setup)",
 	)
+	createFlags.String(
+		"health-on-failure", cliconfig.DefaultHealthCheckOnFailure,
+		"action to take once the container turns unhealthy (none, kill, restart, stop)",
+	)
 	createFlags.Uint(
 		"health-retries", cliconfig.DefaultHealthCheckRetries,
 		"the number of retries allowed before a healthcheck is considered to be unhealthy",
@@ -283,6 +307,10 @@ func getCreateFlags(c *cliconfig.PodmanCommand) {
 		"init", false,
 		"Run an init binary inside the container that forwards signals and reaps processes",
 	)
+	createFlags.Bool(
+		"init-ctr", false,
+		"Make the container an init container of its pod, which runs to completion before the other containers of the pod are started",
+	)
 	createFlags.String(
 		"init-path", "",
 		// Do not use  the Value field for setting the default value to determine user input (i.e., non-empty string)
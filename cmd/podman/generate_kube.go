@@ -15,12 +15,12 @@ import (
 
 var (
 	containerKubeCommand     cliconfig.GenerateKubeValues
-	containerKubeDescription = `Command generates Kubernetes Pod YAML (v1 specification) from a podman container or pod.
+	containerKubeDescription = `Command generates Kubernetes Pod YAML (v1 specification) from podman containers or pods.
 
-  Whether the input is for a container or pod, Podman will always generate the specification as a Pod. The input may be in the form of a pod or container name or ID.`
+  Whether the input is for a container or pod, Podman will always generate the specification as a Pod. The input may be in the form of pod or container names or IDs.  Named volumes used by the containers are described as PersistentVolumeClaims.`
 	_containerKubeCommand = &cobra.Command{
-		Use:   "kube [flags] CONTAINER | POD",
-		Short: "Generate Kubernetes pod YAML from a container or pod",
+		Use:   "kube [flags] CONTAINER | POD [CONTAINER | POD...]",
+		Short: "Generate Kubernetes pod YAML from containers or pods",
 		Long:  containerKubeDescription,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			containerKubeCommand.InputArgs = args
@@ -30,7 +30,8 @@ var (
 		},
 		Example: `podman generate kube ctrID
   podman generate kube podID
-  podman generate kube --service podID`,
+  podman generate kube --service podID
+  podman generate kube ctrID podID`,
 	}
 )
 
@@ -45,17 +46,14 @@ func init() {
 
 func generateKubeYAMLCmd(c *cliconfig.GenerateKubeValues) error {
 	var (
-		//podYAML           *v1.Pod
-		err    error
-		output []byte
-		//pod               *libpod.Pod
-		marshalledPod     []byte
-		marshalledService []byte
+		err       error
+		output    []byte
+		documents [][]byte
 	)
 
 	args := c.InputArgs
-	if len(args) != 1 {
-		return errors.Errorf("you must provide exactly one container|pod ID or name")
+	if len(args) < 1 {
+		return errors.Errorf("you must provide at least one container|pod ID or name")
 	}
 
 	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
@@ -64,20 +62,32 @@ func generateKubeYAMLCmd(c *cliconfig.GenerateKubeValues) error {
 	}
 	defer runtime.DeferredShutdown(false)
 
-	podYAML, serviceYAML, err := runtime.GenerateKube(c)
+	objects, err := runtime.GenerateKube(c)
 	if err != nil {
 		return err
 	}
-	// Marshall the results
-	marshalledPod, err = yaml.Marshal(podYAML)
-	if err != nil {
-		return err
+	// Marshall the results.  Claims come first so the pods using them can
+	// be created right away when the output is applied.
+	for _, claim := range objects.Claims {
+		marshalledClaim, err := yaml.Marshal(claim)
+		if err != nil {
+			return err
+		}
+		documents = append(documents, marshalledClaim)
 	}
-	if c.Service {
-		marshalledService, err = yaml.Marshal(serviceYAML)
+	for i, pod := range objects.Pods {
+		marshalledPod, err := yaml.Marshal(pod)
 		if err != nil {
 			return err
 		}
+		documents = append(documents, marshalledPod)
+		if c.Service {
+			marshalledService, err := yaml.Marshal(objects.Services[i])
+			if err != nil {
+				return err
+			}
+			documents = append(documents, marshalledService)
+		}
 	}
 	header := `# Generation of Kubernetes YAML is still under development!
 #
@@ -87,10 +97,11 @@ func generateKubeYAMLCmd(c *cliconfig.GenerateKubeValues) error {
 # Created with podman-%s
 `
 	output = append(output, []byte(fmt.Sprintf(header, podmanVersion.Version))...)
-	output = append(output, marshalledPod...)
-	if c.Bool("service") {
-		output = append(output, []byte("---\n")...)
-		output = append(output, marshalledService...)
+	for i, document := range documents {
+		if i > 0 {
+			output = append(output, []byte("---\n")...)
+		}
+		output = append(output, document...)
 	}
 
 	if c.Filename != "" {
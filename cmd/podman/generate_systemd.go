@@ -12,11 +12,14 @@ import (
 
 var (
 	containerSystemdCommand     cliconfig.GenerateSystemdValues
-	containerSystemdDescription = `Command generates a systemd unit file for a Podman container
+	containerSystemdDescription = `Command generates systemd unit files for a Podman container or pod.
+
+  When generating for a pod, a unit for the pod driving its infra container is generated together with a unit
+  for each container of the pod, which is bound to the unit of the pod.
   `
 	_containerSystemdCommand = &cobra.Command{
 		Use:   "systemd [flags] CONTAINER | POD",
-		Short: "Generate a systemd unit file for a Podman container",
+		Short: "Generate systemd unit files for a Podman container or pod",
 		Long:  containerSystemdDescription,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			containerSystemdCommand.InputArgs = args
@@ -26,11 +29,13 @@ var (
 		},
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 1 || len(args) < 1 {
-				return errors.New("provide only one container name or ID")
+				return errors.New("provide only one container or pod name or ID")
 			}
 			return nil
 		},
 		Example: `podman generate systemd ctrID
+  podman generate systemd --name --files podName
+  podman generate systemd --new --name ctrName
 `,
 	}
 )
@@ -40,7 +45,11 @@ func init() {
 	containerSystemdCommand.SetHelpTemplate(HelpTemplate())
 	containerSystemdCommand.SetUsageTemplate(UsageTemplate())
 	flags := containerSystemdCommand.Flags()
-	flags.BoolVarP(&containerSystemdCommand.Name, "name", "n", false, "use the container name instead of ID")
+	flags.BoolVarP(&containerSystemdCommand.Files, "files", "f", false, "generate files instead of printing to stdout")
+	markFlagHiddenForRemoteClient("files", flags)
+	flags.BoolVar(&containerSystemdCommand.New, "new", false, "create a new container instead of starting an existing one")
+	markFlagHiddenForRemoteClient("new", flags)
+	flags.BoolVarP(&containerSystemdCommand.Name, "name", "n", false, "use container and pod names instead of IDs")
 	flags.IntVarP(&containerSystemdCommand.StopTimeout, "timeout", "t", -1, "stop timeout override")
 	flags.StringVar(&containerSystemdCommand.RestartPolicy, "restart-policy", "on-failure", "applicable systemd restart-policy")
 }
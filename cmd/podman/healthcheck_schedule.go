@@ -0,0 +1,51 @@
+package main
+
+import (
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/cmd/podman/libpodruntime"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	healthcheckScheduleCommand     cliconfig.HealthCheckScheduleValues
+	healthcheckScheduleDescription = `
+        podman healthcheck schedule
+
+        Run the health check of a container on its interval until the container stops.
+        Started automatically along with the container when systemd timers are not available.
+`
+	_healthcheckScheduleCommand = &cobra.Command{
+		Use:    "schedule [flags] CONTAINER",
+		Short:  "run the health check of a container on its interval",
+		Long:   healthcheckScheduleDescription,
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			healthcheckScheduleCommand.InputArgs = args
+			healthcheckScheduleCommand.GlobalFlags = MainGlobalOpts
+			healthcheckScheduleCommand.Remote = remoteclient
+			return healthCheckScheduleCmd(&healthcheckScheduleCommand)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("must provide the name or ID of one container")
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	healthcheckScheduleCommand.Command = _healthcheckScheduleCommand
+	healthcheckScheduleCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func healthCheckScheduleCmd(c *cliconfig.HealthCheckScheduleValues) error {
+	runtime, err := libpodruntime.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrap(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	return runtime.RunHealthCheckScheduler(getContext(), c.InputArgs[0])
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"time"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/cmd/podman/libpodruntime"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logForwardCommand     cliconfig.LogForwardValues
+	logForwardDescription = `
+        podman container logforward
+
+        Forward the log of a container to its syslog endpoint, until the container stops.
+        Started automatically along with containers using the syslog log driver.
+`
+	_logForwardCommand = &cobra.Command{
+		Use:    "logforward [flags] CONTAINER",
+		Short:  "forward the log of a container to syslog",
+		Long:   logForwardDescription,
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logForwardCommand.InputArgs = args
+			logForwardCommand.GlobalFlags = MainGlobalOpts
+			logForwardCommand.Remote = remoteclient
+			return logForwardCmd(&logForwardCommand)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("must provide the name or ID of one container")
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	logForwardCommand.Command = _logForwardCommand
+	logForwardCommand.SetUsageTemplate(UsageTemplate())
+	flags := logForwardCommand.Flags()
+	flags.StringVar(&logForwardCommand.Since, "since", "", "Forward the lines logged after TIMESTAMP, in RFC3339Nano format")
+}
+
+func logForwardCmd(c *cliconfig.LogForwardValues) error {
+	var since time.Time
+	if c.Since != "" {
+		t, err := time.Parse(time.RFC3339Nano, c.Since)
+		if err != nil {
+			return errors.Wrapf(err, "could not parse time: %q", c.Since)
+		}
+		since = t
+	}
+
+	runtime, err := libpodruntime.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrap(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	return runtime.RunLogForwarding(c.InputArgs[0], since)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/cmd/podman/libpodruntime"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logRotateCommand     cliconfig.LogRotateValues
+	logRotateDescription = `
+        podman container logrotate
+
+        Rotate the log file of a container whenever it reaches its maximum size, until the container stops.
+        Started automatically along with containers keeping more than one log file.
+`
+	_logRotateCommand = &cobra.Command{
+		Use:    "logrotate [flags] CONTAINER",
+		Short:  "rotate the log file of a container",
+		Long:   logRotateDescription,
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logRotateCommand.InputArgs = args
+			logRotateCommand.GlobalFlags = MainGlobalOpts
+			logRotateCommand.Remote = remoteclient
+			return logRotateCmd(&logRotateCommand)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("must provide the name or ID of one container")
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	logRotateCommand.Command = _logRotateCommand
+	logRotateCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func logRotateCmd(c *cliconfig.LogRotateValues) error {
+	runtime, err := libpodruntime.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrap(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	return runtime.RunLogRotation(getContext(), c.InputArgs[0])
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"os"
 	"time"
 
 	"github.com/containers/libpod/cmd/podman/cliconfig"
@@ -9,6 +10,7 @@ import (
 	"github.com/containers/libpod/pkg/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 var (
@@ -28,6 +30,9 @@ var (
 			return logsCmd(&logsCommand)
 		},
 		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateLogStream(logsCommand.Stream); err != nil {
+				return err
+			}
 			if len(args) > 0 && logsCommand.Latest {
 				return errors.New("no containers can be specified when using 'latest'")
 			}
@@ -39,6 +44,7 @@ var (
 		Example: `podman logs ctrID
   podman logs --tail 2 mywebserver
   podman logs --follow=true --since 10m ctrID
+  podman logs --since 2019-10-01T10:00:00 --until 2019-10-01T10:05:00 --stream stderr ctrID
   podman logs mywebserver mydbserver`,
 	}
 )
@@ -52,6 +58,8 @@ func init() {
 	flags.BoolVarP(&logsCommand.Follow, "follow", "f", false, "Follow log output.  The default is false")
 	flags.BoolVarP(&logsCommand.Latest, "latest", "l", false, "Act on the latest container podman is aware of")
 	flags.StringVar(&logsCommand.Since, "since", "", "Show logs since TIMESTAMP")
+	flags.StringVar(&logsCommand.Until, "until", "", "Show logs until TIMESTAMP")
+	flags.StringVar(&logsCommand.Stream, "stream", "", "Only show logs of the container's stdout or stderr")
 	flags.Uint64Var(&logsCommand.Tail, "tail", 0, "Output the specified number of LINES at the end of the logs.  Defaults to 0, which prints all lines")
 	flags.BoolVarP(&logsCommand.Timestamps, "timestamps", "t", false, "Output the timestamps in the log")
 	markFlagHidden(flags, "details")
@@ -69,22 +77,50 @@ func logsCmd(c *cliconfig.LogsValues) error {
 	}
 	defer runtime.DeferredShutdown(false)
 
-	sinceTime := time.Time{}
-	if c.Flag("since").Changed {
-		// parse time, error out if something is wrong
-		since, err := util.ParseInputTime(c.Since)
-		if err != nil {
-			return errors.Wrapf(err, "could not parse time: %q", c.Since)
-		}
-		sinceTime = since
+	sinceTime, untilTime, err := parseLogTimes(&c.PodmanCommand, c.Since, c.Until)
+	if err != nil {
+		return err
 	}
 
 	options := &logs.LogOptions{
 		Details:    c.Details,
 		Follow:     c.Follow,
 		Since:      sinceTime,
+		Until:      untilTime,
+		Stream:     c.Stream,
 		Tail:       c.Tail,
 		Timestamps: c.Timestamps,
+		Color:      terminal.IsTerminal(int(os.Stdout.Fd())),
 	}
 	return runtime.Log(c, options)
 }
+
+// validateLogStream checks the stream the logs are restricted to
+func validateLogStream(stream string) error {
+	if stream != "" && stream != "stdout" && stream != "stderr" {
+		return errors.Errorf("invalid stream %q: must be stdout or stderr", stream)
+	}
+	return nil
+}
+
+// parseLogTimes parses the --since and --until flags of the logs commands,
+// returning zero times for the flags that were not set
+func parseLogTimes(c *cliconfig.PodmanCommand, since, until string) (time.Time, time.Time, error) {
+	var sinceTime, untilTime time.Time
+	if c.Flag("since").Changed {
+		// parse time, error out if something is wrong
+		t, err := util.ParseInputTime(since)
+		if err != nil {
+			return sinceTime, untilTime, errors.Wrapf(err, "could not parse time: %q", since)
+		}
+		sinceTime = t
+	}
+	if c.Flag("until").Changed {
+		t, err := util.ParseInputTime(until)
+		if err != nil {
+			return sinceTime, untilTime, errors.Wrapf(err, "could not parse time: %q", until)
+		}
+		untilTime = t
+	}
+	return sinceTime, untilTime, nil
+}
@@ -0,0 +1,38 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkCommand     cliconfig.PodmanCommand
+	networkDescription = `Manage CNI networks.
+
+  Networks are stored as CNI configuration lists in the CNI configuration directory of podman.`
+	_networkCommand = &cobra.Command{
+		Use:   "network",
+		Short: "Manage networks",
+		Long:  networkDescription,
+		RunE:  commandRunE(),
+	}
+)
+
+// Commands that are implemented by the network command
+var networkSubcommands = []*cobra.Command{
+	_networkConnectCommand,
+	_networkCreateCommand,
+	_networkDisconnectCommand,
+	_networkInspectCommand,
+	_networkListCommand,
+	_networkRmCommand,
+}
+
+func init() {
+	networkCommand.Command = _networkCommand
+	networkCommand.SetHelpTemplate(HelpTemplate())
+	networkCommand.SetUsageTemplate(UsageTemplate())
+	networkCommand.AddCommand(networkSubcommands...)
+}
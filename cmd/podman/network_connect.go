@@ -0,0 +1,50 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkConnectCommand     cliconfig.NetworkConnectValues
+	networkConnectDescription = `Connect a container to a CNI network.
+
+  If the container is running, its network namespace is attached to the network right away. The container joins the network on every subsequent start as well.`
+	_networkConnectCommand = &cobra.Command{
+		Use:   "connect CONTAINER NETWORK",
+		Short: "Connect a container to a network",
+		Args:  cobra.ExactArgs(2),
+		Long:  networkConnectDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			networkConnectCommand.InputArgs = args
+			networkConnectCommand.GlobalFlags = MainGlobalOpts
+			networkConnectCommand.Remote = remoteclient
+			return networkConnectCmd(&networkConnectCommand)
+		},
+		Example: `podman network connect ctrID mynet`,
+	}
+)
+
+func init() {
+	networkConnectCommand.Command = _networkConnectCommand
+	networkConnectCommand.SetHelpTemplate(HelpTemplate())
+	networkConnectCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func networkConnectCmd(c *cliconfig.NetworkConnectValues) error {
+	if rootless.IsRootless() {
+		return errors.New("network connect is not supported for rootless mode")
+	}
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	return runtime.NetworkConnect(c)
+}
@@ -0,0 +1,68 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/containers/libpod/pkg/network"
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkCreateCommand     cliconfig.NetworkCreateValues
+	networkCreateDescription = `Create a CNI network.
+
+  The network is a bridge network with port mapping and firewall plugins. If no subnet is given, podman picks a /24 subnet that neither collides with other CNI networks nor with the addresses of the host.`
+	_networkCreateCommand = &cobra.Command{
+		Use:   "create [flags] [NETWORK]",
+		Short: "Create a CNI network",
+		Long:  networkCreateDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			networkCreateCommand.InputArgs = args
+			networkCreateCommand.GlobalFlags = MainGlobalOpts
+			networkCreateCommand.Remote = remoteclient
+			return networkCreateCmd(&networkCreateCommand)
+		},
+		Example: `podman network create
+  podman network create --subnet 192.168.22.0/24 mynet
+  podman network create --subnet 192.168.33.0/24 --ip-range 192.168.33.128/25 --gateway 192.168.33.1 mynet`,
+	}
+)
+
+func init() {
+	networkCreateCommand.Command = _networkCreateCommand
+	networkCreateCommand.SetHelpTemplate(HelpTemplate())
+	networkCreateCommand.SetUsageTemplate(UsageTemplate())
+	flags := networkCreateCommand.Flags()
+	flags.StringVarP(&networkCreateCommand.Driver, "driver", "d", network.DefaultDriver, "driver to manage the network")
+	flags.IPVar(&networkCreateCommand.Gateway, "gateway", nil, "IPv4 or IPv6 gateway for the subnet")
+	flags.BoolVar(&networkCreateCommand.Internal, "internal", false, "restrict external access from this network")
+	flags.IPNetVar(&networkCreateCommand.IPRange, "ip-range", net.IPNet{}, "allocate container IP from range")
+	flags.IPNetVar(&networkCreateCommand.Network, "subnet", net.IPNet{}, "subnet in CIDR format")
+}
+
+func networkCreateCmd(c *cliconfig.NetworkCreateValues) error {
+	if len(c.InputArgs) > 1 {
+		return errors.Errorf("only one network can be created at a time")
+	}
+	if rootless.IsRootless() {
+		return errors.New("network create is not supported for rootless mode")
+	}
+	runtime, err := adapter.GetRuntimeNoStore(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	fileName, err := runtime.NetworkCreate(c)
+	if err == nil {
+		fmt.Println(fileName)
+	}
+	return err
+}
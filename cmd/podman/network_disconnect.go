@@ -0,0 +1,50 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkDisconnectCommand     cliconfig.NetworkDisconnectValues
+	networkDisconnectDescription = `Disconnect a container from a CNI network.
+
+  If the container is running, its network namespace is detached from the network right away. A container must stay connected to at least one network.`
+	_networkDisconnectCommand = &cobra.Command{
+		Use:   "disconnect CONTAINER NETWORK",
+		Short: "Disconnect a container from a network",
+		Args:  cobra.ExactArgs(2),
+		Long:  networkDisconnectDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			networkDisconnectCommand.InputArgs = args
+			networkDisconnectCommand.GlobalFlags = MainGlobalOpts
+			networkDisconnectCommand.Remote = remoteclient
+			return networkDisconnectCmd(&networkDisconnectCommand)
+		},
+		Example: `podman network disconnect ctrID mynet`,
+	}
+)
+
+func init() {
+	networkDisconnectCommand.Command = _networkDisconnectCommand
+	networkDisconnectCommand.SetHelpTemplate(HelpTemplate())
+	networkDisconnectCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func networkDisconnectCmd(c *cliconfig.NetworkDisconnectValues) error {
+	if rootless.IsRootless() {
+		return errors.New("network disconnect is not supported for rootless mode")
+	}
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	return runtime.NetworkDisconnect(c)
+}
@@ -0,0 +1,55 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkInspectCommand     cliconfig.NetworkInspectValues
+	networkInspectDescription = `Inspect network`
+	_networkInspectCommand    = &cobra.Command{
+		Use:   "inspect NETWORK [NETWORK...]",
+		Short: "network inspect",
+		Args:  cobra.MinimumNArgs(1),
+		Long:  networkInspectDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			networkInspectCommand.InputArgs = args
+			networkInspectCommand.GlobalFlags = MainGlobalOpts
+			networkInspectCommand.Remote = remoteclient
+			return networkInspectCmd(&networkInspectCommand)
+		},
+		Example: `podman network inspect podman`,
+	}
+)
+
+func init() {
+	networkInspectCommand.Command = _networkInspectCommand
+	networkInspectCommand.SetHelpTemplate(HelpTemplate())
+	networkInspectCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func networkInspectCmd(c *cliconfig.NetworkInspectValues) error {
+	runtime, err := adapter.GetRuntimeNoStore(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	rawCNINetworks, err := runtime.NetworkInspect(c)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(rawCNINetworks, "", "\t")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
@@ -0,0 +1,73 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/containers/libpod/pkg/network"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkListCommand     cliconfig.NetworkListValues
+	networkListDescription = `List CNI networks.`
+	_networkListCommand    = &cobra.Command{
+		Use:     "ls",
+		Args:    noSubArgs,
+		Short:   "network list",
+		Long:    networkListDescription,
+		Aliases: []string{"list"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			networkListCommand.InputArgs = args
+			networkListCommand.GlobalFlags = MainGlobalOpts
+			networkListCommand.Remote = remoteclient
+			return networkListCmd(&networkListCommand)
+		},
+		Example: `podman network ls
+  podman network ls --filter plugin=bridge`,
+	}
+)
+
+func init() {
+	networkListCommand.Command = _networkListCommand
+	networkListCommand.SetHelpTemplate(HelpTemplate())
+	networkListCommand.SetUsageTemplate(UsageTemplate())
+	flags := networkListCommand.Flags()
+	flags.StringVarP(&networkListCommand.Filter, "filter", "f", "", "Filter network output (name=NAME, plugin=PLUGIN)")
+	flags.BoolVarP(&networkListCommand.Quiet, "quiet", "q", false, "display only names")
+}
+
+func networkListCmd(c *cliconfig.NetworkListValues) error {
+	runtime, err := adapter.GetRuntimeNoStore(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	networks, err := runtime.NetworkList(c)
+	if err != nil {
+		return err
+	}
+	if c.Quiet {
+		for _, n := range networks {
+			fmt.Println(n.Name)
+		}
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "NAME\tVERSION\tPLUGINS"); err != nil {
+		return err
+	}
+	for _, n := range networks {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", n.Name, n.CNIVersion, network.GetCNIPlugins(n)); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,59 @@
+// +build !remoteclient
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkRmCommand     cliconfig.NetworkRmValues
+	networkRmDescription = `Remove CNI networks.
+
+  Networks that are still used by containers are only removed if --force is given, which removes those containers as well.`
+	_networkRmCommand = &cobra.Command{
+		Use:   "rm [flags] NETWORK [NETWORK...]",
+		Short: "network rm",
+		Args:  cobra.MinimumNArgs(1),
+		Long:  networkRmDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			networkRmCommand.InputArgs = args
+			networkRmCommand.GlobalFlags = MainGlobalOpts
+			networkRmCommand.Remote = remoteclient
+			return networkRmCmd(&networkRmCommand)
+		},
+		Example: `podman network rm podman
+  podman network rm --force mynet`,
+	}
+)
+
+func init() {
+	networkRmCommand.Command = _networkRmCommand
+	networkRmCommand.SetHelpTemplate(HelpTemplate())
+	networkRmCommand.SetUsageTemplate(UsageTemplate())
+	flags := networkRmCommand.Flags()
+	flags.BoolVarP(&networkRmCommand.Force, "force", "f", false, "remove any containers using the network")
+}
+
+func networkRmCmd(c *cliconfig.NetworkRmValues) error {
+	if rootless.IsRootless() {
+		return errors.New("network rm is not supported for rootless mode")
+	}
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	deletes, err := runtime.NetworkRemove(getContext(), c)
+	for _, d := range deletes {
+		fmt.Println(d)
+	}
+	return err
+}
@@ -13,7 +13,9 @@ var (
 	playKubeCommand     cliconfig.KubePlayValues
 	playKubeDescription = `Command reads in a structured file of Kubernetes YAML.
 
-  It creates the pod and containers described in the YAML.  The containers within the pod are then started and the ID of the new Pod is output.`
+  It creates the pod and containers described in the YAML.  The containers within the pod are then started and the ID of the new Pod is output.
+
+  With --down the pods and containers described in the YAML are stopped and removed instead.`
 	_playKubeCommand = &cobra.Command{
 		Use:   "kube [flags] KUBEFILE",
 		Short: "Play a pod based on Kubernetes YAML",
@@ -24,24 +26,28 @@ var (
 			playKubeCommand.Remote = remoteclient
 			return playKubeCmd(&playKubeCommand)
 		},
-		Example: `podman play kube demo.yml`,
+		Example: `podman play kube demo.yml
+  podman play kube --down --volumes demo.yml`,
 	}
 )
 
 func init() {
 	if !remote {
-		_playKubeCommand.Example = fmt.Sprintf("%s\n  podman play kube --cert-dir /mycertsdir --tls-verify=true --quiet myWebPod", _playKubeCommand.Example)
+		_playKubeCommand.Example = fmt.Sprintf("%s\n  podman play kube --cert-dir /mycertsdir --tls-verify=true --quiet myWebPod\n  podman play kube --configmap settings.yml myWebPod", _playKubeCommand.Example)
 	}
 	playKubeCommand.Command = _playKubeCommand
 	playKubeCommand.SetHelpTemplate(HelpTemplate())
 	playKubeCommand.SetUsageTemplate(UsageTemplate())
 	flags := playKubeCommand.Flags()
 	flags.StringVar(&playKubeCommand.Creds, "creds", "", "`Credentials` (USERNAME:PASSWORD) to use for authenticating to a registry")
+	flags.BoolVar(&playKubeCommand.Down, "down", false, "Stop and remove the pods and containers described in the YAML")
 	flags.BoolVarP(&playKubeCommand.Quiet, "quiet", "q", false, "Suppress output information when pulling images")
+	flags.BoolVar(&playKubeCommand.Volumes, "volumes", false, "Also remove the volumes created for the pods when used with --down")
 	// Disabled flags for the remote client
 	if !remote {
 		flags.StringVar(&playKubeCommand.Authfile, "authfile", shared.GetAuthFile(""), "Path of the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
 		flags.StringVar(&playKubeCommand.CertDir, "cert-dir", "", "`Pathname` of a directory containing TLS certificates and keys")
+		flags.StringSliceVar(&playKubeCommand.ConfigMaps, "configmap", []string{}, "`Pathname` of a YAML file containing a kubernetes configmap")
 		flags.StringVar(&playKubeCommand.SignaturePolicy, "signature-policy", "", "`Pathname` of signature policy file (not usually used)")
 		flags.BoolVar(&playKubeCommand.TlsVerify, "tls-verify", true, "Require HTTPS and verify certificates when contacting registries")
 		markFlagHidden(flags, "signature-policy")
@@ -56,6 +62,9 @@ func playKubeCmd(c *cliconfig.KubePlayValues) error {
 	if len(args) < 1 {
 		return errors.New("you must supply at least one file")
 	}
+	if c.Volumes && !c.Down {
+		return errors.New("--volumes can only be used with --down")
+	}
 
 	ctx := getContext()
 	runtime, err := adapter.GetRuntime(ctx, &c.PodmanCommand)
@@ -64,6 +73,9 @@ func playKubeCmd(c *cliconfig.KubePlayValues) error {
 	}
 	defer runtime.DeferredShutdown(false)
 
+	if c.Down {
+		return runtime.TeardownKubeYAML(ctx, c, args[0])
+	}
 	_, err = runtime.PlayKubeYAML(ctx, c, args[0])
 	return err
 }
@@ -23,6 +23,7 @@ var podSubCommands = []*cobra.Command{
 	_podExistsCommand,
 	_podInspectCommand,
 	_podKillCommand,
+	_podLogsCommand,
 	_podPauseCommand,
 	_prunePodsCommand,
 	_podPsCommand,
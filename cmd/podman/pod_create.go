@@ -45,16 +45,26 @@ func init() {
 	flags := podCreateCommand.Flags()
 	flags.SetInterspersed(false)
 
+	flags.StringSliceVar(&podCreateCommand.AddHost, "add-host", []string{}, "Add a custom host-to-IP mapping (host:ip) to the hosts file of the pod (default [])")
+	flags.StringVar(&podCreateCommand.BlkioWeight, "blkio-weight", "", "Block IO weight of the pod, relative weight accepts a weight value between 10 and 1000")
 	flags.StringVar(&podCreateCommand.CgroupParent, "cgroup-parent", "", "Set parent cgroup for the pod")
+	flags.Float64Var(&podCreateCommand.CPUs, "cpus", 0, "Number of CPUs the containers of the pod may use together. The default is 0.000 which means no limit")
+	flags.StringSliceVar(&podCreateCommand.DNS, "dns", []string{}, "Set custom DNS servers for the pod (default [])")
+	flags.StringSliceVar(&podCreateCommand.DNSSearch, "dns-search", []string{}, "Set custom DNS search domains for the pod (default [])")
+	flags.StringVar(&podCreateCommand.Hostname, "hostname", "", "Set the hostname of the pod")
 	flags.BoolVar(&podCreateCommand.Infra, "infra", true, "Create an infra container associated with the pod to share namespaces with")
 	flags.StringVar(&podCreateCommand.InfraImage, "infra-image", define.DefaultInfraImage, "The image of the infra container to associate with the pod")
 	flags.StringVar(&podCreateCommand.InfraCommand, "infra-command", define.DefaultInfraCommand, "The command to run on the infra container when the pod is started")
 	flags.StringSliceVar(&podCreateCommand.LabelFile, "label-file", []string{}, "Read in a line delimited file of labels")
 	flags.StringSliceVarP(&podCreateCommand.Labels, "label", "l", []string{}, "Set metadata on pod (default [])")
+	flags.StringVarP(&podCreateCommand.Memory, "memory", "m", "", "Memory limit of the containers of the pod together (format: <number>[<unit>], where unit = b, k, m or g)")
 	flags.StringVarP(&podCreateCommand.Name, "name", "n", "", "Assign a name to the pod")
+	flags.BoolVar(&podCreateCommand.NoHosts, "no-hosts", false, "Do not create /etc/hosts within the pod, instead use the version from the image")
+	flags.Int64Var(&podCreateCommand.PidsLimit, "pids-limit", 0, "Limit the number of processes the containers of the pod may run together (set 0 for no limit)")
 	flags.StringVar(&podCreateCommand.PodIDFile, "pod-id-file", "", "Write the pod ID to the file")
 	flags.StringSliceVarP(&podCreateCommand.Publish, "publish", "p", []string{}, "Publish a container's port, or a range of ports, to the host (default [])")
 	flags.StringVar(&podCreateCommand.Share, "share", shared.DefaultKernelNamespaces, "A comma delimited list of kernel namespaces the pod will share")
+	flags.StringArrayVarP(&podCreateCommand.Volume, "volume", "v", []string{}, "Mount a named volume into every container of the pod (format: NAME:CONTAINER-DIR[:OPTIONS])")
 
 }
 func podCreateCmd(c *cliconfig.PodCreateValues) error {
@@ -75,6 +85,10 @@ func podCreateCmd(c *cliconfig.PodCreateValues) error {
 		}
 	}
 
+	if !c.Infra && (c.Flag("dns").Changed || c.Flag("dns-search").Changed || c.Flag("add-host").Changed || c.Flag("no-hosts").Changed || c.Flag("hostname").Changed) {
+		return errors.Errorf("you must have an infra container to set the hostname, DNS or hosts configuration of the pod")
+	}
+
 	if !c.Infra && c.Flag("share").Changed && c.Share != "none" && c.Share != "" {
 		return errors.Errorf("You cannot share kernel namespaces on the pod level without an infra container")
 	}
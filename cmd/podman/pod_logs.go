@@ -0,0 +1,88 @@
+package main
+
+import (
+	"os"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/libpod/logs"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var (
+	podLogsCommand     cliconfig.PodLogsValues
+	podLogsDescription = `Retrieves the logs of the containers in a pod.
+
+  The lines of the containers are merged in the order they were logged and prefixed with the name of their container.  When following, containers added to the pod are followed as well.
+`
+	_podLogsCommand = &cobra.Command{
+		Use:   "logs [flags] POD",
+		Short: "Fetch the logs of the containers in a pod",
+		Long:  podLogsDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			podLogsCommand.InputArgs = args
+			podLogsCommand.GlobalFlags = MainGlobalOpts
+			podLogsCommand.Remote = remoteclient
+			return podLogsCmd(&podLogsCommand)
+		},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateLogStream(podLogsCommand.Stream); err != nil {
+				return err
+			}
+			if len(args) > 0 && podLogsCommand.Latest {
+				return errors.New("no pods can be specified when using 'latest'")
+			}
+			if !podLogsCommand.Latest && len(args) != 1 {
+				return errors.New("specify the name or ID of a pod to log")
+			}
+			return nil
+		},
+		Example: `podman pod logs podID
+  podman pod logs --tail 2 mypod
+  podman pod logs --follow --since 10m mypod`,
+	}
+)
+
+func init() {
+	podLogsCommand.Command = _podLogsCommand
+	podLogsCommand.SetHelpTemplate(HelpTemplate())
+	podLogsCommand.SetUsageTemplate(UsageTemplate())
+	flags := podLogsCommand.Flags()
+	flags.BoolVarP(&podLogsCommand.Follow, "follow", "f", false, "Follow log output.  The default is false")
+	flags.BoolVarP(&podLogsCommand.Latest, "latest", "l", false, "Act on the latest pod podman is aware of")
+	flags.StringVar(&podLogsCommand.Since, "since", "", "Show logs since TIMESTAMP")
+	flags.StringVar(&podLogsCommand.Until, "until", "", "Show logs until TIMESTAMP")
+	flags.StringVar(&podLogsCommand.Stream, "stream", "", "Only show logs of the containers' stdout or stderr")
+	flags.Uint64Var(&podLogsCommand.Tail, "tail", 0, "Output the specified number of LINES at the end of the logs of each container.  Defaults to 0, which prints all lines")
+	flags.BoolVarP(&podLogsCommand.Timestamps, "timestamps", "t", false, "Output the timestamps in the log")
+	flags.SetInterspersed(false)
+
+	markFlagHiddenForRemoteClient("latest", flags)
+}
+
+func podLogsCmd(c *cliconfig.PodLogsValues) error {
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	sinceTime, untilTime, err := parseLogTimes(&c.PodmanCommand, c.Since, c.Until)
+	if err != nil {
+		return err
+	}
+
+	options := &logs.LogOptions{
+		Follow:     c.Follow,
+		Since:      sinceTime,
+		Until:      untilTime,
+		Stream:     c.Stream,
+		Tail:       c.Tail,
+		Timestamps: c.Timestamps,
+		Multi:      true,
+		Color:      terminal.IsTerminal(int(os.Stdout.Fd())),
+	}
+	return runtime.PodLog(c, options)
+}
@@ -46,6 +46,10 @@ func runCmd(c *cliconfig.RunValues) error {
 		defer span.Finish()
 	}
 
+	if c.Bool("init-ctr") {
+		return errors.Errorf("init containers are started with their pod, use podman create --init-ctr instead")
+	}
+
 	if err := createInit(&c.PodmanCommand); err != nil {
 		return err
 	}
@@ -809,36 +809,64 @@ func envSliceToMap(env []string) map[string]string {
 	return m
 }
 
-// GenerateKube generates kubernetes yaml based on a pod or container
-func GenerateKube(name string, service bool, r *libpod.Runtime) (*v1.Pod, *v1.Service, error) {
+// KubeObjects holds the kubernetes objects generated from containers and pods
+type KubeObjects struct {
+	Pods     []*v1.Pod
+	Services []*v1.Service
+	Claims   []*v1.PersistentVolumeClaim
+}
+
+// GenerateKube generates kubernetes pods for the given containers and pods,
+// persistent volume claims for the named volumes they use and optionally
+// services for the pods
+func GenerateKube(names []string, service bool, r *libpod.Runtime) (*KubeObjects, error) {
 	var (
-		pod          *libpod.Pod
-		podYAML      *v1.Pod
-		err          error
-		container    *libpod.Container
-		servicePorts []v1.ServicePort
-		serviceYAML  v1.Service
+		containers []*libpod.Container
+		objects    KubeObjects
 	)
-	// Get the container in question.
-	container, err = r.LookupContainer(name)
-	if err != nil {
-		pod, err = r.LookupPod(name)
+	for _, name := range names {
+		var (
+			podYAML      *v1.Pod
+			servicePorts []v1.ServicePort
+		)
+		// Get the container in question.
+		container, err := r.LookupContainer(name)
 		if err != nil {
-			return nil, nil, err
+			pod, err := r.LookupPod(name)
+			if err != nil {
+				return nil, err
+			}
+			podYAML, servicePorts, err = pod.GenerateForKube()
+			if err != nil {
+				return nil, err
+			}
+			podContainers, err := pod.AllContainers()
+			if err != nil {
+				return nil, err
+			}
+			containers = append(containers, podContainers...)
+		} else {
+			if len(container.Dependencies()) > 0 {
+				return nil, errors.Wrapf(define.ErrNotImplemented, "containers with dependencies")
+			}
+			podYAML, err = container.GenerateForKube()
+			if err != nil {
+				return nil, err
+			}
+			containers = append(containers, container)
 		}
-		podYAML, servicePorts, err = pod.GenerateForKube()
-	} else {
-		if len(container.Dependencies()) > 0 {
-			return nil, nil, errors.Wrapf(define.ErrNotImplemented, "containers with dependencies")
+
+		objects.Pods = append(objects.Pods, podYAML)
+		if service {
+			serviceYAML := libpod.GenerateKubeServiceFromV1Pod(podYAML, servicePorts)
+			objects.Services = append(objects.Services, &serviceYAML)
 		}
-		podYAML, err = container.GenerateForKube()
-	}
-	if err != nil {
-		return nil, nil, err
 	}
 
-	if service {
-		serviceYAML = libpod.GenerateKubeServiceFromV1Pod(podYAML, servicePorts)
+	claims, err := libpod.GenerateKubeClaims(containers)
+	if err != nil {
+		return nil, err
 	}
-	return podYAML, &serviceYAML, nil
+	objects.Claims = claims
+	return &objects, nil
 }
@@ -17,11 +17,13 @@ import (
 	"github.com/containers/libpod/libpod"
 	"github.com/containers/libpod/libpod/image"
 	ann "github.com/containers/libpod/pkg/annotations"
+	"github.com/containers/libpod/pkg/autoupdate"
 	"github.com/containers/libpod/pkg/errorhandling"
 	"github.com/containers/libpod/pkg/inspect"
 	ns "github.com/containers/libpod/pkg/namespaces"
 	"github.com/containers/libpod/pkg/rootless"
 	cc "github.com/containers/libpod/pkg/spec"
+	"github.com/containers/libpod/pkg/systemdgen"
 	"github.com/containers/libpod/pkg/util"
 	"github.com/docker/docker/pkg/signal"
 	"github.com/docker/go-connections/nat"
@@ -158,6 +160,10 @@ func CreateContainer(ctx context.Context, c *GenericCLIResults, runtime *libpod.
 	// Because parseCreateOpts does derive anything from the image, we add health check
 	// at this point. The rest is done by WithOptions.
 	createConfig.HealthCheck = healthCheck
+	createConfig.HealthOnFailure = c.String("healthcheck-on-failure")
+	if healthCheck == nil && createConfig.HealthOnFailure != "" && createConfig.HealthOnFailure != libpod.HealthCheckOnFailureActionNone {
+		return nil, nil, errors.Errorf("--health-on-failure requires a health check")
+	}
 
 	// TODO: Should be able to return this from ParseCreateOpts
 	var pod *libpod.Pod
@@ -306,6 +312,11 @@ func configurePod(c *GenericCLIResults, runtime *libpod.Runtime, namespaces map[
 	}
 	if (namespaces["net"] == cc.Pod) || (!c.IsSet("net") && !c.IsSet("network") && pod.SharesNet()) {
 		namespaces["net"] = fmt.Sprintf("container:%s", podInfraID)
+		// The pod's resolv.conf is shared by all containers joining
+		// its network namespace
+		if c.IsSet("dns") || c.IsSet("dns-opt") || c.IsSet("dns-search") {
+			logrus.Warnf("DNS options are ignored for containers joining the network namespace of pod %s, set them on the pod instead", pod.Name())
+		}
 	}
 	if hasUserns && (namespaces["user"] == cc.Pod) || (!c.IsSet("user") && pod.SharesUser()) {
 		namespaces["user"] = fmt.Sprintf("container:%s", podInfraID)
@@ -433,6 +444,9 @@ func ParseCreateOpts(ctx context.Context, c *GenericCLIResults, runtime *libpod.
 	if len(podName) < 1 && c.IsSet("pod") {
 		return nil, errors.Errorf("new pod name must be at least one character")
 	}
+	if c.Bool("init-ctr") && !c.IsSet("pod") {
+		return nil, errors.Errorf("--init-ctr requires --pod")
+	}
 	if c.IsSet("pod") {
 		if strings.HasPrefix(originalPodName, "new:") {
 			// pod does not exist; lets make it
@@ -557,6 +571,27 @@ func ParseCreateOpts(ctx context.Context, c *GenericCLIResults, runtime *libpod.
 		}
 	}
 
+	// Containers created within a systemd unit remember the unit, so
+	// `podman auto-update` knows which unit to restart.
+	if systemdUnit, exists := os.LookupEnv(systemdgen.EnvVariable); exists {
+		if _, ok := labels[systemdgen.EnvVariable]; !ok {
+			labels[systemdgen.EnvVariable] = systemdUnit
+		}
+	}
+	// Auto updates must be able to look up the image in a registry, so
+	// short names, which may resolve to different registries, are refused.
+	if value, exists := labels[autoupdate.Label]; exists {
+		policy, err := autoupdate.LookupPolicy(value)
+		if err != nil {
+			return nil, err
+		}
+		if policy == autoupdate.PolicyNewImage {
+			if err := autoupdate.ValidateImageReference(c.InputArgs[0]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// ANNOTATIONS
 	annotations := make(map[string]string)
 	// First, add our default annotations
@@ -684,6 +719,7 @@ func ParseCreateOpts(ctx context.Context, c *GenericCLIResults, runtime *libpod.
 		Annotations:       annotations,
 		BuiltinImgVolumes: ImageVolumes,
 		ConmonPidFile:     c.String("conmon-pidfile"),
+		CreateCommand:     c.CreateCommand,
 		ImageVolumeType:   c.String("image-volume"),
 		CapAdd:            c.StringSlice("cap-add"),
 		CapDrop:           c.StringSlice("cap-drop"),
@@ -707,6 +743,7 @@ func ParseCreateOpts(ctx context.Context, c *GenericCLIResults, runtime *libpod.
 		NoHosts:     c.Bool("no-hosts"),
 		IDMappings:  idmappings,
 		Init:        c.Bool("init"),
+		InitCtr:     c.Bool("init-ctr"),
 		InitPath:    c.String("init-path"),
 		Image:       imageName,
 		ImageID:     imageID,
@@ -0,0 +1,136 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containers/libpod/libpod"
+	"github.com/containers/libpod/pkg/systemdgen"
+	"github.com/pkg/errors"
+)
+
+// GenerateSystemdOptions control how systemd units are generated
+type GenerateSystemdOptions struct {
+	// Files writes the units to files in the current working directory
+	// instead of returning their content
+	Files bool
+	// Name uses the names of containers and pods instead of their IDs
+	Name bool
+	// New creates a new container from the command the container has
+	// been created with on each start of the unit and removes it on stop
+	New bool
+	// RestartPolicy is the systemd restart policy of the units
+	RestartPolicy string
+	// StopTimeout overrides the stop timeout of the containers unless it
+	// is negative
+	StopTimeout int
+}
+
+// GenerateSystemd generates the systemd unit of a container or the units of
+// a pod and its containers.  It returns the content of the units or, if
+// files are generated, their paths.
+func GenerateSystemd(r *libpod.Runtime, nameOrID string, options GenerateSystemdOptions) (string, error) {
+	if err := systemdgen.ValidateRestartPolicy(options.RestartPolicy); err != nil {
+		return "", err
+	}
+	ctr, ctrErr := r.LookupContainer(nameOrID)
+	if ctrErr == nil {
+		info, err := containerSystemdInfo(ctr, options)
+		if err != nil {
+			return "", err
+		}
+		return systemdgen.CreateContainerSystemdUnit(info, options.Files)
+	}
+	pod, err := r.LookupPod(nameOrID)
+	if err != nil {
+		return "", errors.Wrapf(ctrErr, "%s does not refer to a container or pod", nameOrID)
+	}
+	if options.New {
+		return "", errors.New("creating new containers is not supported for pods")
+	}
+	return generatePodSystemd(r, pod, options)
+}
+
+// generatePodSystemd generates a unit for the pod, which drives the infra
+// container, and a unit for each other container of the pod that is bound
+// to it
+func generatePodSystemd(r *libpod.Runtime, pod *libpod.Pod, options GenerateSystemdOptions) (string, error) {
+	infraID, err := pod.InfraContainerID()
+	if err != nil {
+		return "", err
+	}
+	if infraID == "" {
+		return "", errors.Errorf("pod %s has no infra container", pod.Name())
+	}
+	infra, err := r.LookupContainer(infraID)
+	if err != nil {
+		return "", errors.Wrapf(err, "error looking up infra container of pod %s", pod.Name())
+	}
+	podInfo, err := containerSystemdInfo(infra, options)
+	if err != nil {
+		return "", err
+	}
+	podInfo.PodName = pod.ID()
+	if options.Name {
+		podInfo.PodName = pod.Name()
+	}
+	podInfo.ServiceName = fmt.Sprintf("pod-%s", podInfo.PodName)
+
+	ctrs, err := pod.AllContainers()
+	if err != nil {
+		return "", err
+	}
+	var ctrInfos []*systemdgen.ContainerInfo
+	for _, ctr := range ctrs {
+		if ctr.ID() == infraID {
+			continue
+		}
+		info, err := containerSystemdInfo(ctr, options)
+		if err != nil {
+			return "", err
+		}
+		info.BoundToServices = []string{podInfo.ServiceName}
+		podInfo.RequiredServices = append(podInfo.RequiredServices, info.ServiceName)
+		ctrInfos = append(ctrInfos, info)
+	}
+
+	var units []string
+	for _, info := range append([]*systemdgen.ContainerInfo{podInfo}, ctrInfos...) {
+		unit, err := systemdgen.CreateContainerSystemdUnit(info, options.Files)
+		if err != nil {
+			return "", err
+		}
+		units = append(units, unit)
+	}
+	return strings.Join(units, "\n"), nil
+}
+
+// containerSystemdInfo returns the data needed to generate the unit of a
+// container
+func containerSystemdInfo(ctr *libpod.Container, options GenerateSystemdOptions) (*systemdgen.ContainerInfo, error) {
+	timeout := int(ctr.StopTimeout())
+	if options.StopTimeout >= 0 {
+		timeout = options.StopTimeout
+	}
+	name := ctr.ID()
+	if options.Name {
+		name = ctr.Name()
+	}
+	config := ctr.Config()
+	if options.New {
+		if len(config.CreateCommand) == 0 {
+			return nil, errors.Errorf("container %s has no create command recorded, it may have been created remotely, through the varlink API or by an older version of podman", name)
+		}
+	} else if config.ConmonPidFile == "" {
+		return nil, errors.Errorf("conmon PID file path of container %s is empty, try to recreate the container with --conmon-pidfile flag", name)
+	}
+	return &systemdgen.ContainerInfo{
+		ServiceName:   fmt.Sprintf("container-%s", name),
+		ContainerName: name,
+		RestartPolicy: options.RestartPolicy,
+		PIDFile:       config.ConmonPidFile,
+		StopTimeout:   timeout,
+		CreateCommand: config.CreateCommand,
+		New:           options.New,
+	}, nil
+}
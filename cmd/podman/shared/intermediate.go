@@ -1,6 +1,8 @@
 package shared
 
 import (
+	"os"
+
 	"github.com/containers/libpod/cmd/podman/cliconfig"
 	"github.com/sirupsen/logrus"
 )
@@ -89,6 +91,10 @@ type createResult struct {
 type GenericCLIResults struct {
 	results   map[string]GenericCLIResult
 	InputArgs []string
+	// CreateCommand is the command line the container is created with.
+	// It is only known when the container is created locally with the
+	// podman create or podman run command.
+	CreateCommand []string
 }
 
 // IsSet returns a bool if the flag was changed
@@ -403,6 +409,7 @@ func NewIntermediateLayer(c *cliconfig.PodmanCommand, remote bool) GenericCLIRes
 	m["help"] = newCRBool(c, "help")
 	m["healthcheck-command"] = newCRString(c, "health-cmd")
 	m["healthcheck-interval"] = newCRString(c, "health-interval")
+	m["healthcheck-on-failure"] = newCRString(c, "health-on-failure")
 	m["healthcheck-retries"] = newCRUint(c, "health-retries")
 	m["healthcheck-start-period"] = newCRString(c, "health-start-period")
 	m["healthcheck-timeout"] = newCRString(c, "health-timeout")
@@ -410,6 +417,7 @@ func NewIntermediateLayer(c *cliconfig.PodmanCommand, remote bool) GenericCLIRes
 	m["http-proxy"] = newCRBool(c, "http-proxy")
 	m["image-volume"] = newCRString(c, "image-volume")
 	m["init"] = newCRBool(c, "init")
+	m["init-ctr"] = newCRBool(c, "init-ctr")
 	m["init-path"] = newCRString(c, "init-path")
 	m["interactive"] = newCRBool(c, "interactive")
 	m["ip"] = newCRString(c, "ip")
@@ -468,5 +476,11 @@ func NewIntermediateLayer(c *cliconfig.PodmanCommand, remote bool) GenericCLIRes
 		m["syslog"] = newCRBool(c, "syslog")
 	}
 
-	return GenericCLIResults{m, c.InputArgs}
+	// Only the command line of a local create or run command is recorded,
+	// so a new container can be created from it again
+	var createCommand []string
+	if !remote && c.Command != nil && (c.Command.Name() == "create" || c.Command.Name() == "run") {
+		createCommand = os.Args
+	}
+	return GenericCLIResults{m, c.InputArgs, createCommand}
 }
@@ -106,12 +106,14 @@ func (g GenericCLIResults) MakeVarlink() iopodman.Create {
 		Groupadd:               StringSliceToPtr(g.Find("group-add")),
 		HealthcheckCommand:     StringToPtr(g.Find("healthcheck-command")),
 		HealthcheckInterval:    StringToPtr(g.Find("healthcheck-interval")),
+		HealthcheckOnFailure:   StringToPtr(g.Find("healthcheck-on-failure")),
 		HealthcheckRetries:     AnyIntToInt64Ptr(g.Find("healthcheck-retries")),
 		HealthcheckStartPeriod: StringToPtr(g.Find("healthcheck-start-period")),
 		HealthcheckTimeout:     StringToPtr(g.Find("healthcheck-timeout")),
 		Hostname:               StringToPtr(g.Find("hostname")),
 		ImageVolume:            StringToPtr(g.Find("image-volume")),
 		Init:                   BoolToPtr(g.Find("init")),
+		InitCtr:                BoolToPtr(g.Find("init-ctr")),
 		InitPath:               StringToPtr(g.Find("init-path")),
 		Interactive:            BoolToPtr(g.Find("interactive")),
 		Ip:                     StringToPtr(g.Find("ip")),
@@ -361,12 +363,14 @@ func VarlinkCreateToGeneric(opts iopodman.Create) GenericCLIResults {
 	m["group-add"] = stringSliceFromVarlink(opts.Groupadd, "group-add", nil)
 	m["healthcheck-command"] = stringFromVarlink(opts.HealthcheckCommand, "healthcheck-command", nil)
 	m["healthcheck-interval"] = stringFromVarlink(opts.HealthcheckInterval, "healthcheck-interval", &cliconfig.DefaultHealthCheckInterval)
+	m["healthcheck-on-failure"] = stringFromVarlink(opts.HealthcheckOnFailure, "healthcheck-on-failure", &cliconfig.DefaultHealthCheckOnFailure)
 	m["healthcheck-retries"] = uintFromVarlink(opts.HealthcheckRetries, "healthcheck-retries", &cliconfig.DefaultHealthCheckRetries)
 	m["healthcheck-start-period"] = stringFromVarlink(opts.HealthcheckStartPeriod, "healthcheck-start-period", &cliconfig.DefaultHealthCheckStartPeriod)
 	m["healthcheck-timeout"] = stringFromVarlink(opts.HealthcheckTimeout, "healthcheck-timeout", &cliconfig.DefaultHealthCheckTimeout)
 	m["hostname"] = stringFromVarlink(opts.Hostname, "hostname", nil)
 	m["image-volume"] = stringFromVarlink(opts.ImageVolume, "image-volume", &cliconfig.DefaultImageVolume)
 	m["init"] = boolFromVarlink(opts.Init, "init", false)
+	m["init-ctr"] = boolFromVarlink(opts.InitCtr, "init-ctr", false)
 	m["init-path"] = stringFromVarlink(opts.InitPath, "init-path", nil)
 	m["interactive"] = boolFromVarlink(opts.Interactive, "interactive", false)
 	m["ip"] = stringFromVarlink(opts.Ip, "ip", nil)
@@ -420,7 +424,7 @@ func VarlinkCreateToGeneric(opts iopodman.Create) GenericCLIResults {
 	m["volumes-from"] = stringSliceFromVarlink(opts.VolumesFrom, "volumes-from", nil)
 	m["workdir"] = stringFromVarlink(opts.WorkDir, "workdir", nil)
 
-	gcli := GenericCLIResults{m, opts.Args}
+	gcli := GenericCLIResults{m, opts.Args, nil}
 	return gcli
 }
 
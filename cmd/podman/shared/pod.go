@@ -2,11 +2,16 @@ package shared
 
 import (
 	"strconv"
+	"strings"
 
+	"github.com/containers/libpod/cmd/podman/shared/parse"
 	"github.com/containers/libpod/libpod"
 	"github.com/containers/libpod/libpod/define"
+	cc "github.com/containers/libpod/pkg/spec"
+	"github.com/containers/libpod/pkg/util"
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
 	"github.com/pkg/errors"
 )
 
@@ -105,6 +110,91 @@ func GetNamespaceOptions(ns []string) ([]libpod.PodCreateOption, error) {
 	return options, nil
 }
 
+// GetPodResourceOptions transforms the resource limits of a pod into a slice
+// of pod create options. Limits that are not set have their zero value.
+func GetPodResourceOptions(cpus float64, memory string, pidsLimit int64, blkioWeight string) ([]libpod.PodCreateOption, error) {
+	var options []libpod.PodCreateOption
+	if cpus != 0 {
+		options = append(options, libpod.WithPodCPUs(cpus))
+	}
+	if memory != "" {
+		memoryLimit, err := units.RAMInBytes(memory)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for memory")
+		}
+		options = append(options, libpod.WithPodMemory(memoryLimit))
+	}
+	if pidsLimit != 0 {
+		options = append(options, libpod.WithPodPidsLimit(pidsLimit))
+	}
+	if blkioWeight != "" {
+		weight, err := strconv.ParseUint(blkioWeight, 10, 16)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for blkio-weight")
+		}
+		options = append(options, libpod.WithPodBlkioWeight(uint16(weight)))
+	}
+	return options, nil
+}
+
+// GetPodNetworkOptions transforms the hostname, DNS and hosts configuration
+// of a pod into a slice of pod create options.
+func GetPodNetworkOptions(hostname string, dnsServers, dnsSearch, addHosts []string, noHosts bool) ([]libpod.PodCreateOption, error) {
+	var options []libpod.PodCreateOption
+	if hostname != "" {
+		options = append(options, libpod.WithPodHostname(hostname))
+	}
+	if len(dnsServers) > 0 {
+		if len(dnsServers) == 1 && strings.ToLower(dnsServers[0]) == "none" {
+			options = append(options, libpod.WithPodUseImageResolvConf())
+		} else {
+			options = append(options, libpod.WithPodDNS(dnsServers))
+		}
+	}
+	if len(dnsSearch) > 0 {
+		if util.StringInSlice(".", dnsSearch) && len(dnsSearch) > 1 {
+			return nil, errors.Errorf("cannot pass additional search domains when also specifying '.'")
+		}
+		for _, dom := range dnsSearch {
+			if dom == "." {
+				continue
+			}
+			if _, err := parse.ValidateDomain(dom); err != nil {
+				return nil, err
+			}
+		}
+		options = append(options, libpod.WithPodDNSSearch(dnsSearch))
+	}
+	if noHosts && len(addHosts) > 0 {
+		return nil, errors.Errorf("--no-hosts and --add-host cannot be set together")
+	}
+	if noHosts {
+		options = append(options, libpod.WithPodUseImageHosts())
+	}
+	if len(addHosts) > 0 {
+		for _, host := range addHosts {
+			if _, err := parse.ValidateExtraHost(host); err != nil {
+				return nil, err
+			}
+		}
+		options = append(options, libpod.WithPodHosts(addHosts))
+	}
+	return options, nil
+}
+
+// GetPodVolumeOptions transforms the named volumes of a pod, given in the
+// format of the --volume option, into a pod create option
+func GetPodVolumeOptions(volumes []string) ([]libpod.PodCreateOption, error) {
+	if len(volumes) == 0 {
+		return nil, nil
+	}
+	namedVolumes, err := cc.ParsePodVolumes(volumes)
+	if err != nil {
+		return nil, err
+	}
+	return []libpod.PodCreateOption{libpod.WithPodVolumes(namedVolumes)}, nil
+}
+
 // CreatePortBindings iterates ports mappings and exposed ports into a format CNI understands
 func CreatePortBindings(ports []string) ([]ocicni.PortMapping, error) {
 	var portBindings []ocicni.PortMapping
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/cmd/podman/libpodruntime"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bootCommand     cliconfig.SystemBootValues
+	bootDescription = `
+        podman system boot
+
+        Start all containers with the always restart policy, and containers with
+        the unless-stopped restart policy which were not stopped by the user.
+        Intended to be run by a systemd unit when the system boots.
+`
+
+	_bootCommand = &cobra.Command{
+		Use:   "boot",
+		Args:  noSubArgs,
+		Short: "Start containers with a restart policy after boot",
+		Long:  bootDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bootCommand.InputArgs = args
+			bootCommand.GlobalFlags = MainGlobalOpts
+			bootCommand.Remote = remoteclient
+			return bootCmd(&bootCommand)
+		},
+	}
+)
+
+func init() {
+	bootCommand.Command = _bootCommand
+	bootCommand.SetHelpTemplate(HelpTemplate())
+	bootCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func bootCmd(c *cliconfig.SystemBootValues) error {
+	runtime, err := libpodruntime.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "could not get runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	started, ctrErrors, err := runtime.StartRestartPolicyContainers(getContext())
+	if err != nil {
+		return err
+	}
+	for _, id := range started {
+		fmt.Println(id)
+	}
+	if len(ctrErrors) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(ctrErrors))
+	for id := range ctrErrors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	// Report every failure, and return the last one
+	for _, id := range ids[:len(ids)-1] {
+		logrus.Errorf("error starting container %s: %v", id, ctrErrors[id])
+	}
+	lastID := ids[len(ids)-1]
+	return errors.Wrapf(ctrErrors[lastID], "error starting container %s", lastID)
+}
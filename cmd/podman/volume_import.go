@@ -0,0 +1,53 @@
+package main
+
+import (
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/cmd/podman/shared/parse"
+	"github.com/containers/libpod/pkg/adapter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	volumeImportCommand     cliconfig.VolumeImportValues
+	volumeImportDescription = `Imports the contents of a tar archive into a volume.
+
+  The files are added to the volume, replacing existing files with the same name.
+  Ownership and extended attributes recorded in the archive are restored.`
+	_volumeImportCommand = &cobra.Command{
+		Use:   "import VOLUME FILE",
+		Short: "Import a tar archive into a volume",
+		Long:  volumeImportDescription,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			volumeImportCommand.InputArgs = args
+			volumeImportCommand.GlobalFlags = MainGlobalOpts
+			volumeImportCommand.Remote = remoteclient
+			return volumeImportCmd(&volumeImportCommand)
+		},
+		Example: `podman volume import myvol myvol.tar`,
+	}
+)
+
+func init() {
+	volumeImportCommand.Command = _volumeImportCommand
+	volumeImportCommand.SetHelpTemplate(HelpTemplate())
+	volumeImportCommand.SetUsageTemplate(UsageTemplate())
+}
+
+func volumeImportCmd(c *cliconfig.VolumeImportValues) error {
+	args := c.InputArgs
+	if len(args) != 2 {
+		return errors.Errorf("a volume and a tar archive must be specified")
+	}
+	if err := parse.ValidateFileName(args[1]); err != nil {
+		return err
+	}
+
+	runtime, err := adapter.GetRuntime(getContext(), &c.PodmanCommand)
+	if err != nil {
+		return errors.Wrapf(err, "error creating libpod runtime")
+	}
+	defer runtime.DeferredShutdown(false)
+
+	return runtime.ImportVolume(args[0], args[1])
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/containers/buildah/pkg/formats"
 	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/libpod"
 	"github.com/containers/libpod/pkg/adapter"
+	"github.com/docker/go-units"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -25,16 +27,19 @@ type volumeLsTemplateParams struct {
 	Driver     string
 	Options    string
 	Scope      string
+	Quota      string
+	QuotaUsed  string
 }
 
 // volumeLsJSONParams is the JSON parameters to list the volumes
 type volumeLsJSONParams struct {
-	Name       string            `json:"name"`
-	Labels     map[string]string `json:"labels"`
-	MountPoint string            `json:"mountPoint"`
-	Driver     string            `json:"driver"`
-	Options    map[string]string `json:"options"`
-	Scope      string            `json:"scope"`
+	Name       string              `json:"name"`
+	Labels     map[string]string   `json:"labels"`
+	MountPoint string              `json:"mountPoint"`
+	Driver     string              `json:"driver"`
+	Options    map[string]string   `json:"options"`
+	Scope      string              `json:"scope"`
+	Quota      *libpod.VolumeQuota `json:"quota,omitempty"`
 }
 
 var (
@@ -195,6 +200,13 @@ func getVolTemplateOutput(lsParams []volumeLsJSONParams, opts volumeLsOptions) (
 			Labels:     labels,
 			Options:    options,
 		}
+		if lsParam.Quota != nil {
+			params.Quota = units.HumanSize(float64(lsParam.Quota.Size))
+			params.QuotaUsed = "unknown"
+			if lsParam.Quota.Used != nil {
+				params.QuotaUsed = units.HumanSize(float64(*lsParam.Quota.Used))
+			}
+		}
 
 		lsOutput = append(lsOutput, params)
 	}
@@ -202,10 +214,14 @@ func getVolTemplateOutput(lsParams []volumeLsJSONParams, opts volumeLsOptions) (
 }
 
 // getVolJSONParams returns the volumes in JSON format
-func getVolJSONParams(volumes []*adapter.Volume) []volumeLsJSONParams {
+func getVolJSONParams(volumes []*adapter.Volume) ([]volumeLsJSONParams, error) {
 	var lsOutput []volumeLsJSONParams
 
 	for _, volume := range volumes {
+		quota, err := volume.Quota()
+		if err != nil {
+			return nil, err
+		}
 		params := volumeLsJSONParams{
 			Name:       volume.Name(),
 			Labels:     volume.Labels(),
@@ -213,11 +229,12 @@ func getVolJSONParams(volumes []*adapter.Volume) []volumeLsJSONParams {
 			Driver:     volume.Driver(),
 			Options:    volume.Options(),
 			Scope:      volume.Scope(),
+			Quota:      quota,
 		}
 
 		lsOutput = append(lsOutput, params)
 	}
-	return lsOutput
+	return lsOutput, nil
 }
 
 // generateVolLsOutput generates the output based on the format, JSON or Go Template, and prints it out
@@ -225,7 +242,10 @@ func generateVolLsOutput(volumes []*adapter.Volume, opts volumeLsOptions) error
 	if len(volumes) == 0 && opts.Format != formats.JSONString {
 		return nil
 	}
-	lsOutput := getVolJSONParams(volumes)
+	lsOutput, err := getVolJSONParams(volumes)
+	if err != nil {
+		return err
+	}
 	var out formats.Writer
 
 	switch opts.Format {
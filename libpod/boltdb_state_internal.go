@@ -10,6 +10,7 @@ import (
 	"github.com/containers/libpod/pkg/rootless"
 	"github.com/containers/storage"
 	bolt "github.com/etcd-io/bbolt"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -449,12 +450,45 @@ func (s *BoltState) getVolumeFromDB(name []byte, volume *Volume, volBkt *bolt.Bu
 		return errors.Wrapf(err, "error unmarshalling volume %s config from DB", string(name))
 	}
 
+	// Volumes created by older versions of libpod have no state
+	volume.state = new(VolumeState)
+	if volStateBytes := volDB.Get(stateKey); volStateBytes != nil {
+		if err := json.Unmarshal(volStateBytes, volume.state); err != nil {
+			return errors.Wrapf(err, "error unmarshalling volume %s state from DB", string(name))
+		}
+	}
+
+	// Volumes created by older versions of libpod have no lock, they are
+	// given one by podman system migrate
+	hasLock, err := volumeConfigHasLock(volConfigBytes)
+	if err != nil {
+		return errors.Wrapf(err, "error unmarshalling volume %s config from DB", string(name))
+	}
+	if hasLock {
+		lock, err := s.runtime.lockManager.RetrieveLock(volume.config.LockID)
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving lock for volume %s", string(name))
+		}
+		volume.lock = lock
+	}
+
 	volume.runtime = s.runtime
 	volume.valid = true
 
 	return nil
 }
 
+// volumeConfigHasLock checks whether a volume config in JSON form was
+// assigned a lock. A LockID of 0 is a valid lock, so the key itself is checked.
+func volumeConfigHasLock(configJSON []byte) (bool, error) {
+	fields := make(map[string]jsoniter.RawMessage)
+	if err := json.Unmarshal(configJSON, &fields); err != nil {
+		return false, err
+	}
+	_, ok := fields["lockID"]
+	return ok, nil
+}
+
 // Add a container to the DB
 // If pod is not nil, the container is added to the pod as well
 func (s *BoltState) addContainer(ctr *Container, pod *Pod) error {
@@ -41,6 +41,11 @@ const KubernetesLogging = "k8s-file"
 // JSONLogging is the string conmon expects when specifying to use the json logging format
 const JSONLogging = "json-file"
 
+// SyslogLogging is the log driver forwarding the log of a container to a
+// syslog endpoint. conmon writes the log to a k8s-file log file, whose lines
+// podman forwards.
+const SyslogLogging = "syslog"
+
 // DefaultWaitInterval is the default interval between container status checks
 // while waiting.
 const DefaultWaitInterval = 250 * time.Millisecond
@@ -104,6 +109,11 @@ const (
 	// RestartPolicyOnFailure restarts the container on non-0 exit code,
 	// with an optional maximum number of retries.
 	RestartPolicyOnFailure = "on-failure"
+	// RestartPolicyUnlessStopped unconditionally restarts the container,
+	// unless it was explicitly stopped by the user. Unlike
+	// RestartPolicyAlways, a container that was stopped before a reboot
+	// is not started again at boot.
+	RestartPolicyUnlessStopped = "unless-stopped"
 )
 
 // Container is a single OCI container.
@@ -181,6 +191,15 @@ type ContainerState struct {
 	// namespace for the container, and the network namespace is currently
 	// active
 	NetworkStatus []*cnitypes.Result `json:"networkResults,omitempty"`
+	// Networks are the CNI networks the container joins, once networks
+	// were connected or disconnected after it was created. If empty, the
+	// networks in the container's configuration are used.
+	Networks []string `json:"networks,omitempty"`
+	// NetInterfaces maps the CNI networks of the container to the
+	// interface created for them in the network namespace. It is only
+	// populated once networks were connected or disconnected while the
+	// namespace was active; otherwise the Nth network uses ethN.
+	NetInterfaces map[string]string `json:"netInterfaces,omitempty"`
 	// BindMounts contains files that will be bind-mounted into the
 	// container when it is mounted.
 	// These include /etc/hosts and /etc/resolv.conf
@@ -197,6 +216,31 @@ type ContainerState struct {
 	// restart policy. This is NOT incremented by normal container restarts
 	// (only by restart policy).
 	RestartCount uint `json:"restartCount,omitempty"`
+	// RestartBackoff is the delay that was waited before the last restart
+	// by the container's restart policy. It doubles with every restart
+	// of a container that exits shortly after being started, and is reset
+	// once the container ran long enough.
+	RestartBackoff time.Duration `json:"restartBackoff,omitempty"`
+	// RestartTime is when the container is restarted by its restart
+	// policy once it backed off. It is zero unless a restart is pending.
+	RestartTime time.Time `json:"restartTime,omitempty"`
+	// RestartHelperPID is the PID of the podman process restarting the
+	// container at RestartTime.
+	RestartHelperPID int `json:"restartHelperPid,omitempty"`
+	// HealthCheckScheduler is how the healthchecks of the container are
+	// run on their interval: by a systemd timer, or by a podman process
+	// when systemd timers are not available.
+	HealthCheckScheduler string `json:"healthCheckScheduler,omitempty"`
+	// HealthCheckSchedulerPID is the PID of the podman process running the
+	// healthchecks, if HealthCheckScheduler is "podman".
+	HealthCheckSchedulerPID int `json:"healthCheckSchedulerPid,omitempty"`
+	// LogRotatorPID is the PID of the podman process rotating the log
+	// file of the container, if it keeps more than one log file.
+	LogRotatorPID int `json:"logRotatorPid,omitempty"`
+	// LogForwarderPID is the PID of the podman process forwarding the log
+	// of the container to its syslog endpoint, if it uses the syslog log
+	// driver.
+	LogForwarderPID int `json:"logForwarderPid,omitempty"`
 
 	// ExtensionStageHooks holds hooks which will be executed by libpod
 	// and not delegated to the OCI runtime.
@@ -362,13 +406,34 @@ type ContainerConfig struct {
 	LogPath string `json:"logPath"`
 	// LogDriver driver for logs
 	LogDriver string `json:"logDriver"`
+	// LogSize is the maximum size of the log file in bytes. When it is
+	// reached, the log is rotated if LogFiles is greater than 1, and
+	// truncated otherwise. 0 uses the runtime's max_log_size.
+	LogSize int64 `json:"logSize,omitempty"`
+	// LogFiles is the maximum number of log files kept when rotating the
+	// log, including the one in use
+	LogFiles uint `json:"logFiles,omitempty"`
+	// LogCompress indicates rotated log files are compressed with gzip
+	LogCompress bool `json:"logCompress,omitempty"`
+	// LogSyslogAddress is the address of the syslog endpoint the log is
+	// forwarded to with the syslog log driver. Empty is the local syslog
+	// daemon.
+	LogSyslogAddress string `json:"logSyslogAddress,omitempty"`
+	// LogSyslogFacility is the syslog facility of the forwarded log lines.
+	// Empty is the daemon facility.
+	LogSyslogFacility string `json:"logSyslogFacility,omitempty"`
+	// LogSyslogTag is the application name of the forwarded log lines.
+	// Empty is the name of the container.
+	LogSyslogTag string `json:"logSyslogTag,omitempty"`
 	// File containing the conmon PID
 	ConmonPidFile string `json:"conmonPidFile,omitempty"`
 	// RestartPolicy indicates what action the container will take upon
 	// exiting naturally.
 	// Allowed options are "no" (take no action), "on-failure" (restart on
 	// non-zero exit code, up an a maximum of RestartRetries times),
-	// and "always" (always restart the container on any exit code).
+	// "always" (always restart the container on any exit code), and
+	// "unless-stopped" (as "always", but a container stopped by the user
+	// stays stopped across reboots).
 	// The empty string is treated as the default ("no")
 	RestartPolicy string `json:"restart_policy,omitempty"`
 	// RestartRetries indicates the number of attempts that will be made to
@@ -391,12 +456,24 @@ type ContainerConfig struct {
 	// IsInfra is a bool indicating whether this container is an infra container used for
 	// sharing kernel namespaces in a pod
 	IsInfra bool `json:"pause"`
+	// IsInitCtr indicates the container is an init container of its pod,
+	// which runs to completion before the other containers of the pod are
+	// started
+	IsInitCtr bool `json:"isInitCtr,omitempty"`
 
 	// Systemd tells libpod to setup the container in systemd mode
 	Systemd bool `json:"systemd"`
 
 	// HealthCheckConfig has the health check command and related timings
 	HealthCheckConfig *manifest.Schema2HealthConfig `json:"healthcheck"`
+	// HealthCheckOnFailureAction is the action taken when the container
+	// becomes unhealthy. Allowed values are "none", "kill", "restart" and
+	// "stop". The empty string is treated as "none".
+	HealthCheckOnFailureAction string `json:"healthcheckOnFailureAction,omitempty"`
+
+	// CreateCommand is the full command plus arguments of the process the
+	// container has been created with.
+	CreateCommand []string `json:"CreateCommand,omitempty"`
 }
 
 // ContainerNamedVolume is a named volume that will be mounted into the
@@ -723,6 +800,45 @@ func (c *Container) LogDriver() string {
 	return c.config.LogDriver
 }
 
+// LogSize returns the maximum size of the container's log file in bytes.
+// 0 means the runtime's default is used.
+func (c *Container) LogSize() int64 {
+	return c.config.LogSize
+}
+
+// LogFiles returns the maximum number of log files kept when rotating the
+// container's log
+func (c *Container) LogFiles() uint {
+	return c.config.LogFiles
+}
+
+// LogCompress returns whether rotated log files of the container are
+// compressed
+func (c *Container) LogCompress() bool {
+	return c.config.LogCompress
+}
+
+// LogSyslogAddress returns the address of the syslog endpoint the container's
+// log is forwarded to. Empty is the local syslog daemon.
+func (c *Container) LogSyslogAddress() string {
+	return c.config.LogSyslogAddress
+}
+
+// LogSyslogFacility returns the syslog facility of the container's forwarded
+// log lines
+func (c *Container) LogSyslogFacility() string {
+	return c.config.LogSyslogFacility
+}
+
+// LogSyslogTag returns the application name of the container's forwarded log
+// lines
+func (c *Container) LogSyslogTag() string {
+	if c.config.LogSyslogTag == "" {
+		return c.Name()
+	}
+	return c.config.LogSyslogTag
+}
+
 // RuntimeName returns the name of the runtime
 func (c *Container) RuntimeName() string {
 	return c.config.OCIRuntime
@@ -1123,6 +1239,11 @@ func (c *Container) IsInfra() bool {
 	return c.config.IsInfra
 }
 
+// IsInitCtr returns whether the container is an init container of its pod
+func (c *Container) IsInitCtr() bool {
+	return c.config.IsInitCtr
+}
+
 // IsReadOnly returns whether the container is running in read only mode
 func (c *Container) IsReadOnly() bool {
 	return c.config.Spec.Root.Readonly
@@ -1178,6 +1299,12 @@ func (c *Container) HasHealthCheck() bool {
 	return c.config.HealthCheckConfig != nil
 }
 
+// HealthCheckOnFailureAction returns the action taken when the container
+// becomes unhealthy.
+func (c *Container) HealthCheckOnFailureAction() string {
+	return c.config.HealthCheckOnFailureAction
+}
+
 // HealthCheckConfig returns the command and timing attributes of the health check
 func (c *Container) HealthCheckConfig() *manifest.Schema2HealthConfig {
 	return c.config.HealthCheckConfig
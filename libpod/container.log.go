@@ -1,25 +1,65 @@
 package libpod
 
 import (
+	"context"
 	"os"
+	"sync"
+	"time"
 
+	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/libpod/libpod/logs"
+	"github.com/hpcloud/tail"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
-// Log is a runtime function that can read one or more container logs.
+// logRotationInterval is how often the size of a container's log file is
+// checked for rotation
+const logRotationInterval = time.Second
+
+// Log is a runtime function that can read one or more container logs. The
+// lines of the containers are merged in the order they were logged.
 func (r *Runtime) Log(containers []*Container, options *logs.LogOptions, logChannel chan *logs.LogLine) error {
+	options.PrepareColors()
+	merger := logs.NewMerger(logChannel, options.Follow)
+	options.WaitGroup.Add(1)
+	go func() {
+		merger.Run()
+		options.WaitGroup.Done()
+	}()
+	defer merger.Close()
+
 	for _, ctr := range containers {
-		if err := ctr.ReadLog(options, logChannel); err != nil {
+		if err := ctr.mergeLog(options, merger); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// mergeLog reads the container's log as an input of the given merger
+func (c *Container) mergeLog(options *logs.LogOptions, merger *logs.Merger) error {
+	var wg sync.WaitGroup
+	ctrOptions := *options
+	ctrOptions.WaitGroup = &wg
+	logChannel := make(chan *logs.LogLine)
+	merger.Add(logChannel)
+	err := c.ReadLog(&ctrOptions, logChannel)
+	go func() {
+		wg.Wait()
+		close(logChannel)
+	}()
+	return err
+}
+
 // ReadLog reads a containers log based on the input options and returns loglines over a channel
 func (c *Container) ReadLog(options *logs.LogOptions, logChannel chan *logs.LogLine) error {
+	// There is nothing to follow once the until time passed
+	if options.Follow && !options.Until.IsZero() && time.Now().After(options.Until) {
+		notFollowing := *options
+		notFollowing.Follow = false
+		options = &notFollowing
+	}
 	// TODO Skip sending logs until journald logs can be read
 	// TODO make this not a magic string
 	if c.LogDriver() == JournaldLogging {
@@ -38,36 +78,134 @@ func (c *Container) readFromLogFile(options *logs.LogOptions, logChannel chan *l
 		return errors.Wrapf(err, "unable to read log file %s for %s ", c.ID(), c.LogPath())
 	}
 	options.WaitGroup.Add(1)
-	if len(tailLog) > 0 {
+
+	// Stop following once the until time passed
+	var (
+		tailLock      sync.Mutex
+		untilReached  bool
+		stopFollowing *time.Timer
+	)
+	if options.Follow && !options.Until.IsZero() {
+		stopFollowing = time.AfterFunc(time.Until(options.Until), func() {
+			tailLock.Lock()
+			defer tailLock.Unlock()
+			untilReached = true
+			if err := t.Stop(); err != nil {
+				logrus.Debugf("error stopping to follow log file %s: %v", c.LogPath(), err)
+			}
+		})
+	}
+
+	go func() {
+		// The lines of the rotated segments are sent along with the
+		// followed lines, as they may not fit in the channel
 		for _, nll := range tailLog {
 			nll.CID = c.ID()
-			if nll.Since(options.Since) {
+			nll.CName = c.Name()
+			if nll.Since(options.Since) && nll.Until(options.Until) {
 				logChannel <- nll
 			}
 		}
-	}
 
-	go func() {
 		var partial string
-		for line := range t.Lines {
-			nll, err := logs.NewLogLine(line.Text)
-			if err != nil {
-				logrus.Error(err)
-				continue
+		for {
+			for line := range t.Lines {
+				nll, err := logs.NewLogLine(line.Text)
+				if err != nil {
+					logrus.Error(err)
+					continue
+				}
+				if !nll.FromStream(options.Stream) {
+					continue
+				}
+				if nll.Partial() {
+					partial = partial + nll.Msg
+					continue
+				} else if !nll.Partial() && len(partial) > 1 {
+					nll.Msg = partial
+					partial = ""
+				}
+				nll.CID = c.ID()
+				nll.CName = c.Name()
+				if nll.Since(options.Since) && nll.Until(options.Until) {
+					logChannel <- nll
+				}
 			}
-			if nll.Partial() {
-				partial = partial + nll.Msg
-				continue
-			} else if !nll.Partial() && len(partial) > 1 {
-				nll.Msg = partial
-				partial = ""
-			}
-			nll.CID = c.ID()
-			if nll.Since(options.Since) {
-				logChannel <- nll
+			if !options.Follow || !c.followRotatedLog(&t, &tailLock, &untilReached) {
+				break
 			}
 		}
+		if stopFollowing != nil {
+			stopFollowing.Stop()
+		}
 		options.WaitGroup.Done()
 	}()
 	return nil
 }
+
+// followRotatedLog replaces the tail following the container's log file once
+// it stopped, because the log file was rotated or removed along with the
+// container. It returns false when following should stop.
+func (c *Container) followRotatedLog(t **tail.Tail, tailLock *sync.Mutex, untilReached *bool) bool {
+	tailLock.Lock()
+	defer tailLock.Unlock()
+	if *untilReached {
+		return false
+	}
+	rotated, err := logs.FollowRotatedLogFile(c.LogPath())
+	if err != nil {
+		if !os.IsNotExist(errors.Cause(err)) {
+			logrus.Errorf("unable to follow log file %s of container %s: %v", c.LogPath(), c.ID(), err)
+		}
+		return false
+	}
+	*t = rotated
+	return true
+}
+
+// RunLogRotation rotates the log file of the given container whenever it
+// reaches its maximum size, until the container stops. It is run in a
+// separate podman process started along with containers keeping more than
+// one log file.
+func (r *Runtime) RunLogRotation(ctx context.Context, nameOrID string) error {
+	ctr, err := r.LookupContainer(nameOrID)
+	if err != nil {
+		return errors.Wrapf(err, "unable to lookup %s to rotate its log", nameOrID)
+	}
+	if !ctr.rotatesLog() {
+		return errors.Wrapf(define.ErrInvalidArg, "container %s does not rotate its log", ctr.ID())
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logRotationInterval):
+		}
+
+		running, err := ctr.rotateLog(os.Getpid())
+		if err != nil {
+			if errors.Cause(err) == define.ErrNoSuchCtr || errors.Cause(err) == define.ErrCtrRemoved {
+				return nil
+			}
+			logrus.Errorf("error rotating log of container %s: %v", ctr.ID(), err)
+		}
+		if !running {
+			return nil
+		}
+	}
+}
+
+// RunLogForwarding forwards the lines the given container logs after since to
+// its syslog endpoint, until the container stops. It is run in a separate
+// podman process started along with containers using the syslog log driver.
+func (r *Runtime) RunLogForwarding(nameOrID string, since time.Time) error {
+	ctr, err := r.LookupContainer(nameOrID)
+	if err != nil {
+		return errors.Wrapf(err, "unable to lookup %s to forward its log", nameOrID)
+	}
+	if ctr.LogDriver() != SyslogLogging {
+		return errors.Wrapf(define.ErrInvalidArg, "container %s does not use the %s log driver", ctr.ID(), SyslogLogging)
+	}
+	return ctr.forwardLog(since, os.Getpid())
+}
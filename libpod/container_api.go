@@ -185,6 +185,15 @@ func (c *Container) StopWithTimeout(timeout uint) error {
 
 	if c.state.State == define.ContainerStateStopped ||
 		c.state.State == define.ContainerStateExited {
+		// A restart by the restart policy may be pending, stopping the
+		// container explicitly cancels it.
+		if c.state.RestartPolicyMatch {
+			c.state.RestartPolicyMatch = false
+			c.state.StoppedByUser = true
+			if err := c.save(); err != nil {
+				return err
+			}
+		}
 		return define.ErrCtrStopped
 	}
 
@@ -557,17 +566,11 @@ func (c *Container) Cleanup(ctx context.Context) error {
 	}
 
 	// Handle restart policy.
-	// Returns a bool indicating whether we actually restarted.
-	// If we did, don't proceed to cleanup - just exit.
-	didRestart, err := c.handleRestartPolicy(ctx)
-	if err != nil {
+	// A container to be restarted is cleaned up as well, it is restarted
+	// by a separate podman process once it backed off.
+	if err := c.handleRestartPolicy(); err != nil {
 		return err
 	}
-	if didRestart {
-		return nil
-	}
-
-	// If we didn't restart, we perform a normal cleanup
 
 	// Check if we have active exec sessions
 	if len(c.state.ExecSessions) != 0 {
@@ -174,6 +174,12 @@ type InspectContainerConfig struct {
 	StopSignal uint `json:"StopSignal"`
 	// Configured healthcheck for the container
 	Healthcheck *manifest.Schema2HealthConfig `json:"Healthcheck,omitempty"`
+	// HealthcheckOnFailureAction is the action taken when the container
+	// becomes unhealthy
+	HealthcheckOnFailureAction string `json:"HealthcheckOnFailureAction,omitempty"`
+	// CreateCommand is the full command plus arguments of the process the
+	// container has been created with.
+	CreateCommand []string `json:"CreateCommand,omitempty"`
 }
 
 // InspectContainerHostConfig holds information used when the container was
@@ -595,6 +601,10 @@ type InspectContainerState struct {
 	StartedAt   time.Time          `json:"StartedAt"`
 	FinishedAt  time.Time          `json:"FinishedAt"`
 	Healthcheck HealthCheckResults `json:"Healthcheck,omitempty"`
+	// HealthcheckScheduler is how the healthchecks are run on their
+	// interval, "systemd" or "podman"
+	HealthcheckScheduler    string `json:"HealthcheckScheduler,omitempty"`
+	HealthcheckSchedulerPid int    `json:"HealthcheckSchedulerPid,omitempty"`
 }
 
 // InspectNetworkSettings holds information about the network settings of the
@@ -619,6 +629,23 @@ type InspectNetworkSettings struct {
 	IPPrefixLen            int                  `json:"IPPrefixLen"`
 	IPv6Gateway            string               `json:"IPv6Gateway"`
 	MacAddress             string               `json:"MacAddress"`
+	// Networks contains the settings of every CNI network the container
+	// is connected to, keyed by network name
+	Networks map[string]*InspectNetwork `json:"Networks,omitempty"`
+}
+
+// InspectNetwork holds the settings of the container in a single CNI
+// network. Addresses are only populated while the container's network
+// namespace is active.
+type InspectNetwork struct {
+	NetworkID           string `json:"NetworkID"`
+	Gateway             string `json:"Gateway"`
+	IPAddress           string `json:"IPAddress"`
+	IPPrefixLen         int    `json:"IPPrefixLen"`
+	IPv6Gateway         string `json:"IPv6Gateway"`
+	GlobalIPv6Address   string `json:"GlobalIPv6Address"`
+	GlobalIPv6PrefixLen int    `json:"GlobalIPv6PrefixLen"`
+	MacAddress          string `json:"MacAddress"`
 }
 
 // Inspect a container for low-level information
@@ -774,6 +801,8 @@ func (c *Container) getContainerInspectData(size bool, driverData *driver.Data)
 		} else {
 			data.State.Healthcheck = healthCheckState
 		}
+		data.State.HealthcheckScheduler = c.state.HealthCheckScheduler
+		data.State.HealthcheckSchedulerPid = c.state.HealthCheckSchedulerPID
 	}
 
 	// Copy port mappings into network settings
@@ -938,9 +967,11 @@ func (c *Container) generateInspectContainerConfig(spec *spec.Spec) (*InspectCon
 	}
 
 	ctrConfig.StopSignal = c.config.StopSignal
+	ctrConfig.CreateCommand = c.config.CreateCommand
 	// TODO: should JSON deep copy this to ensure internal pointers don't
 	// leak.
 	ctrConfig.Healthcheck = c.config.HealthCheckConfig
+	ctrConfig.HealthcheckOnFailureAction = c.config.HealthCheckOnFailureAction
 
 	return ctrConfig, nil
 }
@@ -952,6 +983,25 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 
 	logConfig := new(InspectLogConfig)
 	logConfig.Type = c.config.LogDriver
+	logConfig.Config = make(map[string]string)
+	if c.config.LogSize > 0 {
+		logConfig.Config["max-size"] = fmt.Sprintf("%d", c.config.LogSize)
+	}
+	if c.config.LogFiles > 0 {
+		logConfig.Config["max-file"] = fmt.Sprintf("%d", c.config.LogFiles)
+	}
+	if c.config.LogCompress {
+		logConfig.Config["compress"] = "true"
+	}
+	if c.config.LogSyslogAddress != "" {
+		logConfig.Config["syslog-address"] = c.config.LogSyslogAddress
+	}
+	if c.config.LogSyslogFacility != "" {
+		logConfig.Config["syslog-facility"] = c.config.LogSyslogFacility
+	}
+	if c.config.LogSyslogTag != "" {
+		logConfig.Config["tag"] = c.config.LogSyslogTag
+	}
 	hostConfig.LogConfig = logConfig
 
 	restartPolicy := new(InspectRestartPolicy)
@@ -33,6 +33,15 @@ const (
 	// name of the directory holding the artifacts
 	artifactsDir      = "artifacts"
 	execDirPermission = 0755
+
+	// restartBackoffInitial is the delay before the first restart of a
+	// container by its restart policy
+	restartBackoffInitial = 100 * time.Millisecond
+	// restartBackoffMax caps the delay between restarts
+	restartBackoffMax = time.Minute
+	// restartBackoffReset is how long a container must have run for the
+	// restart delay to be reset
+	restartBackoffReset = 10 * time.Second
 )
 
 // rootFsSize gets the size of the container's root filesystem
@@ -281,21 +290,22 @@ func (c *Container) handleExitFile(exitFile string, fi os.FileInfo) error {
 
 // Handle container restart policy.
 // This is called when a container has exited, and was not explicitly stopped by
-// an API call to stop the container or pod it is in.
-func (c *Container) handleRestartPolicy(ctx context.Context) (restarted bool, err error) {
+// an API call to stop the container or pod it is in. A restart is scheduled
+// if the restart policy matches.
+func (c *Container) handleRestartPolicy() error {
 	// If we did not get a restart policy match, exit immediately.
 	// Do the same if we're not a policy that restarts.
 	if !c.state.RestartPolicyMatch ||
 		c.config.RestartPolicy == RestartPolicyNo ||
 		c.config.RestartPolicy == RestartPolicyNone {
-		return false, nil
+		return nil
 	}
 
 	// If we're RestartPolicyOnFailure, we need to check retries and exit
 	// code.
 	if c.config.RestartPolicy == RestartPolicyOnFailure {
 		if c.state.ExitCode == 0 {
-			return false, nil
+			return nil
 		}
 
 		// If we don't have a max retries set, continue
@@ -305,24 +315,41 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (restarted bool, er
 					c.ID(), c.state.RestartCount, c.config.RestartRetries)
 			} else {
 				logrus.Debugf("Container %s restart policy trigger: retries exhausted", c.ID())
-				return false, nil
+				return nil
 			}
 		}
 	}
 
+	// Back off before restarting a container that keeps exiting, so a
+	// crash-looping container does not spin the host. The container is
+	// restarted by a separate podman process once the delay passed, so
+	// cleaning it up is not blocked meanwhile.
+	c.nextRestartBackoff()
+	c.state.RestartTime = time.Now().Add(c.state.RestartBackoff)
+	if err := c.startRestartHelper(); err != nil {
+		c.state.RestartTime = time.Time{}
+		return err
+	}
+	logrus.Debugf("Restarting container %s in %s due to restart policy %s", c.ID(), c.state.RestartBackoff, c.config.RestartPolicy)
+	return c.save()
+}
+
+// restartByPolicy restarts the container once the restart by its restart
+// policy is due.
+func (c *Container) restartByPolicy(ctx context.Context) (err error) {
 	logrus.Debugf("Restarting container %s due to restart policy %s", c.ID(), c.config.RestartPolicy)
 
 	// Need to check if dependencies are alive.
 	if err = c.checkDependenciesAndHandleError(ctx); err != nil {
-		return false, err
+		return err
 	}
 
 	// Is the container running again?
 	// If so, we don't have to do anything
 	if c.state.State == define.ContainerStateRunning || c.state.State == define.ContainerStatePaused {
-		return false, nil
+		return nil
 	} else if c.state.State == define.ContainerStateUnknown {
-		return false, errors.Wrapf(define.ErrInternal, "invalid container state encountered in restart attempt!")
+		return errors.Wrapf(define.ErrInternal, "invalid container state encountered in restart attempt!")
 	}
 
 	c.newContainerEvent(events.Restart)
@@ -331,7 +358,7 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (restarted bool, er
 	c.state.RestartCount = c.state.RestartCount + 1
 	logrus.Debugf("Container %s now on retry %d", c.ID(), c.state.RestartCount)
 	if err := c.save(); err != nil {
-		return false, err
+		return err
 	}
 
 	defer func() {
@@ -342,25 +369,61 @@ func (c *Container) handleRestartPolicy(ctx context.Context) (restarted bool, er
 		}
 	}()
 	if err := c.prepare(); err != nil {
-		return false, err
+		return err
 	}
 
 	if c.state.State == define.ContainerStateStopped {
 		// Reinitialize the container if we need to
 		if err := c.reinit(ctx, true); err != nil {
-			return false, err
+			return err
 		}
 	} else if c.state.State == define.ContainerStateConfigured ||
 		c.state.State == define.ContainerStateExited {
 		// Initialize the container
 		if err := c.init(ctx, true); err != nil {
-			return false, err
+			return err
 		}
 	}
 	if err := c.start(); err != nil {
-		return false, err
+		return err
+	}
+	return nil
+}
+
+// rotatesLog returns whether the container's log file is rotated by podman
+// once it reaches its maximum size, rather than truncated by conmon.
+func (c *Container) rotatesLog() bool {
+	return c.config.LogFiles > 1
+}
+
+// restartAtBoot returns whether the container's restart policy requires it to
+// be started after the system booted.
+func (c *Container) restartAtBoot() (bool, error) {
+	switch c.config.RestartPolicy {
+	case RestartPolicyAlways:
+		return true, nil
+	case RestartPolicyUnlessStopped:
+		stopped, err := c.StoppedByUser()
+		if err != nil {
+			return false, err
+		}
+		return !stopped, nil
+	default:
+		return false, nil
+	}
+}
+
+// nextRestartBackoff computes the delay before the next restart by the
+// container's restart policy and records it in the container's state.
+func (c *Container) nextRestartBackoff() {
+	if c.state.RestartBackoff == 0 || c.state.FinishedTime.Sub(c.state.StartedTime) >= restartBackoffReset {
+		c.state.RestartBackoff = restartBackoffInitial
+		return
+	}
+	c.state.RestartBackoff = c.state.RestartBackoff * 2
+	if c.state.RestartBackoff > restartBackoffMax {
+		c.state.RestartBackoff = restartBackoffMax
 	}
-	return true, nil
 }
 
 // Sync this container with on-disk state and runtime status
@@ -548,10 +611,20 @@ func resetState(state *ContainerState) error {
 	}
 	state.ExecSessions = make(map[string]*ExecSession)
 	state.NetworkStatus = nil
+	state.NetInterfaces = nil
+	// Networks is retained so containers rejoin the networks they were
+	// connected to after a reboot.
 	state.BindMounts = make(map[string]string)
-	state.StoppedByUser = false
+	// StoppedByUser is retained so containers with the unless-stopped
+	// restart policy that were stopped remain stopped after a reboot.
 	state.RestartPolicyMatch = false
 	state.RestartCount = 0
+	state.RestartBackoff = 0
+	state.RestartTime = time.Time{}
+	state.RestartHelperPID = 0
+	state.HealthCheckSchedulerPID = 0
+	state.LogRotatorPID = 0
+	state.LogForwarderPID = 0
 
 	return nil
 }
@@ -935,9 +1008,13 @@ func (c *Container) init(ctx context.Context, retainRetries bool) error {
 	c.state.State = define.ContainerStateCreated
 	c.state.StoppedByUser = false
 	c.state.RestartPolicyMatch = false
+	if err := c.stopRestartHelper(); err != nil {
+		logrus.Debugf("Error stopping restart helper of container %s: %v", c.ID(), err)
+	}
 
 	if !retainRetries {
 		c.state.RestartCount = 0
+		c.state.RestartBackoff = 0
 	}
 
 	if err := c.save(); err != nil {
@@ -1071,6 +1148,8 @@ func (c *Container) start() error {
 		logrus.Debugf("Starting container %s with command %v", c.ID(), c.config.Spec.Process.Args)
 	}
 
+	// Lines logged from now on are forwarded with the syslog log driver
+	started := time.Now()
 	if err := c.ociRuntime.startContainer(c); err != nil {
 		return err
 	}
@@ -1087,6 +1166,18 @@ func (c *Container) start() error {
 		}
 	}
 
+	if c.rotatesLog() {
+		if err := c.startLogRotator(); err != nil {
+			logrus.Errorf("Error starting log rotator for container %s: %v", c.ID(), err)
+		}
+	}
+
+	if c.config.LogDriver == SyslogLogging {
+		if err := c.startLogForwarder(started); err != nil {
+			logrus.Errorf("Error starting log forwarder for container %s: %v", c.ID(), err)
+		}
+	}
+
 	defer c.newContainerEvent(events.Start)
 
 	return c.save()
@@ -1094,6 +1185,12 @@ func (c *Container) start() error {
 
 // Internal, non-locking function to stop container
 func (c *Container) stop(timeout uint) error {
+	return c.stopContainer(timeout, true)
+}
+
+// Internal, non-locking function to stop container.
+// Containers not stopped by the user remain subject to their restart policy.
+func (c *Container) stopContainer(timeout uint, byUser bool) error {
 	logrus.Debugf("Stopping ctr %s (timeout %d)", c.ID(), timeout)
 
 	if err := c.ociRuntime.stopContainer(c, timeout); err != nil {
@@ -1102,7 +1199,9 @@ func (c *Container) stop(timeout uint) error {
 
 	c.state.PID = 0
 	c.state.ConmonPID = 0
-	c.state.StoppedByUser = true
+	if byUser {
+		c.state.StoppedByUser = true
+	}
 	if err := c.save(); err != nil {
 		return errors.Wrapf(err, "error saving container %s state after stopping", c.ID())
 	}
@@ -1236,9 +1335,69 @@ func (c *Container) mountStorage() (string, error) {
 		}
 	}
 
+	if err := c.mountNamedVolumes(); err != nil {
+		if c.config.Rootfs == "" {
+			if err2 := c.unmount(false); err2 != nil {
+				logrus.Errorf("Error unmounting container %s root filesystem: %v", c.ID(), err2)
+			}
+		}
+		return "", err
+	}
+
 	return mountPoint, nil
 }
 
+// mountNamedVolumes mounts the named volumes of the container whose driver
+// requires them to be mounted before use.
+// If any volume fails to mount, the volumes mounted so far are unmounted.
+func (c *Container) mountNamedVolumes() error {
+	mounted := []*Volume{}
+	for _, namedVol := range c.config.NamedVolumes {
+		vol, err := c.runtime.state.Volume(namedVol.Name)
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving volume %s for container %s", namedVol.Name, c.ID())
+		}
+		if !vol.needsMount() {
+			continue
+		}
+		if err := vol.mount(c.ID()); err != nil {
+			for _, mountedVol := range mounted {
+				if err2 := mountedVol.unmount(c.ID()); err2 != nil {
+					logrus.Errorf("Error unmounting volume %s for container %s: %v", mountedVol.Name(), c.ID(), err2)
+				}
+			}
+			return err
+		}
+		mounted = append(mounted, vol)
+	}
+	return nil
+}
+
+// unmountNamedVolumes releases the mounts of the container's named volumes.
+func (c *Container) unmountNamedVolumes() error {
+	var lastError error
+	for _, namedVol := range c.config.NamedVolumes {
+		vol, err := c.runtime.state.Volume(namedVol.Name)
+		if err != nil {
+			// The volume may have been removed with the container
+			if errors.Cause(err) == define.ErrNoSuchVolume {
+				continue
+			}
+			return errors.Wrapf(err, "error retrieving volume %s for container %s", namedVol.Name, c.ID())
+		}
+		if !vol.needsMount() {
+			continue
+		}
+		if err := vol.unmount(c.ID()); err != nil {
+			if lastError != nil {
+				logrus.Error(lastError)
+			}
+			lastError = err
+		}
+	}
+	return lastError
+}
+
 // cleanupStorage unmounts and cleans up the container's root filesystem
 func (c *Container) cleanupStorage() error {
 	if !c.state.Mounted {
@@ -1253,6 +1412,11 @@ func (c *Container) cleanupStorage() error {
 		}
 	}
 
+	// Failing to release a volume must not keep the container mounted
+	if err := c.unmountNamedVolumes(); err != nil {
+		logrus.Errorf("Error unmounting volumes of container %s: %v", c.ID(), err)
+	}
+
 	if c.config.Rootfs != "" {
 		return nil
 	}
@@ -1296,6 +1460,12 @@ func (c *Container) cleanup(ctx context.Context) error {
 		}
 	}
 
+	if c.rotatesLog() {
+		if err := c.stopLogRotator(); err != nil {
+			logrus.Errorf("Error stopping log rotator for container %s: %v", c.ID(), err)
+		}
+	}
+
 	// Clean up network namespace, if present
 	if err := c.cleanupNetwork(); err != nil {
 		lastError = errors.Wrapf(err, "error removing container %s network", c.ID())
@@ -1615,6 +1785,30 @@ func (c *Container) prepareCheckpointExport() (err error) {
 	return nil
 }
 
+// addPodVolumes adds the named volumes of the pod to the container, skipping
+// those whose destination the container already mounts something else at
+func (c *Container) addPodVolumes(pod *Pod) {
+	for _, vol := range pod.config.Volumes {
+		exists := MountExists(c.config.Spec.Mounts, vol.Dest)
+		for _, namedVol := range c.config.NamedVolumes {
+			if namedVol.Dest == vol.Dest {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			logrus.Debugf("Container %s mounts a volume at %s, not adding volume %s of pod %s", c.ID(), vol.Dest, vol.Name, pod.ID())
+			continue
+		}
+		c.config.NamedVolumes = append(c.config.NamedVolumes, &ContainerNamedVolume{
+			Name:    vol.Name,
+			Dest:    vol.Dest,
+			Options: append([]string{}, vol.Options...),
+		})
+		c.config.UserVolumes = append(c.config.UserVolumes, vol.Dest)
+	}
+}
+
 // sortUserVolumes sorts the volumes specified for a container
 // between named and normal volumes
 func (c *Container) sortUserVolumes(ctrSpec *spec.Spec) ([]*ContainerNamedVolume, []spec.Mount) {
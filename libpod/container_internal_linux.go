@@ -160,6 +160,7 @@ func (c *Container) cleanupNetwork() error {
 
 	c.state.NetNS = nil
 	c.state.NetworkStatus = nil
+	c.state.NetInterfaces = nil
 
 	if c.valid {
 		return c.save()
@@ -238,7 +239,10 @@ func (c *Container) generateSpec(ctx context.Context) (*spec.Spec, error) {
 		if err != nil {
 			return nil, errors.Wrapf(err, "error retrieving volume %s to add to container %s", namedVol.Name, c.ID())
 		}
-		mountPoint := volume.MountPoint()
+		mountPoint, err := volume.mountPoint()
+		if err != nil {
+			return nil, err
+		}
 		volMount := spec.Mount{
 			Type:        "bind",
 			Source:      mountPoint,
@@ -935,7 +939,10 @@ func (c *Container) makeBindMounts() error {
 				}
 			}
 
-			if !c.config.UseImageHosts {
+			// If the dependency uses the hosts file of its image, as
+			// the infra container of a pod created with --no-hosts
+			// does, use the hosts file of our own image too
+			if !c.config.UseImageHosts && !depCtr.config.UseImageHosts {
 				// check if dependency container has an /etc/hosts file
 				hostsPath, exists := bindMounts["/etc/hosts"]
 				if !exists {
@@ -0,0 +1,156 @@
+package libpod
+
+import (
+	"sync"
+	"time"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/libpod/logs"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// logForwardInterval is how often the log forwarder of a container checks
+// whether the container stopped
+const logForwardInterval = time.Second
+
+// startLogForwarder starts a podman process forwarding the lines the
+// container logs after since to its syslog endpoint. The forwarder of a
+// previous run of the container is stopped.
+func (c *Container) startLogForwarder(since time.Time) error {
+	if err := c.stopLogForwarder(); err != nil {
+		logrus.Debugf("Error stopping previous log forwarder of container %s: %v", c.ID(), err)
+	}
+	pid, err := c.startPodmanHelper("log forwarder", c.podmanHelperCommand("container", "logforward", "--since", since.Format(time.RFC3339Nano), c.ID()))
+	if err != nil {
+		return err
+	}
+	c.state.LogForwarderPID = pid
+	return nil
+}
+
+// stopLogForwarder stops the podman process forwarding the container's log.
+// Forwarders exit by themselves once the container stopped and its last
+// lines were forwarded, so this only stops a forwarder left behind.
+func (c *Container) stopLogForwarder() error {
+	pid := c.state.LogForwarderPID
+	c.state.LogForwarderPID = 0
+	return c.stopPodmanHelper("log forwarder", pid)
+}
+
+// logForwarding returns whether the log forwarder with the given PID should
+// keep following the container's log, until the container stops or the
+// forwarder is replaced
+func (c *Container) logForwarding(forwarder int) (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return false, err
+		}
+	}
+
+	if c.state.LogForwarderPID != forwarder {
+		logrus.Debugf("Log forwarder of container %s was replaced, exiting", c.ID())
+		return false, nil
+	}
+	if c.state.State != define.ContainerStateRunning && c.state.State != define.ContainerStatePaused {
+		logrus.Debugf("Container %s stopped, exiting log forwarder", c.ID())
+		return false, nil
+	}
+	return true, nil
+}
+
+// forwardLog forwards the lines the container logs after since to its syslog
+// endpoint, following its log file across rotations, until the container
+// stops and its last lines were forwarded
+func (c *Container) forwardLog(since time.Time, forwarder int) error {
+	params := map[string]string{
+		"name": c.Name(),
+		"id":   c.ID(),
+	}
+	for key, value := range c.Labels() {
+		params["label."+key] = value
+	}
+	w, err := logs.NewSyslogWriter(c.config.LogSyslogAddress, c.config.LogSyslogFacility, c.LogSyslogTag(), params)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	forward := func(nll *logs.LogLine) {
+		if !nll.Since(since) {
+			return
+		}
+		if err := w.Write(nll); err != nil {
+			logrus.Errorf("unable to forward log of container %s: %v", c.ID(), err)
+		}
+	}
+
+	t, rotatedLines, err := logs.GetLogFile(c.LogPath(), &logs.LogOptions{Follow: true})
+	if err != nil {
+		return errors.Wrapf(err, "unable to read log file %s of container %s", c.LogPath(), c.ID())
+	}
+	for _, nll := range rotatedLines {
+		forward(nll)
+	}
+
+	// Stop following at the end of the log file once the container stopped
+	var (
+		tailLock sync.Mutex
+		stopped  bool
+	)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(logForwardInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			forwarding, err := c.logForwarding(forwarder)
+			if err != nil {
+				if errors.Cause(err) != define.ErrNoSuchCtr && errors.Cause(err) != define.ErrCtrRemoved {
+					logrus.Errorf("error checking state of container %s: %v", c.ID(), err)
+					continue
+				}
+				forwarding = false
+			}
+			if !forwarding {
+				tailLock.Lock()
+				stopped = true
+				tail := t
+				tailLock.Unlock()
+				if err := tail.StopAtEOF(); err != nil {
+					logrus.Debugf("error stopping to follow log file %s: %v", c.LogPath(), err)
+				}
+				return
+			}
+		}
+	}()
+
+	var partial string
+	for {
+		for line := range t.Lines {
+			nll, err := logs.NewLogLine(line.Text)
+			if err != nil {
+				logrus.Error(err)
+				continue
+			}
+			if nll.Partial() {
+				partial = partial + nll.Msg
+				continue
+			}
+			nll.Msg = partial + nll.Msg
+			partial = ""
+			forward(nll)
+		}
+		if !c.followRotatedLog(&t, &tailLock, &stopped) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// +build !linux
+
+package libpod
+
+import (
+	"time"
+
+	"github.com/containers/libpod/libpod/define"
+)
+
+// startLogForwarder starts a podman process forwarding the container's log
+func (c *Container) startLogForwarder(since time.Time) error {
+	return define.ErrNotImplemented
+}
+
+// stopLogForwarder stops the podman process forwarding the container's log
+func (c *Container) stopLogForwarder() error {
+	return nil
+}
+
+// forwardLog forwards the container's log to its syslog endpoint
+func (c *Container) forwardLog(since time.Time, forwarder int) error {
+	return define.ErrNotImplemented
+}
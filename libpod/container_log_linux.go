@@ -30,9 +30,14 @@ const (
 
 func (c *Container) readFromJournal(options *logs.LogOptions, logChannel chan *logs.LogLine) error {
 	var config journal.JournalReaderConfig
-	config.NumFromTail = options.Tail
 	config.Formatter = journalFormatter
 	defaultTime := time.Time{}
+	// The tail is taken from the entries selected by since and until, the
+	// journal can only be read from a number of entries before its end
+	tailSelected := options.Tail > 0 && (options.Since != defaultTime || (!options.Until.IsZero() && !options.Follow))
+	if !tailSelected {
+		config.NumFromTail = options.Tail
+	}
 	if options.Since != defaultTime {
 		// coreos/go-systemd/sdjournal doesn't correctly handle requests for data in the future
 		// return nothing instead of fasely printing
@@ -45,6 +50,18 @@ func (c *Container) readFromJournal(options *logs.LogOptions, logChannel chan *l
 		Field: "CONTAINER_ID_FULL",
 		Value: c.ID(),
 	})
+	switch options.Stream {
+	case "stdout":
+		config.Matches = append(config.Matches, journal.Match{
+			Field: "PRIORITY",
+			Value: journaldLogOut,
+		})
+	case "stderr":
+		config.Matches = append(config.Matches, journal.Match{
+			Field: "PRIORITY",
+			Value: journaldLogErr,
+		})
+	}
 	options.WaitGroup.Add(1)
 
 	r, err := journal.NewJournalReader(config)
@@ -54,14 +71,19 @@ func (c *Container) readFromJournal(options *logs.LogOptions, logChannel chan *l
 	if r == nil {
 		return errors.Errorf("journal reader creation failed")
 	}
-	if options.Tail == 0 {
+	if config.NumFromTail == 0 && config.Since == 0 {
 		r.Rewind()
 	}
 
 	if options.Follow {
 		go func() {
-			follower := FollowBuffer{logChannel}
-			err := r.Follow(nil, follower)
+			follower := FollowBuffer{logChannel, options.Until, c}
+			// Stop following once the until time passed
+			var until <-chan time.Time
+			if !options.Until.IsZero() {
+				until = time.After(time.Until(options.Until))
+			}
+			err := r.Follow(until, follower)
 			if err != nil {
 				logrus.Debugf(err.Error())
 			}
@@ -73,6 +95,7 @@ func (c *Container) readFromJournal(options *logs.LogOptions, logChannel chan *l
 	}
 
 	go func() {
+		var tailLog []*logs.LogLine
 		bytes := make([]byte, bufLen)
 		// /me complains about no do-while in go
 		ec, err := r.Read(bytes)
@@ -85,12 +108,29 @@ func (c *Container) readFromJournal(options *logs.LogOptions, logChannel chan *l
 				logrus.Error(err2)
 				continue
 			}
-			logChannel <- logLine
+			// Journal entries are read in order, the following
+			// ones occurred after the until time as well
+			if !logLine.Until(options.Until) {
+				break
+			}
+			logLine.CID = c.ID()
+			logLine.CName = c.Name()
+			if tailSelected {
+				if uint64(len(tailLog)) == options.Tail {
+					tailLog = tailLog[1:]
+				}
+				tailLog = append(tailLog, logLine)
+			} else {
+				logChannel <- logLine
+			}
 			ec, err = r.Read(bytes)
 		}
 		if err != nil && err != io.EOF {
 			logrus.Error(err)
 		}
+		for _, logLine := range tailLog {
+			logChannel <- logLine
+		}
 		r.Close()
 		options.WaitGroup.Done()
 	}()
@@ -131,6 +171,8 @@ func journalFormatter(entry *journal.JournalEntry) (string, error) {
 
 type FollowBuffer struct {
 	logChannel chan *logs.LogLine
+	until      time.Time
+	ctr        *Container
 }
 
 func (f FollowBuffer) Write(p []byte) (int, error) {
@@ -139,6 +181,10 @@ func (f FollowBuffer) Write(p []byte) (int, error) {
 	if err != nil {
 		return -1, err
 	}
-	f.logChannel <- logLine
+	if logLine.Until(f.until) {
+		logLine.CID = f.ctr.ID()
+		logLine.CName = f.ctr.Name()
+		f.logChannel <- logLine
+	}
 	return len(p), nil
 }
@@ -0,0 +1,127 @@
+package libpod
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/libpod/logs"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// logReopenTimeout is how long to wait for conmon to reopen the log
+	// file of a container after it was rotated
+	logReopenTimeout = time.Second
+
+	// logReopenMessage is the message asking conmon to reopen the log
+	// file over the control fifo of the container
+	logReopenMessage = 2
+)
+
+// startLogRotator starts a podman process rotating the container's log file
+// whenever it reaches its maximum size
+func (c *Container) startLogRotator() error {
+	pid, err := c.startPodmanHelper("log rotator", c.podmanHelperCommand("container", "logrotate", c.ID()))
+	if err != nil {
+		return err
+	}
+	c.state.LogRotatorPID = pid
+	return nil
+}
+
+// stopLogRotator stops the podman process rotating the container's log file
+func (c *Container) stopLogRotator() error {
+	pid := c.state.LogRotatorPID
+	c.state.LogRotatorPID = 0
+	return c.stopPodmanHelper("log rotator", pid)
+}
+
+// rotateLog rotates the container's log file if it reached its maximum size.
+// It returns false once the log rotator with the given PID should exit,
+// because the container stopped or the rotator was replaced.
+func (c *Container) rotateLog(rotator int) (bool, error) {
+	if !c.batched {
+		c.lock.Lock()
+	}
+	keep, rotated, err := c.rotateLogFile(rotator)
+	if !c.batched {
+		c.lock.Unlock()
+	}
+	if err != nil || !rotated || !c.config.LogCompress {
+		return keep, err
+	}
+
+	// The rotated segment is no longer written by conmon, compress it
+	// without holding the container lock
+	if err := logs.CompressRotatedLogFile(c.LogPath()); err != nil {
+		return keep, err
+	}
+	return keep, nil
+}
+
+// rotateLogFile renames the container's log file if it reached its maximum
+// size and has conmon reopen it. It must be called with the container lock
+// held, and returns whether the log file was rotated.
+func (c *Container) rotateLogFile(rotator int) (bool, bool, error) {
+	if !c.batched {
+		if err := c.syncContainer(); err != nil {
+			return false, false, err
+		}
+	}
+
+	if c.state.LogRotatorPID != rotator {
+		logrus.Debugf("Log rotator of container %s was replaced, exiting", c.ID())
+		return false, false, nil
+	}
+	if c.state.State != define.ContainerStateRunning && c.state.State != define.ContainerStatePaused {
+		logrus.Debugf("Container %s stopped, exiting log rotator", c.ID())
+		return false, false, nil
+	}
+
+	info, err := os.Stat(c.LogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true, false, nil
+		}
+		return true, false, errors.Wrapf(err, "error checking size of log file %s", c.LogPath())
+	}
+	if info.Size() < c.config.LogSize {
+		return true, false, nil
+	}
+
+	logrus.Debugf("Rotating log file %s of container %s", c.LogPath(), c.ID())
+	if err := logs.RotateLogFile(c.LogPath(), c.config.LogFiles); err != nil {
+		return true, false, err
+	}
+	if err := c.reopenLog(); err != nil {
+		return true, true, err
+	}
+	return true, true, nil
+}
+
+// reopenLog has conmon reopen the container's log file after it was rotated,
+// and waits for the new log file to be created
+func (c *Container) reopenLog() error {
+	controlFile, err := os.OpenFile(c.ControlSocketPath(), unix.O_WRONLY, 0)
+	if err != nil {
+		return errors.Wrapf(err, "error opening control file of container %s", c.ID())
+	}
+	defer controlFile.Close()
+
+	if _, err := fmt.Fprintf(controlFile, "%d %d %d\n", logReopenMessage, 0, 0); err != nil {
+		return errors.Wrapf(err, "error asking conmon to reopen the log file of container %s", c.ID())
+	}
+
+	deadline := time.Now().Add(logReopenTimeout)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(c.LogPath()); err == nil {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return errors.Wrapf(define.ErrInternal, "timed out waiting for conmon to reopen the log file of container %s", c.ID())
+}
@@ -0,0 +1,20 @@
+// +build !linux
+
+package libpod
+
+import "github.com/containers/libpod/libpod/define"
+
+// startLogRotator starts a podman process rotating the container's log file
+func (c *Container) startLogRotator() error {
+	return define.ErrNotImplemented
+}
+
+// stopLogRotator stops the podman process rotating the container's log file
+func (c *Container) stopLogRotator() error {
+	return nil
+}
+
+// rotateLog rotates the container's log file if it reached its maximum size
+func (c *Container) rotateLog(rotator int) (bool, error) {
+	return false, define.ErrNotImplemented
+}
@@ -0,0 +1,84 @@
+package libpod
+
+import (
+	"context"
+	"time"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// startRestartHelper starts a podman process restarting the container by its
+// restart policy at the restart time recorded in its state. The helper of a
+// previous restart is stopped.
+func (c *Container) startRestartHelper() error {
+	if err := c.stopRestartHelper(); err != nil {
+		logrus.Debugf("Error stopping previous restart helper of container %s: %v", c.ID(), err)
+	}
+	pid, err := c.startPodmanHelper("restart helper", c.podmanHelperCommand("container", "autorestart", c.ID()))
+	if err != nil {
+		return err
+	}
+	c.state.RestartHelperPID = pid
+	return nil
+}
+
+// stopRestartHelper stops the podman process waiting to restart the
+// container, canceling the pending restart.
+func (c *Container) stopRestartHelper() error {
+	pid := c.state.RestartHelperPID
+	c.state.RestartHelperPID = 0
+	c.state.RestartTime = time.Time{}
+	return c.stopPodmanHelper("restart helper", pid)
+}
+
+// restartWhenDue waits until the restart time recorded in the container's
+// state and then restarts the container by its restart policy, unless the
+// restart was canceled or the restart helper with the given PID was replaced
+// in the meantime.
+func (c *Container) restartWhenDue(ctx context.Context, helper int) error {
+	c.lock.Lock()
+	err := c.syncContainer()
+	restartTime := c.state.RestartTime
+	pending := c.state.RestartHelperPID == helper
+	c.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	if !pending {
+		logrus.Debugf("Restart helper of container %s was replaced, exiting", c.ID())
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-time.After(time.Until(restartTime)):
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+	if c.state.RestartHelperPID != helper {
+		logrus.Debugf("Restart helper of container %s was replaced, exiting", c.ID())
+		return nil
+	}
+	c.state.RestartHelperPID = 0
+	c.state.RestartTime = time.Time{}
+	if err := c.save(); err != nil {
+		return err
+	}
+
+	// Stopping or starting the container cancels the restart
+	if !c.state.RestartPolicyMatch || c.state.StoppedByUser {
+		logrus.Debugf("Restart of container %s was canceled", c.ID())
+		return nil
+	}
+	if c.state.State == define.ContainerStateRunning || c.state.State == define.ContainerStatePaused {
+		return nil
+	}
+	return c.restartByPolicy(ctx)
+}
@@ -0,0 +1,24 @@
+// +build !linux
+
+package libpod
+
+import (
+	"context"
+
+	"github.com/containers/libpod/libpod/define"
+)
+
+// startRestartHelper starts a podman process restarting the container
+func (c *Container) startRestartHelper() error {
+	return define.ErrNotImplemented
+}
+
+// stopRestartHelper stops the podman process restarting the container
+func (c *Container) stopRestartHelper() error {
+	return nil
+}
+
+// restartWhenDue restarts the container by its restart policy when due
+func (c *Container) restartWhenDue(ctx context.Context, helper int) error {
+	return define.ErrNotImplemented
+}
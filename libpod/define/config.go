@@ -9,6 +9,10 @@ var (
 	DefaultInfraCommand = "/pause"
 )
 
+// VolumeDriverLocal is the default volume driver. Volumes using it are
+// directories on the host under the volume path.
+const VolumeDriverLocal = "local"
+
 // CtrRemoveTimeout is the default number of seconds to wait after stopping a container
 // before sending the kill signal
 const CtrRemoveTimeout = 10
@@ -42,6 +42,20 @@ func (c *Container) newContainerExitedEvent(exitCode int32) {
 	}
 }
 
+// newContainerHealthStatusEvent creates a new event for a change of a
+// container's health status
+func (c *Container) newContainerHealthStatusEvent(healthStatus string) {
+	e := events.NewEvent(events.HealthStatus)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+	e.HealthStatus = healthStatus
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("unable to write pod event: %q", err)
+	}
+}
+
 // newPodEvent creates a new event for a libpod pod
 func (p *Pod) newPodEvent(status events.Status) {
 	e := events.NewEvent(status)
@@ -23,6 +23,9 @@ type Event struct {
 	// ContainerExitCode is for storing the exit code of a container which can
 	// be used for "internal" event notification
 	ContainerExitCode int `json:",omitempty"`
+	// HealthStatus is the new health status of a container, set for
+	// health_status events
+	HealthStatus string `json:",omitempty"`
 	// ID can be for the container, image, volume, etc
 	ID string `json:",omitempty"`
 	// Image used where applicable
@@ -112,6 +115,9 @@ const (
 	Exited Status = "died"
 	// Export ...
 	Export Status = "export"
+	// HealthStatus indicates that the health status of a container
+	// changed
+	HealthStatus Status = "health_status"
 	// History ...
 	History Status = "history"
 	// Import ...
@@ -69,6 +69,10 @@ func (e *Event) ToHumanReadable() string {
 	var humanFormat string
 	switch e.Type {
 	case Container, Pod:
+		if e.Status == HealthStatus {
+			humanFormat = fmt.Sprintf("%s %s %s %s (image=%s, name=%s, health_status=%s)", e.Time, e.Type, e.Status, e.ID, e.Image, e.Name, e.HealthStatus)
+			break
+		}
 		humanFormat = fmt.Sprintf("%s %s %s %s (image=%s, name=%s)", e.Time, e.Type, e.Status, e.ID, e.Image, e.Name)
 	case Image:
 		humanFormat = fmt.Sprintf("%s %s %s %s %s", e.Time, e.Type, e.Status, e.ID, e.Name)
@@ -143,6 +147,8 @@ func StringToStatus(name string) (Status, error) {
 		return Exited, nil
 	case Export.String():
 		return Export, nil
+	case HealthStatus.String():
+		return HealthStatus, nil
 	case History.String():
 		return History, nil
 	case Import.String():
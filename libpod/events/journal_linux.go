@@ -42,6 +42,9 @@ func (e EventJournalD) Write(ee Event) error {
 		m["PODMAN_IMAGE"] = ee.Image
 		m["PODMAN_NAME"] = ee.Name
 		m["PODMAN_ID"] = ee.ID
+		if ee.HealthStatus != "" {
+			m["PODMAN_HEALTH_STATUS"] = ee.HealthStatus
+		}
 	case Volume:
 		m["PODMAN_NAME"] = ee.Name
 	}
@@ -150,6 +153,7 @@ func newEventFromJournalEntry(entry *sdjournal.JournalEntry) (*Event, error) { /
 	case Container, Pod:
 		newEvent.ID = entry.Fields["PODMAN_ID"]
 		newEvent.Image = entry.Fields["PODMAN_IMAGE"]
+		newEvent.HealthStatus = entry.Fields["PODMAN_HEALTH_STATUS"]
 	case Image:
 		newEvent.ID = entry.Fields["PODMAN_ID"]
 	}
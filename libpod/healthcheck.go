@@ -3,10 +3,12 @@ package libpod
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/containers/libpod/libpod/define"
@@ -53,6 +55,28 @@ const (
 	HealthCheckStarting string = "starting"
 )
 
+// Ways the healthchecks of a container are run on their interval.
+const (
+	// HealthCheckSchedulerSystemd runs healthchecks from a transient
+	// systemd timer.
+	HealthCheckSchedulerSystemd = "systemd"
+	// HealthCheckSchedulerPodman runs healthchecks from a podman process
+	// started along with the container.
+	HealthCheckSchedulerPodman = "podman"
+)
+
+// Valid actions taken when a container becomes unhealthy.
+const (
+	// HealthCheckOnFailureActionNone takes no action.
+	HealthCheckOnFailureActionNone = "none"
+	// HealthCheckOnFailureActionKill kills the container.
+	HealthCheckOnFailureActionKill = "kill"
+	// HealthCheckOnFailureActionRestart restarts the container.
+	HealthCheckOnFailureActionRestart = "restart"
+	// HealthCheckOnFailureActionStop stops the container.
+	HealthCheckOnFailureActionStop = "stop"
+)
+
 // HealthCheckResults describes the results/logs from a healthcheck
 type HealthCheckResults struct {
 	// Status healthy or unhealthy
@@ -99,6 +123,67 @@ func (r *Runtime) HealthCheck(name string) (HealthCheckStatus, error) {
 	return hcStatus, err
 }
 
+// RunHealthCheckScheduler runs the healthcheck of the given container on its
+// configured interval until the container stops. It is run in a separate
+// podman process in place of a systemd timer, on hosts where systemd timers
+// are not available.
+func (r *Runtime) RunHealthCheckScheduler(ctx context.Context, nameOrID string) error {
+	ctr, err := r.LookupContainer(nameOrID)
+	if err != nil {
+		return errors.Wrapf(err, "unable to lookup %s to schedule its health checks", nameOrID)
+	}
+	if !ctr.HasHealthCheck() || ctr.HealthCheckConfig().Interval == 0 {
+		return errors.Errorf("container %s has no health check interval", ctr.ID())
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(ctr.HealthCheckConfig().Interval):
+		}
+
+		// Exit once the container stopped, or was restarted with a
+		// new scheduler
+		pid, err := ctr.healthCheckSchedulerPID()
+		if err != nil {
+			if errors.Cause(err) == define.ErrNoSuchCtr || errors.Cause(err) == define.ErrCtrRemoved {
+				return nil
+			}
+			return err
+		}
+		if pid != os.Getpid() {
+			logrus.Debugf("Healthcheck scheduler of container %s was replaced, exiting", ctr.ID())
+			return nil
+		}
+		hcStatus, err := checkHealthCheckCanBeRun(ctr)
+		if err != nil {
+			if hcStatus == HealthCheckContainerStopped {
+				logrus.Debugf("Container %s stopped, exiting healthcheck scheduler", ctr.ID())
+				return nil
+			}
+			return err
+		}
+		if _, err := ctr.runHealthCheck(); err != nil {
+			logrus.Errorf("error running healthcheck of container %s: %v", ctr.ID(), err)
+		}
+	}
+}
+
+// healthCheckSchedulerPID returns the PID of the podman process running the
+// container's healthchecks
+func (c *Container) healthCheckSchedulerPID() (int, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return 0, err
+		}
+	}
+	return c.state.HealthCheckSchedulerPID, nil
+}
+
 // runHealthCheck runs the health check as defined by the container
 func (c *Container) runHealthCheck() (HealthCheckStatus, error) {
 	var (
@@ -176,12 +261,58 @@ func (c *Container) runHealthCheck() (HealthCheckStatus, error) {
 		hcErr = errors.Errorf("healthcheck command exceeded timeout of %s", c.HealthCheckConfig().Timeout.String())
 	}
 	hcl := newHealthCheckLog(timeStart, timeEnd, returnCode, eventLog)
-	if err := c.updateHealthCheckLog(hcl, inStartPeriod); err != nil {
+	oldStatus, newStatus, err := c.updateHealthCheckLog(hcl, inStartPeriod)
+	if err != nil {
 		return hcResult, errors.Wrapf(err, "unable to update health check log %s for %s", c.healthCheckLogPath(), c.ID())
 	}
+	if newStatus != oldStatus {
+		c.newContainerHealthStatusEvent(newStatus)
+		if newStatus == HealthCheckUnhealthy {
+			if err := c.healthCheckOnFailure(); err != nil {
+				return hcResult, errors.Wrapf(err, "unable to act on unhealthy container %s", c.ID())
+			}
+		}
+	}
 	return hcResult, hcErr
 }
 
+// healthCheckOnFailure takes the configured action on a container which
+// became unhealthy
+func (c *Container) healthCheckOnFailure() error {
+	action := c.config.HealthCheckOnFailureAction
+	if action == "" || action == HealthCheckOnFailureActionNone {
+		return nil
+	}
+
+	logrus.Infof("Container %s is unhealthy, taking action %s", c.ID(), action)
+
+	switch action {
+	case HealthCheckOnFailureActionKill:
+		return c.Kill(uint(syscall.SIGKILL))
+	case HealthCheckOnFailureActionRestart:
+		return c.RestartWithTimeout(context.Background(), c.StopTimeout())
+	case HealthCheckOnFailureActionStop:
+		return c.healthCheckStop()
+	default:
+		return errors.Wrapf(define.ErrInvalidArg, "%q is not a valid health check on failure action", action)
+	}
+}
+
+// healthCheckStop stops an unhealthy container. Unlike Stop(), the container
+// is not marked as stopped by the user.
+func (c *Container) healthCheckStop() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+	if c.state.State != define.ContainerStateRunning {
+		return nil
+	}
+	return c.stopContainer(c.StopTimeout(), false)
+}
+
 func checkHealthCheckCanBeRun(c *Container) (HealthCheckStatus, error) {
 	cstate, err := c.State()
 	if err != nil {
@@ -212,20 +343,33 @@ func (c *Container) updateHealthStatus(status string) error {
 	if err != nil {
 		return err
 	}
+	oldStatus := healthCheck.Status
 	healthCheck.Status = status
+	if status == HealthCheckStarting {
+		// a (re)started container gets all its retries again
+		healthCheck.FailingStreak = 0
+	}
 	newResults, err := json.Marshal(healthCheck)
 	if err != nil {
 		return errors.Wrapf(err, "unable to marshall healthchecks for writing status")
 	}
-	return ioutil.WriteFile(c.healthCheckLogPath(), newResults, 0700)
+	if err := ioutil.WriteFile(c.healthCheckLogPath(), newResults, 0700); err != nil {
+		return err
+	}
+	if status != oldStatus {
+		c.newContainerHealthStatusEvent(status)
+	}
+	return nil
 }
 
-// UpdateHealthCheckLog parses the health check results and writes the log
-func (c *Container) updateHealthCheckLog(hcl HealthCheckLog, inStartPeriod bool) error {
+// UpdateHealthCheckLog parses the health check results and writes the log.
+// It returns the health status of the container before and after the update.
+func (c *Container) updateHealthCheckLog(hcl HealthCheckLog, inStartPeriod bool) (string, string, error) {
 	healthCheck, err := c.GetHealthCheckLog()
 	if err != nil {
-		return err
+		return "", "", err
 	}
+	oldStatus := healthCheck.Status
 	if hcl.ExitCode == 0 {
 		//	set status to healthy, reset failing state to 0
 		healthCheck.Status = HealthCheckHealthy
@@ -249,9 +393,12 @@ func (c *Container) updateHealthCheckLog(hcl HealthCheckLog, inStartPeriod bool)
 	}
 	newResults, err := json.Marshal(healthCheck)
 	if err != nil {
-		return errors.Wrapf(err, "unable to marshall healthchecks for writing")
+		return "", "", errors.Wrapf(err, "unable to marshall healthchecks for writing")
+	}
+	if err := ioutil.WriteFile(c.healthCheckLogPath(), newResults, 0700); err != nil {
+		return "", "", err
 	}
-	return ioutil.WriteFile(c.healthCheckLogPath(), newResults, 0700)
+	return oldStatus, healthCheck.Status, nil
 }
 
 // HealthCheckLogPath returns the path for where the health check log is
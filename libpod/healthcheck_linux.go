@@ -4,50 +4,15 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containers/libpod/pkg/rootless"
-	"github.com/coreos/go-systemd/dbus"
-	godbus "github.com/godbus/dbus"
+	"github.com/containers/libpod/pkg/systemd"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
-func dbusAuthRootlessConnection(createBus func(opts ...godbus.ConnOption) (*godbus.Conn, error)) (*godbus.Conn, error) {
-	conn, err := createBus()
-	if err != nil {
-		return nil, err
-	}
-
-	methods := []godbus.Auth{godbus.AuthExternal(strconv.Itoa(rootless.GetRootlessUID()))}
-
-	err = conn.Auth(methods)
-	if err != nil {
-		conn.Close()
-		return nil, err
-	}
-
-	return conn, nil
-}
-
-func newRootlessConnection() (*dbus.Conn, error) {
-	return dbus.NewConnection(func() (*godbus.Conn, error) {
-		return dbusAuthRootlessConnection(func(opts ...godbus.ConnOption) (*godbus.Conn, error) {
-			path := filepath.Join(os.Getenv("XDG_RUNTIME_DIR"), "systemd/private")
-			return godbus.Dial(fmt.Sprintf("unix:path=%s", path))
-		})
-	})
-}
-
-func getConnection() (*dbus.Conn, error) {
-	if rootless.IsRootless() {
-		return newRootlessConnection()
-	}
-	return dbus.NewSystemdConnection()
-}
-
 // createTimer systemd timers for healthchecks of a container
 func (c *Container) createTimer() error {
 	if c.disableHealthCheckSystemd() {
@@ -58,24 +23,61 @@ func (c *Container) createTimer() error {
 		return errors.Wrapf(err, "failed to get path for podman for a health check timer")
 	}
 
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		logrus.Debugf("Unable to get systemd connection to add healthchecks, falling back to a podman scheduler: %v", err)
+		return c.setHealthCheckScheduler(HealthCheckSchedulerPodman)
+	}
+	// When the healthcheck restarts the container, the service of the
+	// previous timer is still running it, and systemd-run cannot create a
+	// service with the same name. Only the timer is created then, and it
+	// triggers the existing service, which runs the same command.
+	serviceName := fmt.Sprintf("%s.service", c.ID())
+	loadState, err := conn.GetUnitProperty(serviceName, "LoadState")
+	conn.Close()
+	if err != nil {
+		logrus.Debugf("Unable to get the state of healthcheck service %s, falling back to a podman scheduler: %v", serviceName, err)
+		return c.setHealthCheckScheduler(HealthCheckSchedulerPodman)
+	}
+	serviceExists := loadState.Value.Value() == "loaded"
+	if serviceExists {
+		logrus.Debugf("Healthcheck service %s exists, only creating its timer", serviceName)
+	}
+
 	var cmd = []string{}
 	if rootless.IsRootless() {
 		cmd = append(cmd, "--user")
 	}
-	cmd = append(cmd, "--unit", c.ID(), fmt.Sprintf("--on-unit-inactive=%s", c.HealthCheckConfig().Interval.String()), "--timer-property=AccuracySec=1s", podman, "healthcheck", "run", c.ID())
+	cmd = append(cmd, healthCheckTimerArgs(c.ID(), c.HealthCheckConfig().Interval, podman, serviceExists)...)
 
-	conn, err := getConnection()
-	if err != nil {
-		return errors.Wrapf(err, "unable to get systemd connection to add healthchecks")
-	}
-	conn.Close()
 	logrus.Debugf("creating systemd-transient files: %s %s", "systemd-run", cmd)
 	systemdRun := exec.Command("systemd-run", cmd...)
-	_, err = systemdRun.CombinedOutput()
-	if err != nil {
-		return err
+	if output, err := systemdRun.CombinedOutput(); err != nil {
+		logrus.Debugf("Unable to create systemd timer for healthchecks, falling back to a podman scheduler: %v: %s", err, strings.TrimSpace(string(output)))
+		return c.setHealthCheckScheduler(HealthCheckSchedulerPodman)
 	}
-	return nil
+	return c.setHealthCheckScheduler(HealthCheckSchedulerSystemd)
+}
+
+// healthCheckTimerArgs returns the systemd-run arguments creating the timer
+// running the healthchecks of a container, along with the service it
+// triggers unless that service exists already.
+func healthCheckTimerArgs(ctrID string, interval time.Duration, podman string, serviceExists bool) []string {
+	timerArgs := []string{fmt.Sprintf("--on-unit-inactive=%s", interval.String()), "--timer-property=AccuracySec=1s"}
+	if serviceExists {
+		// Without a command, systemd-run creates a timer for the
+		// given existing unit
+		return append([]string{"--unit", fmt.Sprintf("%s.service", ctrID)}, timerArgs...)
+	}
+	args := append([]string{"--unit", ctrID}, timerArgs...)
+	return append(args, podman, "healthcheck", "run", ctrID)
+}
+
+// setHealthCheckScheduler records how the healthchecks of the container are
+// scheduled
+func (c *Container) setHealthCheckScheduler(scheduler string) error {
+	c.state.HealthCheckScheduler = scheduler
+	return c.save()
 }
 
 // startTimer starts a systemd timer for the healthchecks
@@ -83,7 +85,10 @@ func (c *Container) startTimer() error {
 	if c.disableHealthCheckSystemd() {
 		return nil
 	}
-	conn, err := getConnection()
+	if c.state.HealthCheckScheduler == HealthCheckSchedulerPodman {
+		return c.startHealthCheckScheduler()
+	}
+	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
 		return errors.Wrapf(err, "unable to get systemd connection to start healthchecks")
 	}
@@ -98,7 +103,10 @@ func (c *Container) removeTimer() error {
 	if c.disableHealthCheckSystemd() {
 		return nil
 	}
-	conn, err := getConnection()
+	if c.state.HealthCheckScheduler == HealthCheckSchedulerPodman {
+		return c.stopHealthCheckScheduler()
+	}
+	conn, err := systemd.ConnectToDBUS()
 	if err != nil {
 		return errors.Wrapf(err, "unable to get systemd connection to remove healthchecks")
 	}
@@ -113,3 +121,25 @@ func (c *Container) removeTimer() error {
 	}
 	return err
 }
+
+// startHealthCheckScheduler starts a podman process running the healthchecks
+// of the container on its interval, for hosts where systemd timers are not
+// available
+func (c *Container) startHealthCheckScheduler() error {
+	pid, err := c.startPodmanHelper("healthcheck scheduler", c.podmanHelperCommand("healthcheck", "schedule", c.ID()))
+	if err != nil {
+		return err
+	}
+	c.state.HealthCheckSchedulerPID = pid
+	return nil
+}
+
+// stopHealthCheckScheduler stops the podman process running the healthchecks
+// of the container
+func (c *Container) stopHealthCheckScheduler() error {
+	pid := c.state.HealthCheckSchedulerPID
+	c.state.HealthCheckSchedulerPID = 0
+	// The scheduler itself may be stopping the container as a healthcheck
+	// on failure action. It notices it was replaced and exits by itself.
+	return c.stopPodmanHelper("healthcheck scheduler", pid)
+}
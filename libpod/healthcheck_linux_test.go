@@ -0,0 +1,18 @@
+package libpod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCheckTimerArgs(t *testing.T) {
+	args := healthCheckTimerArgs("abc", 30*time.Second, "/usr/bin/podman", false)
+	assert.Equal(t, []string{"--unit", "abc", "--on-unit-inactive=30s", "--timer-property=AccuracySec=1s", "/usr/bin/podman", "healthcheck", "run", "abc"}, args)
+
+	// The service of the previous timer is still running the healthcheck
+	// that restarted the container
+	args = healthCheckTimerArgs("abc", 30*time.Second, "/usr/bin/podman", true)
+	assert.Equal(t, []string{"--unit", "abc.service", "--on-unit-inactive=30s", "--timer-property=AccuracySec=1s"}, args)
+}
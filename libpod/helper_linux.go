@@ -0,0 +1,98 @@
+package libpod
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// podmanHelperCommand returns a command running podman with the given
+// arguments on behalf of the container. It reuses the global options of the
+// container's exit command, so the helper uses the same storage and runtime
+// configuration as the container.
+func (c *Container) podmanHelperCommand(args ...string) []string {
+	var cmd []string
+	exitCmd := c.config.ExitCommand
+	for i := 0; i+1 < len(exitCmd); i++ {
+		if exitCmd[i] == "container" && exitCmd[i+1] == "cleanup" {
+			cmd = append(cmd, exitCmd[:i]...)
+			break
+		}
+	}
+	if len(cmd) == 0 {
+		podman, err := os.Executable()
+		if err != nil {
+			podman = "podman"
+		}
+		cmd = []string{podman}
+	}
+	return append(cmd, args...)
+}
+
+// startPodmanHelper starts a podman process detached from the current one,
+// so it outlives the podman process starting the container, and returns its
+// PID. The helper is expected to exit by itself along with the container.
+func (c *Container) startPodmanHelper(name string, cmd []string) (int, error) {
+	logrus.Debugf("Starting %s for container %s: %v", name, c.ID(), cmd)
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error opening %s", os.DevNull)
+	}
+	defer devNull.Close()
+
+	helper := exec.Command(cmd[0], cmd[1:]...)
+	helper.Stdin = devNull
+	helper.Stdout = devNull
+	helper.Stderr = devNull
+	helper.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := helper.Start(); err != nil {
+		return 0, errors.Wrapf(err, "unable to start %s for container %s", name, c.ID())
+	}
+	pid := helper.Process.Pid
+	return pid, helper.Process.Release()
+}
+
+// stopPodmanHelper stops a podman process started by startPodmanHelper.
+// A helper may itself be acting on the container, in which case it is not
+// stopped and is expected to notice it was replaced.
+func (c *Container) stopPodmanHelper(name string, pid int) error {
+	if pid == 0 || pid == os.Getpid() {
+		return nil
+	}
+	isHelper, err := c.isPodmanHelper(pid)
+	if err != nil {
+		return errors.Wrapf(err, "unable to stop %s of container %s", name, c.ID())
+	}
+	if !isHelper {
+		logrus.Debugf("Process %d is no longer the %s of container %s, not stopping it", pid, name, c.ID())
+		return nil
+	}
+	if err := unix.Kill(pid, unix.SIGTERM); err != nil && err != unix.ESRCH {
+		return errors.Wrapf(err, "unable to stop %s of container %s", name, c.ID())
+	}
+	return nil
+}
+
+// isPodmanHelper returns whether the process with the given PID is a helper
+// of the container, so a process which reused the PID of an exited helper is
+// not signalled. Helpers are started with the container's ID as their last
+// argument.
+func (c *Container) isPodmanHelper(pid int) (bool, error) {
+	cmdline, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	args := strings.Split(strings.TrimSuffix(string(cmdline), "\x00"), "\x00")
+	return len(args) > 1 && args[len(args)-1] == c.ID(), nil
+}
@@ -0,0 +1,55 @@
+// +build linux
+
+package libpod
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestPodmanHelperCommand(t *testing.T) {
+	c := Container{
+		config: &ContainerConfig{
+			ID:          "123abc",
+			ExitCommand: []string{"/usr/bin/podman", "--root", "/var/lib/containers", "--log-level", "error", "container", "cleanup", "--rm"},
+		},
+	}
+	assert.Equal(t, []string{"/usr/bin/podman", "--root", "/var/lib/containers", "--log-level", "error", "healthcheck", "schedule", "123abc"}, c.podmanHelperCommand("healthcheck", "schedule", c.ID()))
+
+	c.config.ExitCommand = nil
+	cmd := c.podmanHelperCommand("healthcheck", "schedule", c.ID())
+	assert.Len(t, cmd, 4)
+	assert.Equal(t, []string{"healthcheck", "schedule", "123abc"}, cmd[1:])
+}
+
+func TestStopPodmanHelper(t *testing.T) {
+	c := Container{config: &ContainerConfig{ID: "123abc"}}
+	other := Container{config: &ContainerConfig{ID: "456def"}}
+
+	// The container ID is the last argument of the process, as for helpers
+	helper := exec.Command("sh", "-c", "sleep 60; exit 0", c.ID())
+	require.NoError(t, helper.Start())
+	defer helper.Process.Kill()
+
+	isHelper, err := c.isPodmanHelper(helper.Process.Pid)
+	assert.NoError(t, err)
+	assert.True(t, isHelper)
+
+	// A process which is not a helper of the container is left alone
+	isHelper, err = other.isPodmanHelper(helper.Process.Pid)
+	assert.NoError(t, err)
+	assert.False(t, isHelper)
+	assert.NoError(t, other.stopPodmanHelper("test helper", helper.Process.Pid))
+	assert.NoError(t, unix.Kill(helper.Process.Pid, 0))
+
+	assert.NoError(t, c.stopPodmanHelper("test helper", helper.Process.Pid))
+	assert.Error(t, helper.Wait())
+
+	isHelper, err = c.isPodmanHelper(helper.Process.Pid)
+	assert.NoError(t, err)
+	assert.False(t, isHelper)
+}
@@ -333,6 +333,18 @@ func (i *Image) RepoDigests() ([]string, error) {
 	return repoDigests, nil
 }
 
+// HasDifferentDigest returns true if the image the remote reference points to
+// differs from the local image.  The digests of the image configurations are
+// compared as the image ID is derived from them, which keeps the comparison
+// independent of manifest lists and of how the layers were compressed.
+func (i *Image) HasDifferentDigest(ctx context.Context, remote types.ImageReference, sc *types.SystemContext) (bool, error) {
+	remoteDigest, err := getImageDigest(ctx, remote, sc)
+	if err != nil {
+		return false, errors.Wrapf(err, "error getting digest of %s", transports.ImageName(remote))
+	}
+	return remoteDigest != "@"+i.ID(), nil
+}
+
 // Created returns the time the image was created
 func (i *Image) Created() time.Time {
 	return i.image.Created
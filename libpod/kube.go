@@ -1,8 +1,11 @@
 package libpod
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"math/rand"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -11,14 +14,32 @@ import (
 	"github.com/containers/libpod/pkg/lookup"
 	"github.com/containers/libpod/pkg/util"
 	"github.com/cri-o/ocicni/pkg/ocicni"
+	"github.com/docker/go-units"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+const (
+	// kubeDefaultVolumeSize is the storage requested by the persistent
+	// volume claims of volumes created without a size
+	kubeDefaultVolumeSize = "1Gi"
+
+	// KubeVolumeNameAnnotation is the annotation of a persistent volume
+	// claim holding the name of the volume it was generated from, if the
+	// name of the volume is not a valid claim name
+	KubeVolumeNameAnnotation = "io.podman.annotations.volume-name"
+)
+
+// kubeInvalidClaimChars matches the characters which are not allowed in the
+// name of a persistent volume claim
+var kubeInvalidClaimChars = regexp.MustCompile("[^a-z0-9-]+")
+
 // GenerateForKube takes a slice of libpod containers and generates
 // one v1.Pod description that includes just a single container.
 func (c *Container) GenerateForKube() (*v1.Pod, error) {
@@ -64,6 +85,89 @@ func (p *Pod) GenerateForKube() (*v1.Pod, []v1.ServicePort, error) {
 	return pod, servicePorts, err
 }
 
+// GenerateForKube generates a v1.PersistentVolumeClaim description of a named
+// volume.  The claim requests the size of the volume if one was given when
+// creating it and kubeDefaultVolumeSize otherwise.
+func (v *Volume) GenerateForKube() (*v1.PersistentVolumeClaim, error) {
+	size, err := kubeVolumeSize(v.config.Options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid size of volume %s", v.Name())
+	}
+	name := kubeClaimName(v.Name())
+	var annotations map[string]string
+	if name != v.Name() {
+		annotations = map[string]string{KubeVolumeNameAnnotation: v.Name()}
+	}
+	claim := v1.PersistentVolumeClaim{
+		TypeMeta: v12.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: v12.ObjectMeta{
+			Name:              name,
+			Labels:            v.Labels(),
+			Annotations:       annotations,
+			CreationTimestamp: v12.Now(),
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceStorage: *size,
+				},
+			},
+		},
+	}
+	return &claim, nil
+}
+
+// GenerateKubeClaims generates v1.PersistentVolumeClaim descriptions of the
+// named volumes mounted by the given containers.  Volumes mounted by several
+// containers are only described once.
+func GenerateKubeClaims(ctrs []*Container) ([]*v1.PersistentVolumeClaim, error) {
+	var claims []*v1.PersistentVolumeClaim
+	seen := make(map[string]bool)
+	for _, ctr := range ctrs {
+		namedVolumes, _ := ctr.sortUserVolumes(ctr.config.Spec)
+		for _, namedVolume := range namedVolumes {
+			if seen[namedVolume.Name] {
+				continue
+			}
+			seen[namedVolume.Name] = true
+			vol, err := ctr.runtime.state.Volume(namedVolume.Name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error retrieving volume %s of container %s", namedVolume.Name, ctr.ID())
+			}
+			claim, err := vol.GenerateForKube()
+			if err != nil {
+				return nil, err
+			}
+			claims = append(claims, claim)
+		}
+	}
+	return claims, nil
+}
+
+// kubeVolumeSize returns the size of a volume as given by the size option or
+// the size mount option of the volume
+func kubeVolumeSize(options map[string]string) (*resource.Quantity, error) {
+	size := options[volumeOptionSize]
+	for _, o := range strings.Split(options[volumeOptionOptions], ",") {
+		if strings.HasPrefix(o, "size=") {
+			size = strings.TrimPrefix(o, "size=")
+		}
+	}
+	if size == "" {
+		quantity := resource.MustParse(kubeDefaultVolumeSize)
+		return &quantity, nil
+	}
+	bytes, err := units.RAMInBytes(size)
+	if err != nil {
+		return nil, err
+	}
+	return resource.NewQuantity(bytes, resource.BinarySI), nil
+}
+
 func (p *Pod) getInfraContainer() (*Container, error) {
 	infraID, err := p.InfraContainerID()
 	if err != nil {
@@ -129,12 +233,16 @@ func containersToServicePorts(containers []v1.Container) []v1.ServicePort {
 
 func (p *Pod) podWithContainers(containers []*Container, ports []v1.ContainerPort) (*v1.Pod, error) {
 	var (
-		podContainers []v1.Container
+		podContainers  []v1.Container
+		initContainers []v1.Container
 	)
 	deDupPodVolumes := make(map[string]*v1.Volume)
 	first := true
-	for _, ctr := range containers {
+	// Init containers are listed in the order they run in
+	libpodInitCtrs, libpodCtrs := splitInitCtrs(containers)
+	for _, ctr := range append(libpodInitCtrs, libpodCtrs...) {
 		if !ctr.IsInfra() {
+			isInitCtr := ctr.IsInitCtr()
 			ctr, volumes, err := containerToV1Container(ctr)
 			if err != nil {
 				return nil, err
@@ -144,14 +252,18 @@ func (p *Pod) podWithContainers(containers []*Container, ports []v1.ContainerPor
 			// infra container, wipe them here.
 			ctr.Ports = nil
 
-			// We add the original port declarations from the libpod infra container
-			// to the first kubernetes container description because otherwise we loose
-			// the original container/port bindings.
-			if first && len(ports) > 0 {
-				ctr.Ports = ports
-				first = false
+			if isInitCtr {
+				initContainers = append(initContainers, ctr)
+			} else {
+				// We add the original port declarations from the libpod infra container
+				// to the first kubernetes container description because otherwise we loose
+				// the original container/port bindings.
+				if first && len(ports) > 0 {
+					ctr.Ports = ports
+					first = false
+				}
+				podContainers = append(podContainers, ctr)
 			}
-			podContainers = append(podContainers, ctr)
 			// Deduplicate volumes, so if containers in the pod share a volume, it's only
 			// listed in the volumes section once
 			for _, vol := range volumes {
@@ -165,10 +277,10 @@ func (p *Pod) podWithContainers(containers []*Container, ports []v1.ContainerPor
 		podVolumes = append(podVolumes, *vol)
 	}
 
-	return addContainersAndVolumesToPodObject(podContainers, podVolumes, p.Name()), nil
+	return addContainersAndVolumesToPodObject(podContainers, initContainers, podVolumes, p.Name()), nil
 }
 
-func addContainersAndVolumesToPodObject(containers []v1.Container, volumes []v1.Volume, podName string) *v1.Pod {
+func addContainersAndVolumesToPodObject(containers, initContainers []v1.Container, volumes []v1.Volume, podName string) *v1.Pod {
 	tm := v12.TypeMeta{
 		Kind:       "Pod",
 		APIVersion: "v1",
@@ -187,8 +299,9 @@ func addContainersAndVolumesToPodObject(containers []v1.Container, volumes []v1.
 		CreationTimestamp: v12.Now(),
 	}
 	ps := v1.PodSpec{
-		Containers: containers,
-		Volumes:    volumes,
+		Containers:     containers,
+		InitContainers: initContainers,
+		Volumes:        volumes,
 	}
 	p := v1.Pod{
 		TypeMeta:   tm,
@@ -207,7 +320,7 @@ func simplePodWithV1Container(ctr *Container) (*v1.Pod, error) {
 		return nil, err
 	}
 	containers = append(containers, kubeCtr)
-	return addContainersAndVolumesToPodObject(containers, kubeVols, ctr.Name()), nil
+	return addContainersAndVolumesToPodObject(containers, nil, kubeVols, ctr.Name()), nil
 
 }
 
@@ -328,8 +441,12 @@ func libpodMountsToKubeVolumeMounts(c *Container) ([]v1.VolumeMount, []v1.Volume
 	var vms []v1.VolumeMount
 	var vos []v1.Volume
 
-	// TjDO when named volumes are supported in play kube, also parse named volumes here
-	_, mounts := c.sortUserVolumes(c.config.Spec)
+	namedVolumes, mounts := c.sortUserVolumes(c.config.Spec)
+	for _, v := range namedVolumes {
+		vm, vo := generateKubePersistentVolumeClaimMount(v)
+		vms = append(vms, vm)
+		vos = append(vos, vo)
+	}
 	for _, m := range mounts {
 		vm, vo, err := generateKubeVolumeMount(m)
 		if err != nil {
@@ -377,6 +494,49 @@ func generateKubeVolumeMount(m specs.Mount) (v1.VolumeMount, v1.Volume, error) {
 	return vm, vo, nil
 }
 
+// generateKubePersistentVolumeClaimMount takes a named volume and returns a
+// kubernetes VolumeMount (to be added to the container) and a kubernetes
+// Volume referring to the persistent volume claim of the named volume (to be
+// added to the pod)
+func generateKubePersistentVolumeClaimMount(v *ContainerNamedVolume) (v1.VolumeMount, v1.Volume) {
+	vm := v1.VolumeMount{}
+	vo := v1.Volume{}
+
+	name := kubeClaimName(v.Name)
+	vm.Name = name
+	vm.MountPath = v.Dest
+	if util.StringInSlice("ro", v.Options) {
+		vm.ReadOnly = true
+	}
+
+	vo.Name = name
+	vo.PersistentVolumeClaim = &v1.PersistentVolumeClaimVolumeSource{
+		ClaimName: name,
+		ReadOnly:  vm.ReadOnly,
+	}
+	return vm, vo
+}
+
+// kubeClaimName converts the name of a named volume into the name of a
+// kubernetes persistent volume claim.  Names which are not valid claim names
+// are sanitized and get a suffix derived from the original name, so distinct
+// volumes never share a claim.
+func kubeClaimName(volumeName string) string {
+	if len(validation.IsDNS1123Label(volumeName)) == 0 {
+		return volumeName
+	}
+	sum := sha256.Sum256([]byte(volumeName))
+	suffix := hex.EncodeToString(sum[:])[:8]
+	name := strings.Trim(kubeInvalidClaimChars.ReplaceAllString(strings.ToLower(volumeName), "-"), "-")
+	if len(name) > validation.DNS1123LabelMaxLength-len(suffix)-1 {
+		name = strings.TrimRight(name[:validation.DNS1123LabelMaxLength-len(suffix)-1], "-")
+	}
+	if name == "" {
+		return suffix
+	}
+	return name + "-" + suffix
+}
+
 func isHostPathDirectory(hostPathSource string) (bool, error) {
 	info, err := os.Stat(hostPathSource)
 	if err != nil {
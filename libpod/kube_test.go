@@ -0,0 +1,60 @@
+package libpod
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestKubeClaimName(t *testing.T) {
+	assert.Equal(t, "data", kubeClaimName("data"))
+
+	names := []string{"My_Data.v1", "my_data.v1", "my.data.v1", "_", strings.Repeat("a", 64)}
+	claims := make(map[string]bool)
+	for _, name := range names {
+		claim := kubeClaimName(name)
+		assert.Empty(t, validation.IsDNS1123Label(claim), "claim name %q of volume %q", claim, name)
+		assert.False(t, claims[claim], "claim name %q is not unique", claim)
+		claims[claim] = true
+	}
+	assert.True(t, strings.HasPrefix(kubeClaimName("My_Data.v1"), "my-data-v1-"))
+}
+
+func TestVolumeGenerateForKube(t *testing.T) {
+	for _, name := range []string{"data", "My_Data.v1"} {
+		vol, err := newVolume(nil)
+		require.NoError(t, err)
+		vol.config.Name = name
+
+		claim, err := vol.GenerateForKube()
+		require.NoError(t, err)
+
+		// play kube creates the volume named by the annotation, or
+		// named after the claim if there is none
+		volumeName := claim.Name
+		if annotation, ok := claim.Annotations[KubeVolumeNameAnnotation]; ok {
+			volumeName = annotation
+		}
+		assert.Equal(t, name, volumeName)
+	}
+}
+
+func TestKubeVolumeSize(t *testing.T) {
+	size, err := kubeVolumeSize(map[string]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, "1Gi", size.String())
+
+	size, err = kubeVolumeSize(map[string]string{"size": "512m"})
+	assert.NoError(t, err)
+	assert.Equal(t, "512Mi", size.String())
+
+	size, err = kubeVolumeSize(map[string]string{"o": "uid=1000,size=2g"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2Gi", size.String())
+
+	_, err = kubeVolumeSize(map[string]string{"size": "big"})
+	assert.Error(t, err)
+}
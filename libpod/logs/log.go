@@ -2,7 +2,7 @@ package logs
 
 import (
 	"fmt"
-	"io/ioutil"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -24,17 +24,48 @@ const (
 
 	// FullLogType signifies a log line is full
 	FullLogType = "F"
+
+	// colorReset is the terminal escape sequence resetting the color
+	colorReset = "\033[0m"
+
+	// rotatedLogTimeout is how long to wait for a log file to be recreated
+	// after it was rotated
+	rotatedLogTimeout = time.Second
 )
 
+// colorPalette holds the terminal escape sequences of the colors of the
+// containers prefixing their log lines
+var colorPalette = []string{
+	"\033[36m", // cyan
+	"\033[33m", // yellow
+	"\033[32m", // green
+	"\033[35m", // magenta
+	"\033[34m", // blue
+	"\033[31m", // red
+	"\033[96m", // bright cyan
+	"\033[93m", // bright yellow
+	"\033[92m", // bright green
+	"\033[95m", // bright magenta
+	"\033[94m", // bright blue
+	"\033[91m", // bright red
+}
+
 // LogOptions is the options you can use for logs
 type LogOptions struct {
 	Details    bool
 	Follow     bool
 	Since      time.Time
+	Until      time.Time
+	Stream     string
 	Tail       uint64
 	Timestamps bool
 	Multi      bool
-	WaitGroup  *sync.WaitGroup
+	// Color colors the container prefix of the lines when Multi is set
+	Color     bool
+	WaitGroup *sync.WaitGroup
+
+	// colors holds the colors of the containers whose lines were output
+	colors map[string]string
 }
 
 // LogLine describes the information for each line of a log
@@ -44,19 +75,31 @@ type LogLine struct {
 	Time         time.Time
 	Msg          string
 	CID          string
+	CName        string
 }
 
-// GetLogFile returns an hp tail for a container given options
+// GetLogFile returns an hp tail for a container given options. Lines of
+// rotated segments of the log file are returned along with the lines
+// already in the log file when tailing.
 func GetLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error) {
 	var (
 		whence  int
 		err     error
 		logTail []*LogLine
 	)
+	rotated, err := RotatedLogFiles(path)
+	if err != nil {
+		return nil, nil, err
+	}
 	// whence 0=origin, 2=end
 	if options.Tail > 0 {
 		whence = 2
-		logTail, err = getTailLog(path, int(options.Tail))
+		logTail, err = getTailLog(path, rotated, int(options.Tail), options)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if len(rotated) > 0 {
+		logTail, err = getRotatedLog(rotated, options.Stream)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -70,47 +113,113 @@ func GetLogFile(path string, options *LogOptions) (*tail.Tail, []*LogLine, error
 	return t, logTail, err
 }
 
-func getTailLog(path string, tail int) ([]*LogLine, error) {
+// FollowRotatedLogFile returns an hp tail following the log file at path from
+// its start, once it was recreated after being rotated. It returns an error
+// satisfying os.IsNotExist if the log file was removed rather than rotated.
+func FollowRotatedLogFile(path string) (*tail.Tail, error) {
+	deadline := time.Now().Add(rotatedLogTimeout)
+	for {
+		_, err := os.Stat(path)
+		if err == nil {
+			break
+		}
+		if !os.IsNotExist(err) || time.Now().After(deadline) {
+			return nil, err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return tail.TailFile(path, tail.Config{MustExist: true, Poll: true, Follow: true, Logger: tail.DiscardingLogger})
+}
+
+// getRotatedLog returns the lines of the rotated segments of a log file
+// written to the given stream, oldest first
+func getRotatedLog(rotated []string, stream string) ([]*LogLine, error) {
 	var (
-		tailLog     []*LogLine
-		nlls        []*LogLine
-		tailCounter int
-		partial     string
+		rotatedLog []*LogLine
+		partial    string
 	)
-	content, err := ioutil.ReadFile(path)
-	if err != nil {
-		return nil, err
+	for _, segment := range rotated {
+		lines, err := readLogSegment(segment, stream, &partial)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		rotatedLog = append(rotatedLog, lines...)
 	}
-	splitContent := strings.Split(string(content), "\n")
-	// We read the content in reverse and add each nll until we have the same
-	// number of F type messages as the desired tail
-	for i := len(splitContent) - 1; i >= 0; i-- {
-		if len(splitContent[i]) == 0 {
-			continue
+	return rotatedLog, nil
+}
+
+// getTailLog returns the last tail messages of the log file at path and of its
+// rotated segments selected by the stream, since and until options. Log files
+// are read backwards from their end, so only the part of the log that is
+// returned is read.
+func getTailLog(path string, rotated []string, tail int, options *LogOptions) ([]*LogLine, error) {
+	var (
+		tailLog      []*LogLine
+		reachedStart bool
+	)
+	segments := append(rotated, path)
+	// We read the segments from the newest one until we have the desired
+	// tail, and the partial message the oldest message may start with in
+	// the previous segment
+	for i := len(segments) - 1; i >= 0; i-- {
+		missing := tail - len(tailLog)
+		if missing <= 0 && !reachedStart {
+			break
 		}
-		nll, err := NewLogLine(splitContent[i])
+		if missing < 0 {
+			missing = 0
+		}
+		lines, partial, start, err := tailLogSegment(segments[i], missing, options)
 		if err != nil {
+			// A rotated segment may have been removed by a rotation
+			if os.IsNotExist(err) && i < len(segments)-1 {
+				break
+			}
 			return nil, err
 		}
-		nlls = append(nlls, nll)
-		if !nll.Partial() {
-			tailCounter = tailCounter + 1
+		// The partial message at the end of a rotated segment is
+		// completed by the first message of the following segment
+		if i < len(segments)-1 && partial != "" && len(tailLog) > 0 {
+			tailLog[0].Msg = partial + tailLog[0].Msg
 		}
-		if tailCounter == tail {
+		tailLog = append(lines, tailLog...)
+		reachedStart = start
+		// The older segments were all logged before since
+		if len(tailLog) > 0 && !tailLog[0].Since(options.Since) {
+			tailLog = tailLog[1:]
 			break
 		}
 	}
-	// Now we iterate the results and assemble partial messages to become full messages
-	for _, nll := range nlls {
+	return tailLog, nil
+}
+
+// readLogSegment reads the lines of a log file or of one of its rotated
+// segments written to the given stream, assembling partial messages to become full messages. partial
+// holds the partial message left by the previous segment, and is updated
+// with the one left at the end of this segment.
+func readLogSegment(path, stream string, partial *string) ([]*LogLine, error) {
+	var lines []*LogLine
+	f, err := openLogSegment(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	err = readLogLines(f, path, func(nll *LogLine) {
+		if !nll.FromStream(stream) {
+			return
+		}
 		if nll.Partial() {
-			partial = partial + nll.Msg
-		} else {
-			nll.Msg = nll.Msg + partial
-			tailLog = append(tailLog, nll)
-			partial = ""
+			*partial = *partial + nll.Msg
+			return
 		}
-	}
-	return tailLog, nil
+		nll.Msg = *partial + nll.Msg
+		*partial = ""
+		lines = append(lines, nll)
+	})
+	return lines, err
 }
 
 // String converts a logline to a string for output given whether a detail
@@ -118,11 +227,17 @@ func getTailLog(path string, tail int) ([]*LogLine, error) {
 func (l *LogLine) String(options *LogOptions) string {
 	var out string
 	if options.Multi {
-		cid := l.CID
-		if len(cid) > 12 {
-			cid = cid[:12]
+		prefix := l.CName
+		if prefix == "" {
+			prefix = l.CID
+			if len(prefix) > 12 {
+				prefix = prefix[:12]
+			}
+		}
+		if options.Color {
+			prefix = options.color(l.CID) + prefix + colorReset
 		}
-		out = fmt.Sprintf("%s ", cid)
+		out = fmt.Sprintf("%s ", prefix)
 	}
 	if options.Timestamps {
 		out = out + fmt.Sprintf("%s ", l.Time.Format(LogTimeFormat))
@@ -130,11 +245,45 @@ func (l *LogLine) String(options *LogOptions) string {
 	return out + l.Msg
 }
 
+// PrepareColors sets up the colors of the containers before the options are
+// shared by the goroutines reading the logs, which copy them, as the colors
+// are only assigned while the lines are output
+func (o *LogOptions) PrepareColors() {
+	if o.Color && o.colors == nil {
+		o.colors = make(map[string]string)
+	}
+}
+
+// color returns the terminal escape sequence of the color of a container,
+// assigning the next color of the palette to containers in their order of
+// appearance
+func (o *LogOptions) color(cid string) string {
+	o.PrepareColors()
+	color, ok := o.colors[cid]
+	if !ok {
+		color = colorPalette[len(o.colors)%len(colorPalette)]
+		o.colors[cid] = color
+	}
+	return color
+}
+
 // Since returns a bool as to whether a log line occurred after a given time
 func (l *LogLine) Since(since time.Time) bool {
 	return l.Time.After(since)
 }
 
+// Until returns a bool as to whether a log line occurred before a given time.
+// All log lines occurred before the zero time.
+func (l *LogLine) Until(until time.Time) bool {
+	return until.IsZero() || !l.Time.After(until)
+}
+
+// FromStream returns a bool as to whether a log line was written to the given
+// stream, stdout or stderr. All log lines match an empty stream.
+func (l *LogLine) FromStream(stream string) bool {
+	return stream == "" || l.Device == stream
+}
+
 // NewLogLine creates a logLine struct from a container log string
 func NewLogLine(line string) (*LogLine, error) {
 	splitLine := strings.Split(line, " ")
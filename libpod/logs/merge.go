@@ -0,0 +1,190 @@
+package logs
+
+import (
+	"sync"
+	"time"
+)
+
+// mergeDelay is how long a followed log line is held, so lines logged at the
+// same time by the other followed containers can be merged in order
+const mergeDelay = 200 * time.Millisecond
+
+// Merger merges log lines read from several channels, each ordered by time,
+// into a single channel ordered by time. Without following, a line is sent
+// once every other channel sent a later line or was closed. When following,
+// a channel that sent all its lines and is idle cannot send a line logged
+// earlier than mergeDelay ago, so older lines of the other channels are sent.
+type Merger struct {
+	out    chan *LogLine
+	follow bool
+	events chan mergeEvent
+	wake   chan struct{}
+
+	lock   sync.Mutex
+	nextID int
+	added  []int
+	closed bool
+}
+
+// mergeEvent is a line received from one of the inputs of a merger, or the
+// closing of an input
+type mergeEvent struct {
+	input  int
+	line   *LogLine
+	closed bool
+}
+
+// mergeInput holds the lines of an input of a merger that were not sent yet
+type mergeInput struct {
+	id       int
+	lines    []*LogLine
+	open     bool
+	lastLine time.Time
+}
+
+// NewMerger returns a merger sending the merged log lines to out. follow
+// indicates the inputs are followed, and may be idle without being closed.
+func NewMerger(out chan *LogLine, follow bool) *Merger {
+	return &Merger{
+		out:    out,
+		follow: follow,
+		events: make(chan mergeEvent),
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Add adds a channel of log lines ordered by time to the inputs of the
+// merger. Inputs must be closed once all their lines were sent.
+func (m *Merger) Add(in <-chan *LogLine) {
+	m.lock.Lock()
+	id := m.nextID
+	m.nextID++
+	m.added = append(m.added, id)
+	m.lock.Unlock()
+
+	go func() {
+		for line := range in {
+			m.events <- mergeEvent{input: id, line: line}
+		}
+		m.events <- mergeEvent{input: id, closed: true}
+	}()
+	m.notify()
+}
+
+// Close indicates no inputs will be added to the merger anymore. Run returns
+// once the inputs were closed and all their lines were sent.
+func (m *Merger) Close() {
+	m.lock.Lock()
+	m.closed = true
+	m.lock.Unlock()
+	m.notify()
+}
+
+func (m *Merger) notify() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run merges the lines of the inputs until the merger is closed and all its
+// inputs were closed. Lines are received from the inputs while waiting to
+// send a line, so readers sending their lines before out is read never block.
+func (m *Merger) Run() {
+	var (
+		inputs = make(map[int]*mergeInput)
+		tick   <-chan time.Time
+	)
+	if m.follow {
+		ticker := time.NewTicker(mergeDelay / 4)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		closed := m.register(inputs)
+		for id, in := range inputs {
+			if !in.open && len(in.lines) == 0 {
+				delete(inputs, id)
+			}
+		}
+		if closed && len(inputs) == 0 {
+			return
+		}
+
+		var (
+			out  chan *LogLine
+			line *LogLine
+		)
+		next := m.next(inputs)
+		if next != nil {
+			out = m.out
+			line = next.lines[0]
+		}
+		select {
+		case out <- line:
+			next.lines[0] = nil
+			next.lines = next.lines[1:]
+		case event := <-m.events:
+			// Inputs are added before they send events
+			m.register(inputs)
+			in := inputs[event.input]
+			if event.closed {
+				in.open = false
+			} else {
+				in.lines = append(in.lines, event.line)
+				in.lastLine = time.Now()
+			}
+		case <-m.wake:
+		case <-tick:
+		}
+	}
+}
+
+// register adds the inputs added to the merger since its last call to the
+// inputs being merged, and returns whether the merger was closed
+func (m *Merger) register(inputs map[int]*mergeInput) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, id := range m.added {
+		inputs[id] = &mergeInput{id: id, open: true, lastLine: time.Now()}
+	}
+	m.added = nil
+	return m.closed
+}
+
+// next returns the input holding the oldest line not sent yet, if no other
+// input can precede that line anymore
+func (m *Merger) next(inputs map[int]*mergeInput) *mergeInput {
+	var next *mergeInput
+	for _, in := range inputs {
+		if len(in.lines) == 0 {
+			continue
+		}
+		if next == nil || in.lines[0].Time.Before(next.lines[0].Time) ||
+			(in.lines[0].Time.Equal(next.lines[0].Time) && in.id < next.id) {
+			next = in
+		}
+	}
+	if next == nil || !m.ordered(next.lines[0], inputs) {
+		return nil
+	}
+	return next
+}
+
+// ordered returns whether none of the inputs can send a line logged before
+// the given line anymore
+func (m *Merger) ordered(line *LogLine, inputs map[int]*mergeInput) bool {
+	now := time.Now()
+	for _, in := range inputs {
+		if len(in.lines) > 0 || !in.open {
+			continue
+		}
+		if !m.follow {
+			return false
+		}
+		if now.Sub(in.lastLine) < mergeDelay || now.Sub(line.Time) < mergeDelay {
+			return false
+		}
+	}
+	return true
+}
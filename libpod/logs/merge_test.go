@@ -0,0 +1,115 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sendLines sends lines logged at the given offsets from start to a new
+// channel, which is closed after the lines unless following
+func sendLines(start time.Time, msgs map[time.Duration]string, offsets []time.Duration, follow bool) chan *LogLine {
+	in := make(chan *LogLine)
+	go func() {
+		for _, offset := range offsets {
+			in <- &LogLine{Time: start.Add(offset), Msg: msgs[offset]}
+		}
+		if !follow {
+			close(in)
+		}
+	}()
+	return in
+}
+
+func TestMerger(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	msgs := map[time.Duration]string{
+		1 * time.Second: "a1",
+		2 * time.Second: "b2",
+		3 * time.Second: "a3",
+		4 * time.Second: "c4",
+		5 * time.Second: "b5",
+		6 * time.Second: "a6",
+	}
+	out := make(chan *LogLine)
+	merger := NewMerger(out, false)
+	go func() {
+		merger.Run()
+		close(out)
+	}()
+	merger.Add(sendLines(start, msgs, []time.Duration{1 * time.Second, 3 * time.Second, 6 * time.Second}, false))
+	merger.Add(sendLines(start, msgs, []time.Duration{2 * time.Second, 5 * time.Second}, false))
+	merger.Add(sendLines(start, msgs, []time.Duration{4 * time.Second}, false))
+	merger.Add(sendLines(start, msgs, nil, false))
+	merger.Close()
+
+	var merged []string
+	for line := range out {
+		merged = append(merged, line.Msg)
+	}
+	assert.Equal(t, []string{"a1", "b2", "a3", "c4", "b5", "a6"}, merged)
+}
+
+func TestMergerFollow(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	msgs := map[time.Duration]string{
+		1 * time.Second: "a1",
+		2 * time.Second: "b2",
+		3 * time.Second: "a3",
+	}
+	out := make(chan *LogLine)
+	merger := NewMerger(out, true)
+	go merger.Run()
+	// Followed inputs are not closed, and lines are sent once the idle
+	// inputs cannot precede them anymore
+	merger.Add(sendLines(start, msgs, []time.Duration{1 * time.Second, 3 * time.Second}, true))
+	merger.Add(sendLines(start, msgs, []time.Duration{2 * time.Second}, true))
+
+	var merged []string
+	timeout := time.After(5 * time.Second)
+	for len(merged) < 3 {
+		select {
+		case line := <-out:
+			merged = append(merged, line.Msg)
+		case <-timeout:
+			t.Fatalf("timed out merging followed lines, got %v", merged)
+		}
+	}
+	assert.Equal(t, []string{"a1", "b2", "a3"}, merged)
+
+	// An input added while following is merged as well
+	in := make(chan *LogLine)
+	merger.Add(in)
+	in <- &LogLine{Time: time.Now(), Msg: "c"}
+	select {
+	case line := <-out:
+		assert.Equal(t, "c", line.Msg)
+	case <-timeout:
+		t.Fatal("timed out merging a line of an added input")
+	}
+}
+
+func TestMergerBeforeReading(t *testing.T) {
+	out := make(chan *LogLine)
+	merger := NewMerger(out, false)
+	go func() {
+		merger.Run()
+		close(out)
+	}()
+	// Readers send all their lines before the merged lines are read
+	in := make(chan *LogLine)
+	merger.Add(in)
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 100; i++ {
+		in <- &LogLine{Time: start.Add(time.Duration(i) * time.Second)}
+	}
+	close(in)
+	merger.Close()
+
+	count := 0
+	for range out {
+		count++
+	}
+	assert.Equal(t, 100, count)
+}
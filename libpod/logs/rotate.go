@@ -0,0 +1,167 @@
+package logs
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// compressedLogSuffix is appended to rotated log files compressed with gzip
+const compressedLogSuffix = ".gz"
+
+// rotatedLogPath returns the path of the n-th rotated segment of the log file
+// at path, 1 being the most recent one
+func rotatedLogPath(path string, n int, compressed bool) string {
+	rotated := fmt.Sprintf("%s.%d", path, n)
+	if compressed {
+		rotated = rotated + compressedLogSuffix
+	}
+	return rotated
+}
+
+// RotatedLogFiles returns the rotated segments of the log file at path,
+// oldest first. Segments may be compressed.
+func RotatedLogFiles(path string) ([]string, error) {
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, errors.Wrapf(err, "error looking up rotated log files of %s", path)
+	}
+	segments := make(map[int]string)
+	for _, match := range matches {
+		suffix := strings.TrimSuffix(strings.TrimPrefix(match, path+"."), compressedLogSuffix)
+		n, err := strconv.Atoi(suffix)
+		if err != nil || n < 1 {
+			continue
+		}
+		// Prefer the compressed segment if compression was interrupted
+		if _, ok := segments[n]; ok && !strings.HasSuffix(match, compressedLogSuffix) {
+			continue
+		}
+		segments[n] = match
+	}
+	numbers := make([]int, 0, len(segments))
+	for n := range segments {
+		numbers = append(numbers, n)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(numbers)))
+	rotated := make([]string, 0, len(numbers))
+	for _, n := range numbers {
+		rotated = append(rotated, segments[n])
+	}
+	return rotated, nil
+}
+
+// RotateLogFile moves the log file at path to its first rotated segment,
+// shifting the existing segments and removing those beyond maxFiles, which
+// counts the log file in use. The caller is responsible for having the
+// writer of the log reopen it.
+func RotateLogFile(path string, maxFiles uint) error {
+	if maxFiles < 2 {
+		return errors.Errorf("rotating log file %s requires keeping at least 2 files", path)
+	}
+	rotated, err := RotatedLogFiles(path)
+	if err != nil {
+		return err
+	}
+	// rotated is oldest first, so segments are shifted without overwriting
+	// one another
+	for _, segment := range rotated {
+		compressed := strings.HasSuffix(segment, compressedLogSuffix)
+		n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(segment, path+"."), compressedLogSuffix))
+		if err != nil {
+			return errors.Wrapf(err, "invalid rotated log file %s", segment)
+		}
+		if uint(n+1) >= maxFiles {
+			if err := os.Remove(segment); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "error removing rotated log file %s", segment)
+			}
+			continue
+		}
+		if err := os.Rename(segment, rotatedLogPath(path, n+1, compressed)); err != nil {
+			return errors.Wrapf(err, "error rotating log file %s", segment)
+		}
+	}
+	if err := os.Rename(path, rotatedLogPath(path, 1, false)); err != nil {
+		return errors.Wrapf(err, "error rotating log file %s", path)
+	}
+	return nil
+}
+
+// CompressRotatedLogFile compresses the most recent rotated segment of the
+// log file at path with gzip. The segment is compressed to a temporary file
+// first, so readers never see a partially compressed segment.
+func CompressRotatedLogFile(path string) error {
+	segment := rotatedLogPath(path, 1, false)
+	src, err := os.Open(segment)
+	if err != nil {
+		return errors.Wrapf(err, "error opening rotated log file %s", segment)
+	}
+	defer src.Close()
+
+	compressed := rotatedLogPath(path, 1, true)
+	tmp := compressed + ".tmp"
+	dst, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "error creating compressed log file %s", tmp)
+	}
+	defer os.Remove(tmp)
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		dst.Close()
+		return errors.Wrapf(err, "error compressing log file %s", segment)
+	}
+	if err := zw.Close(); err != nil {
+		dst.Close()
+		return errors.Wrapf(err, "error compressing log file %s", segment)
+	}
+	if err := dst.Close(); err != nil {
+		return errors.Wrapf(err, "error writing compressed log file %s", tmp)
+	}
+	if err := os.Rename(tmp, compressed); err != nil {
+		return errors.Wrapf(err, "error renaming compressed log file %s", tmp)
+	}
+	if err := os.Remove(segment); err != nil {
+		return errors.Wrapf(err, "error removing rotated log file %s", segment)
+	}
+	return nil
+}
+
+// openLogSegment opens a log file or one of its rotated segments for
+// reading, decompressing it if needed
+func openLogSegment(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, compressedLogSuffix) {
+		return f, nil
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, errors.Wrapf(err, "error decompressing log file %s", path)
+	}
+	return &gzipLogSegment{Reader: zr, file: f}, nil
+}
+
+// gzipLogSegment closes both the gzip reader and the underlying file of a
+// compressed log segment
+type gzipLogSegment struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipLogSegment) Close() error {
+	err := g.Reader.Close()
+	if ferr := g.file.Close(); err == nil {
+		err = ferr
+	}
+	return err
+}
@@ -0,0 +1,136 @@
+package logs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeLog appends full log lines with the given messages to the log file
+// at path
+func writeLog(t *testing.T, path string, msgs ...string) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	defer f.Close()
+	for _, msg := range msgs {
+		_, err := fmt.Fprintf(f, "%s stdout %s %s\n", time.Now().Format(LogTimeFormat), FullLogType, msg)
+		require.NoError(t, err)
+	}
+}
+
+func messages(lines []*LogLine) []string {
+	msgs := make([]string, 0, len(lines))
+	for _, line := range lines {
+		msgs = append(msgs, line.Msg)
+	}
+	return msgs
+}
+
+func TestRotateLogFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ctr.log")
+
+	for i := 0; i < 4; i++ {
+		writeLog(t, path, fmt.Sprintf("line %d", i))
+		require.NoError(t, RotateLogFile(path, 3))
+	}
+	writeLog(t, path, "line 4")
+
+	rotated, err := RotatedLogFiles(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{path + ".2", path + ".1"}, rotated)
+
+	_, logTail, err := GetLogFile(path, &LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"line 2", "line 3"}, messages(logTail))
+}
+
+func TestRotateLogFileCompressed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ctr.log")
+
+	for i := 0; i < 3; i++ {
+		writeLog(t, path, fmt.Sprintf("line %da", i), fmt.Sprintf("line %db", i))
+		require.NoError(t, RotateLogFile(path, 5))
+		require.NoError(t, CompressRotatedLogFile(path))
+	}
+	writeLog(t, path, "line 3a", "line 3b")
+
+	rotated, err := RotatedLogFiles(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{path + ".3.gz", path + ".2.gz", path + ".1.gz"}, rotated)
+
+	_, logTail, err := GetLogFile(path, &LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"line 0a", "line 0b", "line 1a", "line 1b", "line 2a", "line 2b"}, messages(logTail))
+
+	// The tail spans the log file in use and rotated segments
+	_, logTail, err = GetLogFile(path, &LogOptions{Tail: 5})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"line 1b", "line 2a", "line 2b", "line 3a", "line 3b"}, messages(logTail))
+}
+
+func TestReadLogSegmentPartial(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ctr.log")
+
+	now := time.Now().Format(LogTimeFormat)
+	content := fmt.Sprintf("%[1]s stdout P hello \n%[1]s stdout F world\n%[1]s stdout P split ", now)
+	require.NoError(t, ioutil.WriteFile(path+".1", []byte(content), 0600))
+	require.NoError(t, ioutil.WriteFile(path, []byte(fmt.Sprintf("%s stdout F message\n", now)), 0600))
+
+	_, logTail, err := GetLogFile(path, &LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello world"}, messages(logTail))
+
+	rotated, err := RotatedLogFiles(path)
+	require.NoError(t, err)
+	var partial string
+	lines, err := readLogSegment(rotated[0], "", &partial)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello world"}, messages(lines))
+	assert.Equal(t, "split ", partial)
+	lines, err = readLogSegment(path, "", &partial)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"split message"}, messages(lines))
+}
+
+func TestFollowRotatedLogFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ctr.log")
+
+	writeLog(t, path, "before rotation")
+	require.NoError(t, RotateLogFile(path, 2))
+	written := make(chan error, 1)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		line := fmt.Sprintf("%s stdout %s after rotation\n", time.Now().Format(LogTimeFormat), FullLogType)
+		written <- ioutil.WriteFile(path, []byte(line), 0600)
+	}()
+	tl, err := FollowRotatedLogFile(path)
+	require.NoError(t, err)
+	require.NoError(t, <-written)
+	defer tl.Stop()
+	line := <-tl.Lines
+	nll, err := NewLogLine(line.Text)
+	require.NoError(t, err)
+	assert.Equal(t, "after rotation", nll.Msg)
+
+	// The log file was removed rather than rotated
+	_, err = FollowRotatedLogFile(filepath.Join(dir, "removed.log"))
+	assert.True(t, os.IsNotExist(err))
+}
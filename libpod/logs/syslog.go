@@ -0,0 +1,286 @@
+package logs
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultSyslogAddress is the address of the local syslog daemon
+	DefaultSyslogAddress = "unix:///dev/log"
+
+	// DefaultSyslogFacility is the facility of the log lines sent to syslog
+	DefaultSyslogFacility = "daemon"
+
+	// defaultSyslogPort is the port of remote syslog endpoints whose
+	// address has no port
+	defaultSyslogPort = "514"
+
+	// syslogTimeFormat is the RFC5424 timestamp format, which allows at
+	// most 6 digits for fractions of seconds
+	syslogTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+	// syslogSDID is the ID of the structured data element describing the
+	// container. 2312 is the private enterprise number of Red Hat.
+	syslogSDID = "podman@2312"
+
+	// Maximum lengths of RFC5424 header fields and parameter names
+	syslogHostnameMaxLen  = 255
+	syslogAppNameMaxLen   = 48
+	syslogParamNameMaxLen = 32
+
+	// Severities of the lines logged to stdout and stderr
+	syslogSeverityErr  = 3
+	syslogSeverityInfo = 6
+)
+
+// syslogFacilities maps the names of syslog facilities to their codes
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// SyslogWriter sends log lines to a syslog endpoint as RFC5424 messages,
+// over a unix socket, UDP or TCP. Messages sent over TCP are framed with their
+// length, as described by RFC6587, while messages sent over unix stream
+// sockets are terminated by a newline, as local syslog daemons expect.
+type SyslogWriter struct {
+	network  string
+	address  string
+	facility int
+	hostname string
+	appName  string
+	data     string
+	conn     net.Conn
+}
+
+// ParseSyslogAddress returns the network and address to connect to for a
+// syslog address of the form unix:///path, udp://host[:port] or
+// tcp://host[:port]. An empty address is the local syslog daemon.
+func ParseSyslogAddress(address string) (string, string, error) {
+	if address == "" {
+		address = DefaultSyslogAddress
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "invalid syslog address %q", address)
+	}
+	switch u.Scheme {
+	case "unix":
+		if u.Path == "" {
+			return "", "", errors.Errorf("invalid syslog address %q: missing socket path", address)
+		}
+		return u.Scheme, u.Path, nil
+	case "udp", "tcp":
+		if u.Host == "" {
+			return "", "", errors.Errorf("invalid syslog address %q: missing host", address)
+		}
+		host := u.Host
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), defaultSyslogPort)
+		}
+		return u.Scheme, host, nil
+	default:
+		return "", "", errors.Errorf("invalid syslog address %q: must be unix://, udp:// or tcp://", address)
+	}
+}
+
+// ParseSyslogFacility returns the code of the named syslog facility. An
+// empty name is the default facility.
+func ParseSyslogFacility(facility string) (int, error) {
+	if facility == "" {
+		facility = DefaultSyslogFacility
+	}
+	code, ok := syslogFacilities[facility]
+	if !ok {
+		return 0, errors.Errorf("invalid syslog facility %q", facility)
+	}
+	return code, nil
+}
+
+// NewSyslogWriter returns a writer sending log lines to the syslog endpoint
+// at address, with the given facility and tag. Every message carries the
+// given parameters as structured data. The connection to the endpoint is
+// opened when the first line is written.
+func NewSyslogWriter(address, facility, tag string, params map[string]string) (*SyslogWriter, error) {
+	network, addr, err := ParseSyslogAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	code, err := ParseSyslogFacility(facility)
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = ""
+	}
+	return &SyslogWriter{
+		network:  network,
+		address:  addr,
+		facility: code,
+		hostname: syslogHeaderField(hostname, syslogHostnameMaxLen),
+		appName:  syslogHeaderField(tag, syslogAppNameMaxLen),
+		data:     syslogStructuredData(params),
+	}, nil
+}
+
+// Write sends a log line to the syslog endpoint. A broken connection is
+// reopened once before giving up on the line.
+func (w *SyslogWriter) Write(line *LogLine) error {
+	msg := w.format(line)
+	err := w.send(msg)
+	if err != nil && w.conn != nil {
+		w.Close()
+		err = w.send(msg)
+	}
+	return err
+}
+
+// Close closes the connection to the syslog endpoint
+func (w *SyslogWriter) Close() error {
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+// format returns the RFC5424 message of a log line. Lines logged to stderr
+// have the error severity, the others the informational one.
+func (w *SyslogWriter) format(line *LogLine) string {
+	severity := syslogSeverityInfo
+	if line.Device == "stderr" {
+		severity = syslogSeverityErr
+	}
+	return fmt.Sprintf("<%d>1 %s %s %s - - %s %s", w.facility*8+severity, line.Time.Format(syslogTimeFormat), w.hostname, w.appName, w.data, line.Msg)
+}
+
+// send sends a message to the syslog endpoint, connecting to it first if
+// needed
+func (w *SyslogWriter) send(msg string) error {
+	if w.conn == nil {
+		if err := w.connect(); err != nil {
+			return err
+		}
+	}
+	switch w.network {
+	case "tcp":
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	case "unix":
+		msg = msg + "\n"
+	}
+	if _, err := w.conn.Write([]byte(msg)); err != nil {
+		return errors.Wrapf(err, "error sending log line to syslog endpoint %s", w.address)
+	}
+	return nil
+}
+
+// connect connects to the syslog endpoint. Unix sockets of syslog daemons
+// are usually datagram sockets, stream sockets are tried next.
+func (w *SyslogWriter) connect() error {
+	var (
+		conn net.Conn
+		err  error
+	)
+	if w.network == "unix" {
+		conn, err = net.Dial("unixgram", w.address)
+		if err == nil {
+			w.network = "unixgram"
+		} else {
+			conn, err = net.Dial("unix", w.address)
+		}
+	} else {
+		conn, err = net.Dial(w.network, w.address)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to syslog endpoint %s", w.address)
+	}
+	w.conn = conn
+	return nil
+}
+
+// syslogHeaderField returns value as an RFC5424 header field, made of at
+// most maxLen printable ASCII characters other than spaces, or the nil
+// value "-" when empty
+func syslogHeaderField(value string, maxLen int) string {
+	field := strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, value)
+	if len(field) > maxLen {
+		field = field[:maxLen]
+	}
+	if field == "" {
+		return "-"
+	}
+	return field
+}
+
+// syslogStructuredData returns an RFC5424 structured data element holding
+// the given parameters, sorted by name, or the nil value "-" when there are
+// none
+func syslogStructuredData(params map[string]string) string {
+	if len(params) == 0 {
+		return "-"
+	}
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var data strings.Builder
+	data.WriteString("[" + syslogSDID)
+	for _, name := range names {
+		fmt.Fprintf(&data, " %s=\"%s\"", syslogParamName(name), syslogParamValueEscaper.Replace(params[name]))
+	}
+	data.WriteString("]")
+	return data.String()
+}
+
+// syslogParamName returns name as an RFC5424 parameter name, replacing the
+// characters parameter names cannot contain
+func syslogParamName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, name)
+	if len(name) > syslogParamNameMaxLen {
+		name = name[:syslogParamNameMaxLen]
+	}
+	return name
+}
+
+// syslogParamValueEscaper escapes the characters RFC5424 parameter values
+// cannot contain unescaped
+var syslogParamValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
@@ -0,0 +1,181 @@
+package logs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSyslogAddress(t *testing.T) {
+	for _, tt := range []struct {
+		address string
+		network string
+		addr    string
+	}{
+		{"", "unix", "/dev/log"},
+		{"unix:///run/syslog.sock", "unix", "/run/syslog.sock"},
+		{"udp://127.0.0.1", "udp", "127.0.0.1:514"},
+		{"udp://[::1]:1514", "udp", "[::1]:1514"},
+		{"tcp://logs.example.com:601", "tcp", "logs.example.com:601"},
+	} {
+		network, addr, err := ParseSyslogAddress(tt.address)
+		require.NoError(t, err, tt.address)
+		assert.Equal(t, tt.network, network, tt.address)
+		assert.Equal(t, tt.addr, addr, tt.address)
+	}
+
+	for _, address := range []string{"/dev/log", "unix://", "udp://", "http://logs.example.com"} {
+		_, _, err := ParseSyslogAddress(address)
+		assert.Error(t, err, address)
+	}
+}
+
+func TestParseSyslogFacility(t *testing.T) {
+	code, err := ParseSyslogFacility("")
+	require.NoError(t, err)
+	assert.Equal(t, 3, code)
+	code, err = ParseSyslogFacility("local7")
+	require.NoError(t, err)
+	assert.Equal(t, 23, code)
+	_, err = ParseSyslogFacility("local8")
+	assert.Error(t, err)
+}
+
+func TestSyslogFormat(t *testing.T) {
+	w, err := NewSyslogWriter("udp://127.0.0.1", "local0", "my app", map[string]string{
+		"name":        "web",
+		"id":          "abc",
+		"label.a=b c": `quoted "value" with \ and ]`,
+	})
+	require.NoError(t, err)
+	w.hostname = "host"
+
+	logTime := time.Date(2019, 10, 1, 10, 0, 0, 123456789, time.UTC)
+	msg := w.format(&LogLine{Device: "stdout", Time: logTime, Msg: "hello"})
+	assert.Equal(t, `<134>1 2019-10-01T10:00:00.123456Z host my_app - - [podman@2312 id="abc" label.a_b_c="quoted \"value\" with \\ and \]" name="web"] hello`, msg)
+
+	msg = w.format(&LogLine{Device: "stderr", Time: logTime, Msg: "oops"})
+	assert.True(t, strings.HasPrefix(msg, "<131>1 "), msg)
+
+	w, err = NewSyslogWriter("udp://127.0.0.1", "", "", nil)
+	require.NoError(t, err)
+	w.hostname = "host"
+	msg = w.format(&LogLine{Device: "stdout", Time: logTime, Msg: "hello"})
+	assert.Equal(t, "<30>1 2019-10-01T10:00:00.123456Z host - - - - hello", msg)
+}
+
+func TestSyslogWriterUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	w, err := NewSyslogWriter("udp://"+conn.LocalAddr().String(), "", "app", nil)
+	require.NoError(t, err)
+	defer w.Close()
+	require.NoError(t, w.Write(&LogLine{Device: "stdout", Time: time.Now(), Msg: "over udp"}))
+
+	buf := make([]byte, 1024)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(buf[:n]), " app - - - over udp"), string(buf[:n]))
+}
+
+func TestSyslogWriterTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			received <- err.Error()
+			return
+		}
+		defer conn.Close()
+		data, _ := ioutil.ReadAll(conn)
+		received <- string(data)
+	}()
+
+	w, err := NewSyslogWriter("tcp://"+l.Addr().String(), "", "app", nil)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(&LogLine{Device: "stdout", Time: time.Now(), Msg: "one"}))
+	require.NoError(t, w.Write(&LogLine{Device: "stdout", Time: time.Now(), Msg: "two"}))
+	require.NoError(t, w.Close())
+
+	// Messages are framed with their length
+	r := bufio.NewReader(strings.NewReader(<-received))
+	for _, msg := range []string{"one", "two"} {
+		var length int
+		_, err := fmt.Fscanf(r, "%d ", &length)
+		require.NoError(t, err)
+		frame := make([]byte, length)
+		_, err = io.ReadFull(r, frame)
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(string(frame), " app - - - "+msg), string(frame))
+	}
+}
+
+func TestSyslogWriterUnix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "log.sock")
+
+	conn, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	w, err := NewSyslogWriter("unix://"+path, "", "app", nil)
+	require.NoError(t, err)
+	defer w.Close()
+	require.NoError(t, w.Write(&LogLine{Device: "stdout", Time: time.Now(), Msg: "over unix"}))
+
+	buf := make([]byte, 1024)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(buf[:n]), " app - - - over unix"), string(buf[:n]))
+}
+
+func TestSyslogWriterUnixStream(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "log.sock")
+
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	w, err := NewSyslogWriter("unix://"+path, "", "app", nil)
+	require.NoError(t, err)
+	defer w.Close()
+	for _, msg := range []string{"first", "second"} {
+		require.NoError(t, w.Write(&LogLine{Device: "stdout", Time: time.Now(), Msg: msg}))
+	}
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	reader := bufio.NewReader(conn)
+	// Messages are not framed with their length, but end with a newline
+	for _, msg := range []string{"first", "second"} {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(line, "<"), line)
+		assert.True(t, strings.HasSuffix(line, " app - - - "+msg+"\n"), line)
+	}
+}
@@ -0,0 +1,231 @@
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// tailBlockSize is the size of the blocks read backwards from the end of a
+// log file when tailing it
+const tailBlockSize = 64 * 1024
+
+// reverseLineReader reads the lines of a file backwards, from its end to its
+// start, one block at a time
+type reverseLineReader struct {
+	file *os.File
+	// pos is the offset in the file of the data in buf
+	pos int64
+	// buf holds data read from the file and not returned yet. Only its
+	// first line may be incomplete.
+	buf []byte
+}
+
+// newReverseLineReader returns a reader returning the lines of the first size
+// bytes of file, last line first
+func newReverseLineReader(file *os.File, size int64) *reverseLineReader {
+	return &reverseLineReader{
+		file: file,
+		pos:  size,
+	}
+}
+
+// previous returns the line preceding the lines already returned, without its
+// newline, and io.EOF once the start of the file was reached
+func (r *reverseLineReader) previous() (string, error) {
+	for {
+		if i := bytes.LastIndexByte(r.buf, '\n'); i >= 0 {
+			line := string(r.buf[i+1:])
+			r.buf = r.buf[:i]
+			return line, nil
+		}
+		if r.pos == 0 {
+			if r.buf == nil {
+				return "", io.EOF
+			}
+			line := string(r.buf)
+			r.buf = nil
+			return line, nil
+		}
+		size := int64(tailBlockSize)
+		if size > r.pos {
+			size = r.pos
+		}
+		// Only the incomplete first line is left in buf, which is
+		// copied after the new block
+		block := make([]byte, size+int64(len(r.buf)))
+		if _, err := r.file.ReadAt(block[:size], r.pos-size); err != nil {
+			return "", errors.Wrapf(err, "error reading log file %s", r.file.Name())
+		}
+		copy(block[size:], r.buf)
+		r.pos -= size
+		r.buf = block
+	}
+}
+
+// tailLogSegment returns the last n messages of a log file or of one of its
+// rotated segments selected by the stream, since and until options, oldest
+// first, assembling partial messages to become full messages. If fewer
+// messages were selected, they are preceded by the newest message logged
+// before since, as the messages preceding it are not selected either. It also
+// returns the partial message left at the end of the segment, which is
+// completed by the following segment, and whether the oldest returned message
+// starts at the start of the segment, in which case the partial message left
+// by the previous segment belongs to it.
+func tailLogSegment(path string, n int, options *LogOptions) ([]*LogLine, string, bool, error) {
+	if strings.HasSuffix(path, compressedLogSuffix) {
+		return tailCompressedLogSegment(path, n, options)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, "", false, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, "", false, errors.Wrapf(err, "error reading log file %s", path)
+	}
+
+	var (
+		// messages holds the messages read so far, newest first
+		messages []*LogLine
+		selected int
+		partial  string
+		// skipping is set while reading a message logged after until
+		skipping bool
+	)
+	r := newReverseLineReader(f, info.Size())
+	for {
+		line, err := r.previous()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, "", false, err
+		}
+		if len(line) == 0 {
+			continue
+		}
+		nll, err := NewLogLine(line)
+		if err != nil {
+			return nil, "", false, err
+		}
+		if !nll.FromStream(options.Stream) {
+			continue
+		}
+		if nll.Partial() {
+			// Partial lines precede the full line ending their
+			// message, or end the segment
+			if skipping {
+				continue
+			}
+			if len(messages) == 0 {
+				partial = nll.Msg + partial
+			} else {
+				messages[len(messages)-1].Msg = nll.Msg + messages[len(messages)-1].Msg
+			}
+			continue
+		}
+		// A full line ends the message preceding the ones already
+		// read, so the oldest message was read completely
+		if selected == n || (len(messages) > 0 && !messages[len(messages)-1].Since(options.Since)) {
+			return reverseLogLines(messages), partial, false, nil
+		}
+		skipping = !nll.Until(options.Until)
+		if skipping {
+			continue
+		}
+		messages = append(messages, nll)
+		if nll.Since(options.Since) {
+			selected++
+		}
+	}
+	return reverseLogLines(messages), partial, true, nil
+}
+
+// tailCompressedLogSegment is tailLogSegment for compressed segments, which
+// cannot be read backwards and are read from their start, keeping only the
+// last n messages
+func tailCompressedLogSegment(path string, n int, options *LogOptions) ([]*LogLine, string, bool, error) {
+	f, err := openLogSegment(path)
+	if err != nil {
+		return nil, "", false, err
+	}
+	defer f.Close()
+
+	var (
+		messages    []*LogLine
+		partial     string
+		total       int
+		beforeSince *LogLine
+	)
+	err = readLogLines(f, path, func(nll *LogLine) {
+		if !nll.FromStream(options.Stream) {
+			return
+		}
+		if nll.Partial() {
+			partial = partial + nll.Msg
+			return
+		}
+		nll.Msg = partial + nll.Msg
+		partial = ""
+		if !nll.Since(options.Since) {
+			beforeSince = nll
+			return
+		}
+		if !nll.Until(options.Until) {
+			return
+		}
+		total++
+		if n == 0 {
+			return
+		}
+		if len(messages) == n {
+			messages = messages[1:]
+		}
+		messages = append(messages, nll)
+	})
+	if err != nil {
+		return nil, "", false, err
+	}
+	if beforeSince != nil {
+		if total < n {
+			messages = append([]*LogLine{beforeSince}, messages...)
+		}
+		return messages, partial, false, nil
+	}
+	return messages, partial, total <= n, nil
+}
+
+// readLogLines calls fn with every line of the log read from r, in order
+func readLogLines(r io.Reader, path string, fn func(*LogLine)) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return errors.Wrapf(err, "error reading log file %s", path)
+		}
+		if line = strings.TrimSuffix(line, "\n"); len(line) > 0 {
+			nll, perr := NewLogLine(line)
+			if perr != nil {
+				return perr
+			}
+			fn(nll)
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// reverseLogLines reverses the order of lines in place and returns them
+func reverseLogLines(lines []*LogLine) []*LogLine {
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return lines
+}
@@ -0,0 +1,267 @@
+package logs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logContent returns log lines of the given type for each message
+func logContent(logType string, msgs ...string) string {
+	var content strings.Builder
+	now := time.Now().Format(LogTimeFormat)
+	for _, msg := range msgs {
+		fmt.Fprintf(&content, "%s stdout %s %s\n", now, logType, msg)
+	}
+	return content.String()
+}
+
+// writeLargeLog writes a log file of at least size bytes, made of a sparse
+// region that is never read when tailing, followed by count full lines
+func writeLargeLog(tb testing.TB, path string, size int64, count int) {
+	f, err := os.Create(path)
+	require.NoError(tb, err)
+	defer f.Close()
+	require.NoError(tb, f.Truncate(size))
+	_, err = f.Seek(0, io.SeekEnd)
+	require.NoError(tb, err)
+	w := bufio.NewWriter(f)
+	_, err = w.WriteString("\n")
+	require.NoError(tb, err)
+	now := time.Now().Format(LogTimeFormat)
+	for i := 0; i < count; i++ {
+		_, err := fmt.Fprintf(w, "%s stdout F message %d\n", now, i)
+		require.NoError(tb, err)
+	}
+	require.NoError(tb, w.Flush())
+}
+
+func TestGetTailLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ctr.log")
+
+	// Enough lines to span several blocks
+	var msgs []string
+	for i := 0; i < 2000; i++ {
+		msgs = append(msgs, fmt.Sprintf("line %04d %s", i, strings.Repeat("x", 100)))
+	}
+	require.NoError(t, ioutil.WriteFile(path, []byte(logContent(FullLogType, msgs...)), 0600))
+
+	for _, tail := range []int{1, 3, 1000, 2000, 5000} {
+		logTail, err := getTailLog(path, nil, tail, &LogOptions{})
+		require.NoError(t, err)
+		expected := msgs
+		if tail < len(msgs) {
+			expected = msgs[len(msgs)-tail:]
+		}
+		assert.Equal(t, expected, messages(logTail), "tail %d", tail)
+	}
+}
+
+func TestGetTailLogPartial(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ctr.log")
+
+	// A message longer than a block, split in partial lines
+	long := strings.Repeat("y", tailBlockSize)
+	content := logContent(FullLogType, "first") +
+		logContent(PartialLogType, "a", "b") + logContent(FullLogType, "c") +
+		logContent(PartialLogType, long, long) + logContent(FullLogType, "end") +
+		logContent(FullLogType, "last") +
+		logContent(PartialLogType, "incomplete")
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+
+	logTail, err := getTailLog(path, nil, 1, &LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"last"}, messages(logTail))
+
+	logTail, err = getTailLog(path, nil, 3, &LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"abc", long + long + "end", "last"}, messages(logTail))
+
+	logTail, err = getTailLog(path, nil, 10, &LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "abc", long + long + "end", "last"}, messages(logTail))
+}
+
+func TestGetTailLogPartialAcrossSegments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ctr.log")
+
+	require.NoError(t, ioutil.WriteFile(path+".2", []byte(logContent(FullLogType, "one")+logContent(PartialLogType, "t")), 0600))
+	require.NoError(t, ioutil.WriteFile(path+".1", []byte(logContent(PartialLogType, "w")), 0600))
+	require.NoError(t, ioutil.WriteFile(path, []byte(logContent(FullLogType, "o", "three")), 0600))
+	rotated, err := RotatedLogFiles(path)
+	require.NoError(t, err)
+
+	// The tail is found in the log file in use, but its oldest message
+	// starts in the rotated segments
+	logTail, err := getTailLog(path, rotated, 2, &LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"two", "three"}, messages(logTail))
+
+	logTail, err = getTailLog(path, rotated, 3, &LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two", "three"}, messages(logTail))
+
+	logTail, err = getTailLog(path, rotated, 1, &LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"three"}, messages(logTail))
+
+	// Compressed segments are read from their start
+	require.NoError(t, os.Rename(path+".1", path+".1.tmp"))
+	require.NoError(t, os.Rename(path+".2", path+".1"))
+	require.NoError(t, CompressRotatedLogFile(path))
+	require.NoError(t, os.Rename(path+".1.gz", path+".2.gz"))
+	require.NoError(t, os.Rename(path+".1.tmp", path+".1"))
+	rotated, err = RotatedLogFiles(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{path + ".2.gz", path + ".1"}, rotated)
+
+	logTail, err = getTailLog(path, rotated, 2, &LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"two", "three"}, messages(logTail))
+
+	logTail, err = getTailLog(path, rotated, 3, &LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two", "three"}, messages(logTail))
+}
+
+func TestGetTailLogStream(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ctr.log")
+
+	content := logContent(FullLogType, "out1") +
+		strings.Replace(logContent(PartialLogType, "err"), "stdout", "stderr", 1) +
+		logContent(FullLogType, "out2") +
+		strings.Replace(logContent(FullLogType, "1", "err2"), "stdout", "stderr", -1) +
+		logContent(FullLogType, "out3")
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+
+	logTail, err := getTailLog(path, nil, 2, &LogOptions{Stream: "stderr"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"err1", "err2"}, messages(logTail))
+
+	logTail, err = getTailLog(path, nil, 2, &LogOptions{Stream: "stdout"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"out2", "out3"}, messages(logTail))
+
+	_, logTail, err = GetLogFile(path, &LogOptions{Tail: 10, Stream: "stderr"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"err1", "err2"}, messages(logTail))
+}
+
+func TestGetTailLogSinceUntil(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ctr.log")
+
+	// One message per second, message 2 spans a partial and a full line
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(i int) time.Time {
+		return start.Add(time.Duration(i) * time.Second)
+	}
+	var content strings.Builder
+	for i := 0; i < 8; i++ {
+		if i == 2 {
+			fmt.Fprintf(&content, "%s stdout %s mess\n", at(i).Format(LogTimeFormat), PartialLogType)
+			fmt.Fprintf(&content, "%s stdout %s age 2\n", at(i).Format(LogTimeFormat), FullLogType)
+			continue
+		}
+		fmt.Fprintf(&content, "%s stdout %s message %d\n", at(i).Format(LogTimeFormat), FullLogType, i)
+	}
+	require.NoError(t, ioutil.WriteFile(path, []byte(content.String()), 0600))
+
+	// The tail is taken from the messages logged until the given time
+	logTail, err := getTailLog(path, nil, 2, &LogOptions{Until: at(5)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"message 4", "message 5"}, messages(logTail))
+
+	logTail, err = getTailLog(path, nil, 4, &LogOptions{Until: at(5)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"message 2", "message 3", "message 4", "message 5"}, messages(logTail))
+
+	// Fewer messages than the tail were logged since the given time
+	logTail, err = getTailLog(path, nil, 10, &LogOptions{Since: at(4), Until: at(6)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"message 5", "message 6"}, messages(logTail))
+
+	logTail, err = getTailLog(path, nil, 10, &LogOptions{Until: start.Add(-time.Second)})
+	require.NoError(t, err)
+	assert.Empty(t, logTail)
+
+	// The same messages, with the older half in a compressed segment
+	lines := strings.SplitAfter(content.String(), "\n")
+	require.NoError(t, ioutil.WriteFile(path, []byte(strings.Join(lines[:5], "")), 0600))
+	require.NoError(t, RotateLogFile(path, 2))
+	require.NoError(t, CompressRotatedLogFile(path))
+	require.NoError(t, ioutil.WriteFile(path, []byte(strings.Join(lines[5:], "")), 0600))
+	rotated, err := RotatedLogFiles(path)
+	require.NoError(t, err)
+
+	logTail, err = getTailLog(path, rotated, 4, &LogOptions{Until: at(5)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"message 2", "message 3", "message 4", "message 5"}, messages(logTail))
+
+	logTail, err = getTailLog(path, rotated, 10, &LogOptions{Since: at(1), Until: at(3)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"message 2", "message 3"}, messages(logTail))
+}
+
+func TestGetTailLogLargeFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ctr.log")
+
+	// Tailing a multi-GB log only reads its end
+	writeLargeLog(t, path, 4<<30, 1000)
+	logTail, err := getTailLog(path, nil, 2, &LogOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"message 998", "message 999"}, messages(logTail))
+}
+
+func BenchmarkGetTailLog(b *testing.B) {
+	dir, err := ioutil.TempDir("", "logs")
+	require.NoError(b, err)
+	defer os.RemoveAll(dir)
+
+	for _, size := range []struct {
+		name  string
+		bytes int64
+	}{
+		{"1MB", 1 << 20},
+		{"64MB", 64 << 20},
+		{"4GB", 4 << 30},
+	} {
+		path := filepath.Join(dir, size.name+".log")
+		writeLargeLog(b, path, size.bytes, 100000)
+		for _, tail := range []int{10, 10000} {
+			b.Run(fmt.Sprintf("%s/tail=%d", size.name, tail), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := getTailLog(path, nil, tail, &LogOptions{}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -3,6 +3,7 @@
 package libpod
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
 	"net"
@@ -14,12 +15,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/containernetworking/cni/libcni"
 	cnitypes "github.com/containernetworking/cni/pkg/types/current"
 	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/libpod/pkg/errorhandling"
 	"github.com/containers/libpod/pkg/firewall"
 	"github.com/containers/libpod/pkg/netns"
 	"github.com/containers/libpod/pkg/rootless"
+	"github.com/containers/libpod/pkg/util"
 	"github.com/cri-o/ocicni/pkg/ocicni"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -31,14 +35,19 @@ import (
 func (r *Runtime) getPodNetwork(id, name, nsPath string, networks []string, ports []ocicni.PortMapping, staticIP net.IP) ocicni.PodNetwork {
 	defaultNetwork := r.netPlugin.GetDefaultNetworkName()
 	network := ocicni.PodNetwork{
-		Name:      name,
-		Namespace: name, // TODO is there something else we should put here? We don't know about Kube namespaces
-		ID:        id,
-		NetNS:     nsPath,
-		Networks:  networks,
-		RuntimeConfig: map[string]ocicni.RuntimeConfig{
-			defaultNetwork: {PortMappings: ports},
-		},
+		Name:          name,
+		Namespace:     name, // TODO is there something else we should put here? We don't know about Kube namespaces
+		ID:            id,
+		NetNS:         nsPath,
+		Networks:      networks,
+		RuntimeConfig: map[string]ocicni.RuntimeConfig{},
+	}
+	// Ports are forwarded on every network the container joins
+	if len(networks) == 0 {
+		network.RuntimeConfig[defaultNetwork] = ocicni.RuntimeConfig{PortMappings: ports}
+	}
+	for _, netName := range networks {
+		network.RuntimeConfig[netName] = ocicni.RuntimeConfig{PortMappings: ports}
 	}
 
 	if staticIP != nil {
@@ -62,7 +71,7 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS ns.NetNS) ([]*cnitypes.Re
 		requestedIP = ctr.config.StaticIP
 	}
 
-	podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctrNS.Path(), ctr.config.Networks, ctr.config.PortMappings, requestedIP)
+	podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctrNS.Path(), r.ctrNetworks(ctr), ctr.config.PortMappings, requestedIP)
 
 	results, err := r.netPlugin.SetUpPod(podNetwork)
 	if err != nil {
@@ -421,11 +430,23 @@ func (r *Runtime) teardownNetNS(ctr *Container) error {
 		requestedIP = ctr.config.StaticIP
 	}
 
-	podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctr.state.NetNS.Path(), ctr.config.Networks, ctr.config.PortMappings, requestedIP)
+	if len(ctr.state.NetInterfaces) > 0 {
+		// Networks were connected or disconnected while the namespace
+		// was active, so the interfaces no longer follow the layout
+		// ocicni expects. Remove every network individually instead.
+		ifaces := r.ctrNetInterfaces(ctr)
+		for _, netName := range r.ctrNetworks(ctr) {
+			if err := r.delNetwork(ctr, netName, ifaces[netName]); err != nil {
+				return errors.Wrapf(err, "error tearing down CNI namespace configuration for container %s", ctr.ID())
+			}
+		}
+	} else {
+		podNetwork := r.getPodNetwork(ctr.ID(), ctr.Name(), ctr.state.NetNS.Path(), r.ctrNetworks(ctr), ctr.config.PortMappings, requestedIP)
 
-	// The network may have already been torn down, so don't fail here, just log
-	if err := r.netPlugin.TearDownPod(podNetwork); err != nil {
-		return errors.Wrapf(err, "error tearing down CNI namespace configuration for container %s", ctr.ID())
+		// The network may have already been torn down, so don't fail here, just log
+		if err := r.netPlugin.TearDownPod(podNetwork); err != nil {
+			return errors.Wrapf(err, "error tearing down CNI namespace configuration for container %s", ctr.ID())
+		}
 	}
 
 	// First unmount the namespace
@@ -483,34 +504,348 @@ func getContainerNetIO(ctr *Container) (*netlink.LinkStatistics, error) {
 }
 
 func (c *Container) getContainerNetworkInfo(data *InspectContainerData) *InspectContainerData {
-	if c.state.NetNS != nil && len(c.state.NetworkStatus) > 0 {
-		// Report network settings from the first pod network
-		result := c.state.NetworkStatus[0]
-		// Go through our IP addresses
-		for _, ctrIP := range result.IPs {
-			ipWithMask := ctrIP.Address.String()
-			splitIP := strings.Split(ipWithMask, "/")
-			mask, _ := strconv.Atoi(splitIP[1])
-			if ctrIP.Version == "4" {
-				data.NetworkSettings.IPAddress = splitIP[0]
-				data.NetworkSettings.IPPrefixLen = mask
-				data.NetworkSettings.Gateway = ctrIP.Gateway.String()
-			} else {
-				data.NetworkSettings.GlobalIPv6Address = splitIP[0]
-				data.NetworkSettings.GlobalIPv6PrefixLen = mask
-				data.NetworkSettings.IPv6Gateway = ctrIP.Gateway.String()
-			}
+	var networks []string
+	if c.config.CreateNetNS && !c.config.NetMode.IsSlirp4netns() && c.runtime.netPlugin != nil {
+		networks = c.runtime.ctrNetworks(c)
+		data.NetworkSettings.Networks = make(map[string]*InspectNetwork, len(networks))
+		for _, netName := range networks {
+			data.NetworkSettings.Networks[netName] = &InspectNetwork{NetworkID: netName}
 		}
+	}
 
+	if c.state.NetNS != nil && len(c.state.NetworkStatus) > 0 {
 		// Set network namespace path
 		data.NetworkSettings.SandboxKey = c.state.NetNS.Path()
 
-		// Set MAC address of interface linked with network namespace path
-		for _, i := range result.Interfaces {
-			if i.Sandbox == data.NetworkSettings.SandboxKey {
-				data.NetworkSettings.MacAddress = i.Mac
+		for idx, result := range c.state.NetworkStatus {
+			settings := resultToInspectNetwork(result, data.NetworkSettings.SandboxKey)
+			if idx < len(networks) {
+				settings.NetworkID = networks[idx]
+				data.NetworkSettings.Networks[networks[idx]] = settings
 			}
+			if idx > 0 {
+				continue
+			}
+			// Report network settings from the first pod network
+			data.NetworkSettings.IPAddress = settings.IPAddress
+			data.NetworkSettings.IPPrefixLen = settings.IPPrefixLen
+			data.NetworkSettings.Gateway = settings.Gateway
+			data.NetworkSettings.GlobalIPv6Address = settings.GlobalIPv6Address
+			data.NetworkSettings.GlobalIPv6PrefixLen = settings.GlobalIPv6PrefixLen
+			data.NetworkSettings.IPv6Gateway = settings.IPv6Gateway
+			data.NetworkSettings.MacAddress = settings.MacAddress
 		}
 	}
 	return data
 }
+
+// resultToInspectNetwork converts the CNI result of a network into the
+// settings reported by inspect
+func resultToInspectNetwork(result *cnitypes.Result, sandbox string) *InspectNetwork {
+	settings := new(InspectNetwork)
+	// Go through our IP addresses
+	for _, ctrIP := range result.IPs {
+		ipWithMask := ctrIP.Address.String()
+		splitIP := strings.Split(ipWithMask, "/")
+		mask, _ := strconv.Atoi(splitIP[1])
+		if ctrIP.Version == "4" {
+			settings.IPAddress = splitIP[0]
+			settings.IPPrefixLen = mask
+			settings.Gateway = ctrIP.Gateway.String()
+		} else {
+			settings.GlobalIPv6Address = splitIP[0]
+			settings.GlobalIPv6PrefixLen = mask
+			settings.IPv6Gateway = ctrIP.Gateway.String()
+		}
+	}
+
+	// Set MAC address of interface linked with network namespace path
+	for _, i := range result.Interfaces {
+		if i.Sandbox == sandbox {
+			settings.MacAddress = i.Mac
+		}
+	}
+	return settings
+}
+
+// ctrNetworks returns the CNI networks the container joins. Networks connected
+// or disconnected after creation take precedence over the configured ones, and
+// containers that did not request any network join the default network.
+func (r *Runtime) ctrNetworks(ctr *Container) []string {
+	if len(ctr.state.Networks) > 0 {
+		return ctr.state.Networks
+	}
+	if len(ctr.config.Networks) > 0 {
+		return ctr.config.Networks
+	}
+	return []string{r.netPlugin.GetDefaultNetworkName()}
+}
+
+// ctrNetInterfaces returns the interface used for each of the container's
+// networks in its network namespace
+func (r *Runtime) ctrNetInterfaces(ctr *Container) map[string]string {
+	ifaces := make(map[string]string)
+	if len(ctr.state.NetInterfaces) > 0 {
+		for netName, ifName := range ctr.state.NetInterfaces {
+			ifaces[netName] = ifName
+		}
+		return ifaces
+	}
+	// ocicni names the interfaces after the position of the network
+	for i, netName := range r.ctrNetworks(ctr) {
+		ifaces[netName] = fmt.Sprintf("eth%d", i)
+	}
+	return ifaces
+}
+
+// cniRuntimeConf builds the CNI runtime configuration for attaching the
+// container to a single network, matching the one ocicni uses
+func (r *Runtime) cniRuntimeConf(ctr *Container, netName, ifName string) *libcni.RuntimeConf {
+	rt := &libcni.RuntimeConf{
+		ContainerID: ctr.ID(),
+		NetNS:       ctr.state.NetNS.Path(),
+		IfName:      ifName,
+		Args: [][2]string{
+			{"IgnoreUnknown", "1"},
+			{"K8S_POD_NAMESPACE", ctr.Name()},
+			{"K8S_POD_NAME", ctr.Name()},
+			{"K8S_POD_INFRA_CONTAINER_ID", ctr.ID()},
+		},
+		CapabilityArgs: map[string]interface{}{},
+	}
+	// Ports are forwarded on every network the container joins
+	if len(ctr.config.PortMappings) > 0 {
+		rt.CapabilityArgs["portMappings"] = ctr.config.PortMappings
+	}
+	return rt
+}
+
+// loadNetworkConfig loads the CNI configuration of the given network
+func (r *Runtime) loadNetworkConfig(netName string) (*libcni.NetworkConfigList, error) {
+	netConf, err := libcni.LoadConfList(r.config.CNIConfigDir, netName)
+	if err != nil {
+		if _, ok := err.(libcni.NotFoundError); ok {
+			return nil, errors.Wrapf(define.ErrInvalidArg, "no CNI network named %s found in %s", netName, r.config.CNIConfigDir)
+		}
+		return nil, errors.Wrapf(err, "error loading CNI network %s", netName)
+	}
+	return netConf, nil
+}
+
+// addNetwork attaches the active network namespace of a container to a
+// network, using the first free ethN interface name
+func (r *Runtime) addNetwork(ctr *Container, netName string) error {
+	netConf, err := r.loadNetworkConfig(netName)
+	if err != nil {
+		return err
+	}
+
+	ifaces := r.ctrNetInterfaces(ctr)
+	var ifName string
+	for i := 0; ; i++ {
+		ifName = fmt.Sprintf("eth%d", i)
+		inUse := false
+		for _, used := range ifaces {
+			if used == ifName {
+				inUse = true
+				break
+			}
+		}
+		if !inUse {
+			break
+		}
+	}
+
+	rt := r.cniRuntimeConf(ctr, netName, ifName)
+	cniConfig := libcni.NewCNIConfig(r.config.CNIPluginDir, nil)
+	res, err := cniConfig.AddNetworkList(context.Background(), netConf, rt)
+	if err != nil {
+		return errors.Wrapf(err, "error connecting container %s to network %s", ctr.ID(), netName)
+	}
+	defer func() {
+		if err != nil {
+			if err2 := cniConfig.DelNetworkList(context.Background(), netConf, rt); err2 != nil {
+				logrus.Errorf("Error disconnecting container %s from network %s: %v", ctr.ID(), netName, err2)
+			}
+		}
+	}()
+	logrus.Debugf("CNI result for network %s: %v", netName, res.String())
+
+	result, err := cnitypes.GetResult(res)
+	if err != nil {
+		return errors.Wrapf(err, "error parsing CNI plugin result %q", res.String())
+	}
+	firewallConf := &firewall.FirewallNetConf{
+		PrevResult: result,
+	}
+	if err = r.firewallBackend.Add(firewallConf); err != nil {
+		return errors.Wrapf(err, "error adding firewall rules for container %s", ctr.ID())
+	}
+
+	ifaces[netName] = ifName
+	ctr.state.NetInterfaces = ifaces
+	ctr.state.NetworkStatus = append(ctr.state.NetworkStatus, result)
+	return nil
+}
+
+// delNetwork detaches the network namespace of a container from a network
+func (r *Runtime) delNetwork(ctr *Container, netName, ifName string) error {
+	netConf, err := r.loadNetworkConfig(netName)
+	if err != nil {
+		return err
+	}
+	rt := r.cniRuntimeConf(ctr, netName, ifName)
+	cniConfig := libcni.NewCNIConfig(r.config.CNIPluginDir, nil)
+	if err := cniConfig.DelNetworkList(context.Background(), netConf, rt); err != nil {
+		return errors.Wrapf(err, "error disconnecting container %s from network %s", ctr.ID(), netName)
+	}
+	return nil
+}
+
+// removeNetwork detaches the active network namespace of a container from a
+// network and drops the network from the container's network state
+func (r *Runtime) removeNetwork(ctr *Container, netName string) error {
+	networks := r.ctrNetworks(ctr)
+	idx := -1
+	for i, n := range networks {
+		if n == netName {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return errors.Wrapf(define.ErrInvalidArg, "container %s is not connected to network %s", ctr.ID(), netName)
+	}
+
+	if idx < len(ctr.state.NetworkStatus) {
+		firewallConf := &firewall.FirewallNetConf{
+			PrevResult: ctr.state.NetworkStatus[idx],
+		}
+		if err := r.firewallBackend.Del(firewallConf); err != nil {
+			return errors.Wrapf(err, "error removing firewall rules for container %s", ctr.ID())
+		}
+	}
+
+	ifaces := r.ctrNetInterfaces(ctr)
+	if err := r.delNetwork(ctr, netName, ifaces[netName]); err != nil {
+		return err
+	}
+
+	delete(ifaces, netName)
+	ctr.state.NetInterfaces = ifaces
+	if idx < len(ctr.state.NetworkStatus) {
+		ctr.state.NetworkStatus = append(ctr.state.NetworkStatus[:idx], ctr.state.NetworkStatus[idx+1:]...)
+	}
+	return nil
+}
+
+// Networks returns the CNI networks the container joins, including networks
+// connected or disconnected after its creation.
+func (c *Container) Networks() ([]string, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.runtime.ctrNetworks(c), nil
+}
+
+// canChangeNetworks verifies that the CNI networks of a container are
+// managed by libpod and can be changed after creation
+func (c *Container) canChangeNetworks() error {
+	if rootless.IsRootless() {
+		return errors.Wrapf(define.ErrInvalidArg, "CNI networks cannot be changed in rootless mode")
+	}
+	if !c.config.CreateNetNS || c.config.NetMode.IsSlirp4netns() {
+		return errors.Wrapf(define.ErrInvalidArg, "container %s network namespace is not managed by libpod with CNI", c.ID())
+	}
+	if c.config.StaticIP != nil {
+		return errors.Wrapf(define.ErrInvalidArg, "container %s has a static IP and can only join the default network", c.ID())
+	}
+	return nil
+}
+
+// NetworkConnect connects the container to a CNI network. If the network
+// namespace of the container is active, it is attached to the network
+// immediately. The network is recorded in the container's state, so it is
+// joined again on every subsequent start.
+func (c *Container) NetworkConnect(netName string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.canChangeNetworks(); err != nil {
+		return err
+	}
+	if _, err := c.runtime.loadNetworkConfig(netName); err != nil {
+		return err
+	}
+
+	oldNetworks := c.runtime.ctrNetworks(c)
+	if util.StringInSlice(netName, oldNetworks) {
+		return errors.Wrapf(define.ErrInvalidArg, "container %s is already connected to network %s", c.ID(), netName)
+	}
+	newNetworks := make([]string, 0, len(oldNetworks)+1)
+	newNetworks = append(newNetworks, oldNetworks...)
+	newNetworks = append(newNetworks, netName)
+
+	if c.state.NetNS != nil {
+		if err := c.runtime.addNetwork(c, netName); err != nil {
+			return err
+		}
+	}
+
+	c.state.Networks = newNetworks
+	return c.save()
+}
+
+// NetworkDisconnect disconnects the container from a CNI network. If the
+// network namespace of the container is active, it is detached from the
+// network immediately. A container must remain connected to at least one
+// network.
+func (c *Container) NetworkDisconnect(netName string) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.canChangeNetworks(); err != nil {
+		return err
+	}
+
+	oldNetworks := c.runtime.ctrNetworks(c)
+	if !util.StringInSlice(netName, oldNetworks) {
+		return errors.Wrapf(define.ErrInvalidArg, "container %s is not connected to network %s", c.ID(), netName)
+	}
+	if len(oldNetworks) == 1 {
+		return errors.Wrapf(define.ErrInvalidArg, "container %s cannot be disconnected from its only network %s", c.ID(), netName)
+	}
+	newNetworks := make([]string, 0, len(oldNetworks)-1)
+	for _, n := range oldNetworks {
+		if n != netName {
+			newNetworks = append(newNetworks, n)
+		}
+	}
+
+	if c.state.NetNS != nil {
+		if err := c.runtime.removeNetwork(c, netName); err != nil {
+			return err
+		}
+	}
+
+	c.state.Networks = newNetworks
+	return c.save()
+}
@@ -23,3 +23,15 @@ func (r *Runtime) createNetNS(ctr *Container) (err error) {
 func (c *Container) getContainerNetworkInfo(data *InspectContainerData) *InspectContainerData {
 	return nil
 }
+
+func (c *Container) Networks() ([]string, error) {
+	return nil, define.ErrNotImplemented
+}
+
+func (c *Container) NetworkConnect(netName string) error {
+	return define.ErrNotImplemented
+}
+
+func (c *Container) NetworkDisconnect(netName string) error {
+	return define.ErrNotImplemented
+}
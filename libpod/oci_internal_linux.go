@@ -282,6 +282,9 @@ func (r *OCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, pidPath
 		// to get here, either a user would specify `--log-driver ""`, or this came from another place in libpod
 		// since the former case is obscure, and the latter case isn't an error, let's silently fallthrough
 		fallthrough
+	case SyslogLogging:
+		// podman forwards the lines of the log file to the syslog endpoint
+		fallthrough
 	case KubernetesLogging:
 		logDriver = fmt.Sprintf("%s:%s", KubernetesLogging, logPath)
 	}
@@ -289,8 +292,19 @@ func (r *OCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, pidPath
 	args = append(args, "-l", logDriver)
 	args = append(args, "--exit-dir", exitDir)
 	args = append(args, "--socket-dir-path", r.socketsDir)
-	if r.logSizeMax >= 0 {
-		args = append(args, "--log-size-max", fmt.Sprintf("%v", r.logSizeMax))
+	logSizeMax := r.logSizeMax
+	if logPath == ctr.LogPath() {
+		if ctr.config.LogSize > 0 {
+			logSizeMax = ctr.config.LogSize
+		}
+		// Rotated logs are not truncated by conmon, podman rotates them
+		// once they reach their maximum size
+		if ctr.rotatesLog() {
+			logSizeMax = -1
+		}
+	}
+	if logSizeMax >= 0 {
+		args = append(args, "--log-size-max", fmt.Sprintf("%v", logSizeMax))
 	}
 
 	logLevel := logrus.GetLevel()
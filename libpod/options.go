@@ -10,18 +10,23 @@ import (
 	"github.com/containers/image/manifest"
 	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/libpod/libpod/events"
+	"github.com/containers/libpod/libpod/logs"
 	"github.com/containers/libpod/pkg/namespaces"
 	"github.com/containers/libpod/pkg/rootless"
 	"github.com/containers/libpod/pkg/util"
 	"github.com/containers/storage"
 	"github.com/containers/storage/pkg/idtools"
 	"github.com/cri-o/ocicni/pkg/ocicni"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 )
 
 var (
-	nameRegex  = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_.-]*$")
-	regexError = errors.Wrapf(define.ErrInvalidArg, "names must match [a-zA-Z0-9][a-zA-Z0-9_.-]*")
+	// NameRegex is the regular expression that container, pod, volume
+	// and network names must match
+	NameRegex = regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_.-]*$")
+	// RegexError is returned when a name does not match NameRegex
+	RegexError = errors.Wrapf(define.ErrInvalidArg, "names must match [a-zA-Z0-9][a-zA-Z0-9_.-]*")
 )
 
 // Runtime Creation Options
@@ -639,8 +644,8 @@ func WithName(name string) CtrCreateOption {
 		}
 
 		// Check the name against a regex
-		if !nameRegex.MatchString(name) {
-			return regexError
+		if !NameRegex.MatchString(name) {
+			return RegexError
 		}
 
 		ctr.config.Name = name
@@ -1019,7 +1024,7 @@ func WithLogDriver(driver string) CtrCreateOption {
 		switch driver {
 		case "":
 			return errors.Wrapf(define.ErrInvalidArg, "log driver must be set")
-		case JournaldLogging, KubernetesLogging, JSONLogging:
+		case JournaldLogging, KubernetesLogging, JSONLogging, SyslogLogging:
 			break
 		default:
 			return errors.Wrapf(define.ErrInvalidArg, "invalid log driver")
@@ -1047,6 +1052,77 @@ func WithLogPath(path string) CtrCreateOption {
 	}
 }
 
+// WithLogSize sets the maximum size of the container's log file in bytes.
+func WithLogSize(size int64) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if size <= 0 {
+			return errors.Wrapf(define.ErrInvalidArg, "log size must be greater than 0")
+		}
+
+		ctr.config.LogSize = size
+
+		return nil
+	}
+}
+
+// WithLogFiles sets the maximum number of log files kept when rotating the
+// container's log, including the one in use. Rotation requires a log size to
+// be set.
+func WithLogFiles(files uint) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if files == 0 {
+			return errors.Wrapf(define.ErrInvalidArg, "number of log files must be greater than 0")
+		}
+
+		ctr.config.LogFiles = files
+
+		return nil
+	}
+}
+
+// WithLogCompress compresses rotated log files of the container with gzip.
+func WithLogCompress() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.LogCompress = true
+
+		return nil
+	}
+}
+
+// WithLogSyslog sets the syslog endpoint the log of the container is
+// forwarded to with the syslog log driver, the syslog facility and the tag of
+// the forwarded log lines. Empty values use the defaults: the local syslog
+// daemon, the daemon facility and the name of the container.
+func WithLogSyslog(address, facility, tag string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if _, _, err := logs.ParseSyslogAddress(address); err != nil {
+			return errors.Wrap(define.ErrInvalidArg, err.Error())
+		}
+		if _, err := logs.ParseSyslogFacility(facility); err != nil {
+			return errors.Wrap(define.ErrInvalidArg, err.Error())
+		}
+
+		ctr.config.LogSyslogAddress = address
+		ctr.config.LogSyslogFacility = facility
+		ctr.config.LogSyslogTag = tag
+
+		return nil
+	}
+}
+
 // WithCgroupParent sets the Cgroup Parent of the new container.
 func WithCgroupParent(parent string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1142,6 +1218,18 @@ func WithConmonPidFile(path string) CtrCreateOption {
 	}
 }
 
+// WithCreateCommand adds the full command plus arguments of the current
+// process to the container config.
+func WithCreateCommand(cmd []string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.CreateCommand = cmd
+		return nil
+	}
+}
+
 // WithGroups sets additional groups for the container, which are defined by
 // the user.
 func WithGroups(groups []string) CtrCreateOption {
@@ -1285,8 +1373,8 @@ func WithUseImageHosts() CtrCreateOption {
 }
 
 // WithRestartPolicy sets the container's restart policy. Valid values are
-// "no", "on-failure", and "always". The empty string is allowed, and will be
-// equivalent to "no".
+// "no", "on-failure", "always", and "unless-stopped". The empty string is
+// allowed, and will be equivalent to "no".
 func WithRestartPolicy(policy string) CtrCreateOption {
 	return func(ctr *Container) error {
 		if ctr.valid {
@@ -1294,7 +1382,7 @@ func WithRestartPolicy(policy string) CtrCreateOption {
 		}
 
 		switch policy {
-		case RestartPolicyNone, RestartPolicyNo, RestartPolicyOnFailure, RestartPolicyAlways:
+		case RestartPolicyNone, RestartPolicyNo, RestartPolicyOnFailure, RestartPolicyAlways, RestartPolicyUnlessStopped:
 			ctr.config.RestartPolicy = policy
 		default:
 			return errors.Wrapf(define.ErrInvalidArg, "%q is not a valid restart policy", policy)
@@ -1333,6 +1421,22 @@ func withIsInfra() CtrCreateOption {
 	}
 }
 
+// WithInitCtr makes the container an init container of its pod. Init
+// containers are run one after another, in the order they were created, when
+// the pod is started and must exit successfully before the other containers of
+// the pod are started.
+func WithInitCtr() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.IsInitCtr = true
+
+		return nil
+	}
+}
+
 // WithNamedVolumes adds the given named volumes to the container.
 func WithNamedVolumes(volumes []*ContainerNamedVolume) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1372,8 +1476,8 @@ func WithVolumeName(name string) VolumeCreateOption {
 		}
 
 		// Check the name against a regex
-		if !nameRegex.MatchString(name) {
-			return regexError
+		if !NameRegex.MatchString(name) {
+			return RegexError
 		}
 		volume.config.Name = name
 
@@ -1478,8 +1582,8 @@ func WithPodName(name string) PodCreateOption {
 		}
 
 		// Check the name against a regex
-		if !nameRegex.MatchString(name) {
-			return regexError
+		if !NameRegex.MatchString(name) {
+			return RegexError
 		}
 
 		pod.config.Name = name
@@ -1533,6 +1637,93 @@ func WithPodCgroups() PodCreateOption {
 	}
 }
 
+// WithPodCPUs limits the CPU time available to all containers in the pod to
+// the given number of CPUs.
+// The limit is set on the pod's cgroup, so WithPodCgroups is required.
+func WithPodCPUs(cpus float64) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+		if cpus <= 0 {
+			return errors.Wrapf(define.ErrInvalidArg, "pod CPU limit must be greater than 0")
+		}
+
+		period := uint64(podCPUPeriod)
+		quota := int64(cpus * podCPUPeriod)
+		resources := pod.podResources()
+		if resources.CPU == nil {
+			resources.CPU = new(spec.LinuxCPU)
+		}
+		resources.CPU.Period = &period
+		resources.CPU.Quota = &quota
+
+		return nil
+	}
+}
+
+// WithPodMemory limits the memory available to all containers in the pod to
+// the given number of bytes.
+// The limit is set on the pod's cgroup, so WithPodCgroups is required.
+func WithPodMemory(limit int64) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+		if limit <= 0 {
+			return errors.Wrapf(define.ErrInvalidArg, "pod memory limit must be greater than 0")
+		}
+
+		resources := pod.podResources()
+		if resources.Memory == nil {
+			resources.Memory = new(spec.LinuxMemory)
+		}
+		resources.Memory.Limit = &limit
+
+		return nil
+	}
+}
+
+// WithPodPidsLimit limits the number of processes all containers in the pod
+// may run.
+// The limit is set on the pod's cgroup, so WithPodCgroups is required.
+func WithPodPidsLimit(limit int64) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+		if limit <= 0 {
+			return errors.Wrapf(define.ErrInvalidArg, "pod pids limit must be greater than 0")
+		}
+
+		pod.podResources().Pids = &spec.LinuxPids{Limit: limit}
+
+		return nil
+	}
+}
+
+// WithPodBlkioWeight sets the block IO weight of all containers in the pod,
+// relative to other cgroups. It must be between 10 and 1000.
+// The weight is set on the pod's cgroup, so WithPodCgroups is required.
+func WithPodBlkioWeight(weight uint16) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+		if weight < 10 || weight > 1000 {
+			return errors.Wrapf(define.ErrInvalidArg, "pod blkio weight must be between 10 and 1000")
+		}
+
+		resources := pod.podResources()
+		if resources.BlockIO == nil {
+			resources.BlockIO = new(spec.LinuxBlockIO)
+		}
+		resources.BlockIO.Weight = &weight
+
+		return nil
+	}
+}
+
 // WithPodNamespace sets the namespace for the created pod.
 // Namespaces are used to create separate views of Podman's state - runtimes can
 // join a specific namespace and see only containers and pods in that namespace.
@@ -1674,6 +1865,127 @@ func WithInfraContainerPorts(bindings []ocicni.PortMapping) PodCreateOption {
 	}
 }
 
+// WithPodVolumes adds named volumes to the pod. They are mounted into every
+// container joining the pod, unless the container mounts something else at the
+// same destination. Volumes which do not exist are created with the pod.
+func WithPodVolumes(volumes []*ContainerNamedVolume) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		destinations := make(map[string]bool)
+		for _, vol := range volumes {
+			if _, ok := destinations[vol.Dest]; ok {
+				return errors.Wrapf(define.ErrInvalidArg, "two volumes found with destination %s", vol.Dest)
+			}
+			destinations[vol.Dest] = true
+
+			pod.config.Volumes = append(pod.config.Volumes, &ContainerNamedVolume{
+				Name:    vol.Name,
+				Dest:    vol.Dest,
+				Options: util.ProcessOptions(vol.Options),
+			})
+		}
+
+		return nil
+	}
+}
+
+// WithPodHostname sets the hostname of the pod's infra container. Containers
+// joining the pod's UTS namespace will share it.
+func WithPodHostname(hostname string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+
+		pod.config.Hostname = hostname
+
+		return nil
+	}
+}
+
+// WithPodDNS sets the name servers of the pod's infra container.
+// Containers joining the pod's network namespace will share its
+// /etc/resolv.conf.
+func WithPodDNS(dnsServers []string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+		if pod.config.InfraContainer.UseImageResolvConf {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot add DNS servers if pod will not create /etc/resolv.conf")
+		}
+		for _, i := range dnsServers {
+			if net.ParseIP(i) == nil {
+				return errors.Wrapf(define.ErrInvalidArg, "invalid IP address %s", i)
+			}
+		}
+		pod.config.InfraContainer.DNSServer = dnsServers
+		return nil
+	}
+}
+
+// WithPodDNSSearch sets the search domains of the pod's infra container.
+func WithPodDNSSearch(searchDomains []string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+		if pod.config.InfraContainer.UseImageResolvConf {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot add DNS search domains if pod will not create /etc/resolv.conf")
+		}
+		pod.config.InfraContainer.DNSSearch = searchDomains
+		return nil
+	}
+}
+
+// WithPodUseImageResolvConf tells the pod's infra container not to create
+// /etc/resolv.conf.
+func WithPodUseImageResolvConf() PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+		if len(pod.config.InfraContainer.DNSServer) != 0 || len(pod.config.InfraContainer.DNSSearch) != 0 {
+			return errors.Wrapf(define.ErrInvalidArg, "not creating /etc/resolv.conf conflicts with setting DNS servers or search domains")
+		}
+		pod.config.InfraContainer.UseImageResolvConf = true
+		return nil
+	}
+}
+
+// WithPodHosts adds host:IP entries to the hosts file of the pod's infra
+// container.
+func WithPodHosts(hosts []string) PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+		if pod.config.InfraContainer.UseImageHosts {
+			return errors.Wrapf(define.ErrInvalidArg, "cannot add hosts if pod will not create /etc/hosts")
+		}
+		pod.config.InfraContainer.HostAdd = hosts
+		return nil
+	}
+}
+
+// WithPodUseImageHosts tells the pod's infra container not to create
+// /etc/hosts.
+func WithPodUseImageHosts() PodCreateOption {
+	return func(pod *Pod) error {
+		if pod.valid {
+			return define.ErrPodFinalized
+		}
+		if len(pod.config.InfraContainer.HostAdd) != 0 {
+			return errors.Wrapf(define.ErrInvalidArg, "not creating /etc/hosts conflicts with adding to the hosts file")
+		}
+		pod.config.InfraContainer.UseImageHosts = true
+		return nil
+	}
+}
+
 // WithHealthCheck adds the healthcheck to the container config
 func WithHealthCheck(healthCheck *manifest.Schema2HealthConfig) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1684,3 +1996,22 @@ func WithHealthCheck(healthCheck *manifest.Schema2HealthConfig) CtrCreateOption
 		return nil
 	}
 }
+
+// WithHealthCheckOnFailureAction sets the action taken when the container
+// becomes unhealthy. Valid values are "none", "kill", "restart" and "stop".
+func WithHealthCheckOnFailureAction(action string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		switch action {
+		case HealthCheckOnFailureActionNone, HealthCheckOnFailureActionKill, HealthCheckOnFailureActionRestart, HealthCheckOnFailureActionStop:
+			ctr.config.HealthCheckOnFailureAction = action
+		default:
+			return errors.Wrapf(define.ErrInvalidArg, "%q is not a valid health check on failure action", action)
+		}
+
+		return nil
+	}
+}
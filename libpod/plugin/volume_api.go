@@ -0,0 +1,429 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Endpoints of the Docker volume plugin API.
+// These are well-established paths that should not change unless the plugin
+// API version changes.
+const (
+	activatePath     = "/Plugin.Activate"
+	createPath       = "/VolumeDriver.Create"
+	getPath          = "/VolumeDriver.Get"
+	listPath         = "/VolumeDriver.List"
+	removePath       = "/VolumeDriver.Remove"
+	hostVirtualPath  = "/VolumeDriver.Path"
+	mountPath        = "/VolumeDriver.Mount"
+	unmountPath      = "/VolumeDriver.Unmount"
+	capabilitiesPath = "/VolumeDriver.Capabilities"
+)
+
+const (
+	// volumePluginType is the type a plugin must advertise in its
+	// activation response to be usable as a volume driver.
+	volumePluginType = "VolumeDriver"
+	// pluginContentType is the content type of requests and responses
+	// exchanged with a plugin.
+	pluginContentType = "application/vnd.docker.plugins.v1.2+json"
+	// defaultTimeout is the time we wait for a plugin to respond to a
+	// single request.
+	defaultTimeout = 5 * time.Second
+)
+
+var (
+	// ErrNotPlugin indicates that a plugin was found, but does not
+	// implement the volume driver API.
+	ErrNotPlugin = errors.New("plugin does not implement the volume driver API")
+	// ErrNoSuchPlugin indicates that no plugin with the given name could
+	// be found in any of the plugin directories.
+	ErrNoSuchPlugin = errors.New("no such volume plugin")
+
+	pluginsLock sync.Mutex
+	plugins     = make(map[string]*VolumePlugin)
+)
+
+// VolumePlugin is a single volume plugin.
+type VolumePlugin struct {
+	// Name is the name of the volume plugin. This will be used to refer to
+	// it.
+	Name string
+	// SocketPath is the address of the plugin. For unix sockets this is
+	// the path to the socket, for TCP plugins it is host:port.
+	SocketPath string
+	// Client is the HTTP client we use to connect to the plugin.
+	Client *http.Client
+	// network is the network the plugin listens on, either "unix" or
+	// "tcp".
+	network string
+}
+
+// Volume is a volume as reported by a volume plugin.
+type Volume struct {
+	Name       string                 `json:"Name"`
+	Mountpoint string                 `json:"Mountpoint,omitempty"`
+	CreatedAt  string                 `json:"CreatedAt,omitempty"`
+	Status     map[string]interface{} `json:"Status,omitempty"`
+}
+
+// Capabilities are the capabilities of a volume plugin.
+type Capabilities struct {
+	// Scope is the scope of the volumes the plugin manages, either
+	// "local" or "global".
+	Scope string `json:"Scope"`
+}
+
+// activateResponse is the response to a /Plugin.Activate request.
+type activateResponse struct {
+	Implements []string `json:"Implements"`
+}
+
+// createRequest is the body of a /VolumeDriver.Create request.
+type createRequest struct {
+	Name    string            `json:"Name"`
+	Options map[string]string `json:"Opts,omitempty"`
+}
+
+// nameRequest is the body of requests that only carry a volume name.
+type nameRequest struct {
+	Name string `json:"Name"`
+}
+
+// mountRequest is the body of /VolumeDriver.Mount and
+// /VolumeDriver.Unmount requests.
+type mountRequest struct {
+	Name string `json:"Name"`
+	ID   string `json:"ID"`
+}
+
+// response holds every field a volume plugin may return.
+type response struct {
+	Err          string       `json:"Err,omitempty"`
+	Mountpoint   string       `json:"Mountpoint,omitempty"`
+	Volume       *Volume      `json:"Volume,omitempty"`
+	Volumes      []*Volume    `json:"Volumes,omitempty"`
+	Capabilities Capabilities `json:"Capabilities,omitempty"`
+}
+
+// GetVolumePlugin gets a single volume plugin by name, searching the given
+// directories for its socket or spec file. Plugins are activated on first use
+// and cached afterwards.
+func GetVolumePlugin(name string, pluginDirs []string) (*VolumePlugin, error) {
+	pluginsLock.Lock()
+	defer pluginsLock.Unlock()
+
+	if plugin, ok := plugins[name]; ok {
+		return plugin, nil
+	}
+
+	network, address, err := findPlugin(name, pluginDirs)
+	if err != nil {
+		return nil, err
+	}
+
+	plugin := newVolumePlugin(name, network, address)
+	if err := plugin.activate(); err != nil {
+		return nil, err
+	}
+
+	plugins[name] = plugin
+	return plugin, nil
+}
+
+// GetAllVolumePlugins gets every volume plugin found in the given directories.
+// Plugins which cannot be activated or do not implement the volume driver API
+// are skipped.
+func GetAllVolumePlugins(pluginDirs []string) []*VolumePlugin {
+	var (
+		names []string
+		seen  = make(map[string]bool)
+	)
+	for _, dir := range pluginDirs {
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				logrus.Debugf("Error reading volume plugin directory %s: %v", dir, err)
+			}
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			switch {
+			case entry.IsDir():
+			case strings.HasSuffix(name, ".sock"):
+				name = strings.TrimSuffix(name, ".sock")
+			case strings.HasSuffix(name, ".spec"):
+				name = strings.TrimSuffix(name, ".spec")
+			default:
+				continue
+			}
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	var volPlugins []*VolumePlugin
+	for _, name := range names {
+		volPlugin, err := GetVolumePlugin(name, pluginDirs)
+		if err != nil {
+			if cause := errors.Cause(err); cause == ErrNoSuchPlugin || cause == ErrNotPlugin {
+				logrus.Debugf("Skipping plugin %s: %v", name, err)
+			} else {
+				logrus.Warnf("Skipping volume plugin %s: %v", name, err)
+			}
+			continue
+		}
+		volPlugins = append(volPlugins, volPlugin)
+	}
+	return volPlugins
+}
+
+// newVolumePlugin creates a client for the plugin listening on the given
+// address.
+func newVolumePlugin(name, network, address string) *VolumePlugin {
+	plugin := new(VolumePlugin)
+	plugin.Name = name
+	plugin.SocketPath = address
+	plugin.network = network
+	plugin.Client = &http.Client{
+		Timeout: defaultTimeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, address)
+			},
+			DisableCompression: true,
+		},
+	}
+	return plugin
+}
+
+// findPlugin searches the plugin directories for the given plugin. We
+// accept a socket named <name>.sock, either directly in a plugin directory
+// or in a subdirectory named after the plugin, and a <name>.spec file
+// holding the address of the plugin.
+func findPlugin(name string, pluginDirs []string) (string, string, error) {
+	if name == "" || strings.ContainsAny(name, "/\\") {
+		return "", "", errors.Wrapf(ErrNoSuchPlugin, "invalid volume plugin name %q", name)
+	}
+
+	for _, dir := range pluginDirs {
+		for _, socket := range []string{
+			filepath.Join(dir, name+".sock"),
+			filepath.Join(dir, name, name+".sock"),
+		} {
+			info, err := os.Stat(socket)
+			if err != nil {
+				continue
+			}
+			if info.Mode()&os.ModeSocket == 0 {
+				logrus.Debugf("Ignoring %s for volume plugin %s: not a socket", socket, name)
+				continue
+			}
+			return "unix", socket, nil
+		}
+
+		spec := filepath.Join(dir, name+".spec")
+		contents, err := ioutil.ReadFile(spec)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", "", errors.Wrapf(err, "error reading volume plugin spec file %s", spec)
+		}
+		return parseSpecAddress(spec, strings.TrimSpace(string(contents)))
+	}
+
+	return "", "", errors.Wrapf(ErrNoSuchPlugin, "volume plugin %s not found in %s", name, strings.Join(pluginDirs, ", "))
+}
+
+// parseSpecAddress parses the address held in a plugin spec file.
+func parseSpecAddress(spec, address string) (string, string, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "error parsing address %q in volume plugin spec file %s", address, spec)
+	}
+	switch u.Scheme {
+	case "unix":
+		return "unix", u.Path, nil
+	case "tcp":
+		return "tcp", u.Host, nil
+	default:
+		return "", "", errors.Errorf("unsupported address %q in volume plugin spec file %s: must be unix:// or tcp://", address, spec)
+	}
+}
+
+// activate performs the handshake with the plugin and verifies it
+// implements the volume driver API.
+func (p *VolumePlugin) activate() error {
+	resp := new(activateResponse)
+	if err := p.call(activatePath, nil, resp); err != nil {
+		return errors.Wrapf(err, "error activating volume plugin %s", p.Name)
+	}
+	for _, implements := range resp.Implements {
+		if implements == volumePluginType {
+			return nil
+		}
+	}
+	return errors.Wrapf(ErrNotPlugin, "volume plugin %s implements %s", p.Name, strings.Join(resp.Implements, ", "))
+}
+
+// call sends a request to the plugin and decodes its response into out.
+func (p *VolumePlugin) call(endpoint string, in, out interface{}) error {
+	body := []byte("{}")
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return errors.Wrapf(err, "error marshalling request to %s", endpoint)
+		}
+	}
+
+	// The host is ignored by our dialer, but must be present for the
+	// request to be valid.
+	req, err := http.NewRequest(http.MethodPost, "http://plugin"+endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "error creating request to %s", endpoint)
+	}
+	req.Header.Set("Accept", pluginContentType)
+	req.Header.Set("Content-Type", pluginContentType)
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "error sending request to volume plugin %s endpoint %s", p.Name, endpoint)
+	}
+	defer resp.Body.Close()
+
+	contents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "error reading response from volume plugin %s endpoint %s", p.Name, endpoint)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// Plugins report errors in the Err field, even with a non-200
+		// status code
+		errResp := new(response)
+		if err := json.Unmarshal(contents, errResp); err == nil && errResp.Err != "" {
+			return errors.Errorf("volume plugin %s: %s", p.Name, errResp.Err)
+		}
+		return errors.Errorf("volume plugin %s endpoint %s returned status %d: %s", p.Name, endpoint, resp.StatusCode, strings.TrimSpace(string(contents)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(contents, out); err != nil {
+		return errors.Wrapf(err, "error decoding response from volume plugin %s endpoint %s", p.Name, endpoint)
+	}
+	return nil
+}
+
+// volumeCall performs a volume driver request and checks the Err field of
+// the response.
+func (p *VolumePlugin) volumeCall(endpoint string, in interface{}) (*response, error) {
+	resp := new(response)
+	if err := p.call(endpoint, in, resp); err != nil {
+		return nil, err
+	}
+	if resp.Err != "" {
+		return nil, errors.Errorf("volume plugin %s: %s", p.Name, resp.Err)
+	}
+	return resp, nil
+}
+
+// CreateVolume creates a volume in the plugin.
+func (p *VolumePlugin) CreateVolume(name string, options map[string]string) error {
+	_, err := p.volumeCall(createPath, &createRequest{Name: name, Options: options})
+	return err
+}
+
+// RemoveVolume removes a volume from the plugin.
+func (p *VolumePlugin) RemoveVolume(name string) error {
+	_, err := p.volumeCall(removePath, &nameRequest{Name: name})
+	return err
+}
+
+// GetVolume gets a single volume from the plugin.
+func (p *VolumePlugin) GetVolume(name string) (*Volume, error) {
+	resp, err := p.volumeCall(getPath, &nameRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Volume == nil {
+		return nil, errors.Errorf("volume plugin %s did not return volume %s", p.Name, name)
+	}
+	return resp.Volume, nil
+}
+
+// ListVolumes lists all volumes managed by the plugin.
+func (p *VolumePlugin) ListVolumes() ([]*Volume, error) {
+	resp, err := p.volumeCall(listPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Volumes, nil
+}
+
+// GetVolumePath gets the path the volume is mounted at on the host. The
+// path is only valid while the volume is mounted.
+func (p *VolumePlugin) GetVolumePath(name string) (string, error) {
+	resp, err := p.volumeCall(hostVirtualPath, &nameRequest{Name: name})
+	if err != nil {
+		return "", err
+	}
+	return resp.Mountpoint, nil
+}
+
+// MountVolume mounts the volume on behalf of the container with the given
+// ID and returns the path the volume is mounted at.
+func (p *VolumePlugin) MountVolume(name, id string) (string, error) {
+	resp, err := p.volumeCall(mountPath, &mountRequest{Name: name, ID: id})
+	if err != nil {
+		return "", err
+	}
+	if resp.Mountpoint == "" {
+		return "", errors.Errorf("volume plugin %s did not return a mountpoint for volume %s", p.Name, name)
+	}
+	return resp.Mountpoint, nil
+}
+
+// UnmountVolume releases the mount of the volume held by the container with
+// the given ID.
+func (p *VolumePlugin) UnmountVolume(name, id string) error {
+	_, err := p.volumeCall(unmountPath, &mountRequest{Name: name, ID: id})
+	return err
+}
+
+// Capabilities retrieves the capabilities of the plugin. Plugins are not
+// required to implement this, so we default to a local scope.
+func (p *VolumePlugin) Capabilities() (*Capabilities, error) {
+	resp := new(response)
+	if err := p.call(capabilitiesPath, nil, resp); err != nil {
+		logrus.Debugf("Volume plugin %s does not report capabilities: %v", p.Name, err)
+		return &Capabilities{Scope: "local"}, nil
+	}
+	if resp.Err != "" {
+		return nil, errors.Errorf("volume plugin %s: %s", p.Name, resp.Err)
+	}
+	caps := resp.Capabilities
+	if caps.Scope == "" {
+		caps.Scope = "local"
+	}
+	return &caps, nil
+}
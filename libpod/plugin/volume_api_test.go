@@ -0,0 +1,182 @@
+package plugin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePlugin is a minimal in-memory volume plugin.
+type fakePlugin struct {
+	lock    sync.Mutex
+	volumes map[string]map[string]string
+	mounts  map[string]int
+}
+
+func (f *fakePlugin) reply(w http.ResponseWriter, resp interface{}) {
+	w.Header().Set("Content-Type", pluginContentType)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (f *fakePlugin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	req := new(createRequest)
+	_ = json.NewDecoder(r.Body).Decode(req)
+
+	switch r.URL.Path {
+	case activatePath:
+		f.reply(w, &activateResponse{Implements: []string{volumePluginType}})
+	case capabilitiesPath:
+		f.reply(w, map[string]interface{}{"Capabilities": Capabilities{Scope: "global"}})
+	case createPath:
+		f.volumes[req.Name] = req.Options
+		f.reply(w, &response{})
+	case removePath:
+		if _, ok := f.volumes[req.Name]; !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			f.reply(w, &response{Err: "no such volume"})
+			return
+		}
+		delete(f.volumes, req.Name)
+		f.reply(w, &response{})
+	case mountPath:
+		f.mounts[req.Name]++
+		f.reply(w, &response{Mountpoint: "/mnt/" + req.Name})
+	case unmountPath:
+		f.mounts[req.Name]--
+		f.reply(w, &response{})
+	case hostVirtualPath:
+		f.reply(w, &response{Mountpoint: "/mnt/" + req.Name})
+	case getPath:
+		f.reply(w, &response{Volume: &Volume{Name: req.Name}})
+	case listPath:
+		resp := &response{}
+		for name := range f.volumes {
+			resp.Volumes = append(resp.Volumes, &Volume{Name: name})
+		}
+		f.reply(w, resp)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// startFakePlugin serves a fake plugin on <dir>/<name>.sock.
+func startFakePlugin(t *testing.T, dir, name string) (*fakePlugin, *http.Server) {
+	fake := &fakePlugin{
+		volumes: make(map[string]map[string]string),
+		mounts:  make(map[string]int),
+	}
+	listener, err := net.Listen("unix", filepath.Join(dir, name+".sock"))
+	require.NoError(t, err)
+	server := &http.Server{Handler: fake}
+	go server.Serve(listener)
+	return fake, server
+}
+
+func TestVolumePluginLifecycle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-plugins")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fake, server := startFakePlugin(t, dir, "lifecycle")
+	defer server.Close()
+
+	volPlugin, err := GetVolumePlugin("lifecycle", []string{dir})
+	require.NoError(t, err)
+
+	caps, err := volPlugin.Capabilities()
+	require.NoError(t, err)
+	assert.Equal(t, "global", caps.Scope)
+
+	require.NoError(t, volPlugin.CreateVolume("vol1", map[string]string{"size": "1G"}))
+	assert.Equal(t, map[string]string{"size": "1G"}, fake.volumes["vol1"])
+
+	vols, err := volPlugin.ListVolumes()
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	assert.Equal(t, "vol1", vols[0].Name)
+
+	vol, err := volPlugin.GetVolume("vol1")
+	require.NoError(t, err)
+	assert.Equal(t, "vol1", vol.Name)
+
+	mountPoint, err := volPlugin.MountVolume("vol1", "ctr1")
+	require.NoError(t, err)
+	assert.Equal(t, "/mnt/vol1", mountPoint)
+	assert.Equal(t, 1, fake.mounts["vol1"])
+
+	path, err := volPlugin.GetVolumePath("vol1")
+	require.NoError(t, err)
+	assert.Equal(t, "/mnt/vol1", path)
+
+	require.NoError(t, volPlugin.UnmountVolume("vol1", "ctr1"))
+	assert.Equal(t, 0, fake.mounts["vol1"])
+
+	require.NoError(t, volPlugin.RemoveVolume("vol1"))
+	err = volPlugin.RemoveVolume("vol1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no such volume")
+
+	// Plugins are cached once activated
+	cached, err := GetVolumePlugin("lifecycle", []string{dir})
+	require.NoError(t, err)
+	assert.True(t, cached == volPlugin)
+}
+
+func TestGetAllVolumePlugins(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-plugins")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, server := startFakePlugin(t, dir, "listed")
+	defer server.Close()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README"), []byte("not a plugin"), 0644))
+
+	volPlugins := GetAllVolumePlugins([]string{dir, filepath.Join(dir, "missing")})
+	require.Len(t, volPlugins, 1)
+	assert.Equal(t, "listed", volPlugins[0].Name)
+}
+
+func TestFindPluginSpecFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-plugins")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "tcpplugin.spec"), []byte("tcp://localhost:8080\n"), 0644))
+	network, address, err := findPlugin("tcpplugin", []string{dir})
+	require.NoError(t, err)
+	assert.Equal(t, "tcp", network)
+	assert.Equal(t, "localhost:8080", address)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "unixplugin.spec"), []byte("unix:///run/unixplugin.sock"), 0644))
+	network, address, err = findPlugin("unixplugin", []string{dir})
+	require.NoError(t, err)
+	assert.Equal(t, "unix", network)
+	assert.Equal(t, "/run/unixplugin.sock", address)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "badplugin.spec"), []byte("http://localhost"), 0644))
+	_, _, err = findPlugin("badplugin", []string{dir})
+	assert.Error(t, err)
+}
+
+func TestFindPluginMissing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-plugins")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = GetVolumePlugin("missing", []string{dir})
+	assert.Error(t, err)
+
+	_, _, err = findPlugin("../missing", []string{dir})
+	assert.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/libpod/libpod/lock"
 	"github.com/cri-o/ocicni/pkg/ocicni"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 )
 
@@ -38,6 +39,9 @@ type PodConfig struct {
 
 	// Labels contains labels applied to the pod
 	Labels map[string]string `json:"labels"`
+	// Hostname is the hostname of the pod's infra container, shared with
+	// containers joining the pod's UTS namespace
+	Hostname string `json:"hostname,omitempty"`
 	// CgroupParent contains the pod's CGroup parent
 	CgroupParent string `json:"cgroupParent"`
 	// UsePodCgroup indicates whether the pod will create its own CGroup and
@@ -57,6 +61,13 @@ type PodConfig struct {
 
 	InfraContainer *InfraContainerConfig `json:"infraConfig"`
 
+	// Resources are the resource limits set on the pod's cgroup, shared by
+	// all containers in the pod
+	Resources *spec.LinuxResources `json:"resources,omitempty"`
+
+	// Volumes are named volumes mounted into every container in the pod
+	Volumes []*ContainerNamedVolume `json:"volumes,omitempty"`
+
 	// Time pod was created
 	CreatedTime time.Time `json:"created"`
 
@@ -97,6 +108,18 @@ type PodContainerInfo struct {
 type InfraContainerConfig struct {
 	HasInfraContainer bool                 `json:"makeInfraContainer"`
 	PortBindings      []ocicni.PortMapping `json:"infraPortBindings"`
+	// DNSServer, DNSSearch and HostAdd configure the infra container's
+	// /etc/resolv.conf and /etc/hosts, which are shared by all containers
+	// joining the pod's network namespace
+	DNSServer []string `json:"dnsServer,omitempty"`
+	DNSSearch []string `json:"dnsSearch,omitempty"`
+	HostAdd   []string `json:"hostsAdd,omitempty"`
+	// UseImageResolvConf indicates the infra container will not create
+	// /etc/resolv.conf
+	UseImageResolvConf bool `json:"useImageResolvConf,omitempty"`
+	// UseImageHosts indicates the infra container will not create
+	// /etc/hosts
+	UseImageHosts bool `json:"useImageHosts,omitempty"`
 }
 
 // ID retrieves the pod's ID
@@ -104,6 +127,11 @@ func (p *Pod) ID() string {
 	return p.config.ID
 }
 
+// Hostname returns the hostname of the pod
+func (p *Pod) Hostname() string {
+	return p.config.Hostname
+}
+
 // Name retrieves the pod's name
 func (p *Pod) Name() string {
 	return p.config.Name
@@ -125,6 +153,19 @@ func (p *Pod) Labels() map[string]string {
 	return labels
 }
 
+// Volumes returns the named volumes mounted into every container in the pod
+func (p *Pod) Volumes() []*ContainerNamedVolume {
+	volumes := make([]*ContainerNamedVolume, 0, len(p.config.Volumes))
+	for _, vol := range p.config.Volumes {
+		newVol := new(ContainerNamedVolume)
+		newVol.Name = vol.Name
+		newVol.Dest = vol.Dest
+		newVol.Options = append([]string{}, vol.Options...)
+		volumes = append(volumes, newVol)
+	}
+	return volumes
+}
+
 // CreatedTime gets the time when the pod was created
 func (p *Pod) CreatedTime() time.Time {
 	return p.config.CreatedTime
@@ -285,8 +326,23 @@ func (p *Pod) GetPodStats(previousContainerStats map[string]*ContainerStats) (ma
 			return nil, err
 		}
 		if err == nil {
+			p.applyMemoryLimit(newStats)
 			newContainerStats[c.ID()] = newStats
 		}
 	}
 	return newContainerStats, nil
 }
+
+// applyMemoryLimit compares the memory usage of a container in the pod against
+// the pod's memory limit, if it is lower than the container's own limit.
+func (p *Pod) applyMemoryLimit(stats *ContainerStats) {
+	if p.config.Resources == nil || p.config.Resources.Memory == nil || p.config.Resources.Memory.Limit == nil {
+		return
+	}
+	limit := *p.config.Resources.Memory.Limit
+	if limit <= 0 || uint64(limit) >= stats.MemLimit {
+		return
+	}
+	stats.MemLimit = uint64(limit)
+	stats.MemPerc = (float64(stats.MemUsage) / float64(stats.MemLimit)) * 100
+}
@@ -16,6 +16,8 @@ import (
 // Containers that are already running or have been paused are ignored
 // All containers are started independently, in order dictated by their
 // dependencies.
+// Init containers are run first, one after another, and must all exit
+// successfully before the other containers are started.
 // An error and a map[string]error are returned
 // If the error is not nil and the map is nil, an error was encountered before
 // any containers were started
@@ -36,8 +38,12 @@ func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
 		return nil, err
 	}
 
+	// Init containers run before the others, and are not part of the
+	// dependency graph
+	initCtrs, ctrs := splitInitCtrs(allCtrs)
+
 	// Build a dependency graph of containers in the pod
-	graph, err := buildContainerGraph(allCtrs)
+	graph, err := buildContainerGraph(ctrs)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error generating dependency graph for pod %s", p.ID())
 	}
@@ -47,10 +53,14 @@ func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
 
 	// If there are no containers without dependencies, we can't start
 	// Error out
-	if len(graph.noDepNodes) == 0 {
+	if len(graph.noDepNodes) == 0 && (len(graph.nodes) > 0 || len(initCtrs) == 0) {
 		return nil, errors.Wrapf(define.ErrNoSuchCtr, "no containers in pod %s have no dependencies, cannot start pod", p.ID())
 	}
 
+	if err := p.runInitCtrs(ctx, initCtrs, ctrs); err != nil {
+		return nil, err
+	}
+
 	// Traverse the graph beginning at nodes with no dependencies
 	for _, node := range graph.noDepNodes {
 		startNode(ctx, node, false, ctrErrors, ctrsVisited, false)
@@ -265,6 +275,7 @@ func (p *Pod) Unpause() (map[string]error, error) {
 // Restart restarts all containers within a pod that are not paused or in an error state.
 // It combines the effects of Stop() and Start() on a container
 // Each container will use its own stop timeout.
+// Init containers are not run again.
 // All containers are started independently, in order dictated by their
 // dependencies. An error restarting one container
 // will not prevent other containers being restarted.
@@ -288,8 +299,11 @@ func (p *Pod) Restart(ctx context.Context) (map[string]error, error) {
 		return nil, err
 	}
 
+	// Init containers have already completed, they are not run again
+	_, ctrs := splitInitCtrs(allCtrs)
+
 	// Build a dependency graph of containers in the pod
-	graph, err := buildContainerGraph(allCtrs)
+	graph, err := buildContainerGraph(ctrs)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error generating dependency graph for pod %s", p.ID())
 	}
@@ -0,0 +1,57 @@
+// +build linux
+
+package libpod
+
+import (
+	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/pkg/cgroups"
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// setupCgroupLimits creates the pod's cgroup if it does not exist yet and
+// applies the pod's resource limits to it.
+// The cgroup is shared by all containers in the pod, so the limits apply to
+// the containers as a whole.
+func (p *Pod) setupCgroupLimits() error {
+	if !p.config.UsePodCgroup || p.config.Resources == nil {
+		return nil
+	}
+
+	cgroup2, err := cgroups.IsCgroup2UnifiedMode()
+	if err != nil {
+		return err
+	}
+	if rootless.IsRootless() && !cgroup2 {
+		return errors.Wrapf(define.ErrInvalidArg, "pod resource limits are not supported in rootless mode with cgroups v1")
+	}
+
+	var control *cgroups.CgroupControl
+	switch p.runtime.config.CgroupManager {
+	case SystemdCgroupsManager:
+		// systemd only creates the slice when the first container is
+		// placed in it, create it now so its limits can be set
+		control, err = cgroups.NewSystemd(p.state.CgroupPath)
+		if err == nil {
+			err = control.CreateSystemdUnit(p.state.CgroupPath)
+		}
+	case CgroupfsCgroupsManager:
+		control, err = cgroups.New(p.state.CgroupPath, p.config.Resources)
+	default:
+		return errors.Wrapf(define.ErrInvalidArg, "unsupported CGroup manager: %s", p.runtime.config.CgroupManager)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "error creating cgroup %s for pod %s", p.state.CgroupPath, p.ID())
+	}
+
+	if err := control.Update(p.config.Resources); err != nil {
+		if err2 := control.Delete(); err2 != nil {
+			logrus.Errorf("Error removing cgroup %s of pod %s: %v", p.state.CgroupPath, p.ID(), err2)
+		}
+		return errors.Wrapf(err, "error setting resource limits on cgroup %s of pod %s", p.state.CgroupPath, p.ID())
+	}
+
+	logrus.Debugf("Set resource limits on cgroup %s of pod %s", p.state.CgroupPath, p.ID())
+	return nil
+}
@@ -0,0 +1,14 @@
+// +build !linux
+
+package libpod
+
+import (
+	"github.com/containers/libpod/libpod/define"
+)
+
+func (p *Pod) setupCgroupLimits() error {
+	if p.config.Resources == nil {
+		return nil
+	}
+	return define.ErrOSNotSupported
+}
@@ -1,16 +1,22 @@
 package libpod
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/storage/pkg/stringid"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
+// podCPUPeriod is the CFS period used to enforce the pod's CPU limit
+const podCPUPeriod = 100000
+
 // Creates a new, empty pod
 func newPod(runtime *Runtime) (*Pod, error) {
 	pod := new(Pod)
@@ -79,8 +85,156 @@ func (p *Pod) refresh() error {
 		default:
 			return errors.Wrapf(define.ErrInvalidArg, "unknown cgroups manager %s specified", p.runtime.config.CgroupManager)
 		}
+
+		if err := p.setupCgroupLimits(); err != nil {
+			logrus.Errorf("Error setting resource limits of pod %s: %v", p.ID(), err)
+		}
 	}
 
 	// Save changes
 	return p.save()
 }
+
+// podResources returns the pod's resource limits, creating them if needed.
+func (p *Pod) podResources() *spec.LinuxResources {
+	if p.config.Resources == nil {
+		p.config.Resources = new(spec.LinuxResources)
+	}
+	return p.config.Resources
+}
+
+// hasInfraNetworkConfig returns whether the pod sets a hostname, DNS or hosts
+// configuration, all of which are applied to its infra container.
+func (p *Pod) hasInfraNetworkConfig() bool {
+	infraConfig := p.config.InfraContainer
+	return p.config.Hostname != "" ||
+		len(infraConfig.DNSServer) > 0 ||
+		len(infraConfig.DNSSearch) > 0 ||
+		len(infraConfig.HostAdd) > 0 ||
+		infraConfig.UseImageResolvConf ||
+		infraConfig.UseImageHosts
+}
+
+// splitInitCtrs separates the init containers of a pod from its other
+// containers. The init containers are sorted in the order they were created,
+// which is the order they run in.
+func splitInitCtrs(ctrs []*Container) ([]*Container, []*Container) {
+	var initCtrs, otherCtrs []*Container
+	for _, ctr := range ctrs {
+		if ctr.config.IsInitCtr {
+			initCtrs = append(initCtrs, ctr)
+		} else {
+			otherCtrs = append(otherCtrs, ctr)
+		}
+	}
+	sort.Slice(initCtrs, func(i, j int) bool {
+		return initCtrs[i].config.CreatedTime.Before(initCtrs[j].config.CreatedTime)
+	})
+	return initCtrs, otherCtrs
+}
+
+// initCtrsDone returns whether the init containers of the pod must not be run
+// again, because a container of the pod is already running or because all init
+// containers exited with code 0 since the running infra container of the pod
+// was started.  Init containers run again once the whole pod was stopped.
+// The pod must be locked.
+func (p *Pod) initCtrsDone(initCtrs []*Container, ctrs []*Container) (bool, error) {
+	// The start of the infra container is the start of the pod
+	var podStarted time.Time
+	for _, ctr := range ctrs {
+		ctr.lock.Lock()
+		err := ctr.syncContainer()
+		state := ctr.state.State
+		startedTime := ctr.state.StartedTime
+		ctr.lock.Unlock()
+		if err != nil {
+			return false, err
+		}
+		running := state == define.ContainerStateRunning || state == define.ContainerStatePaused
+		if ctr.IsInfra() {
+			if running {
+				podStarted = startedTime
+			}
+			continue
+		}
+		if running {
+			return true, nil
+		}
+	}
+	if podStarted.IsZero() {
+		return false, nil
+	}
+
+	for _, ctr := range initCtrs {
+		ctr.lock.Lock()
+		err := ctr.syncContainer()
+		exited := ctr.state.State == define.ContainerStateStopped || ctr.state.State == define.ContainerStateExited
+		completed := exited && ctr.state.ExitCode == 0 && ctr.state.FinishedTime.After(podStarted)
+		ctr.lock.Unlock()
+		if err != nil {
+			return false, err
+		}
+		if !completed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// runInitCtrs starts the infra container of the pod, if it has one among
+// ctrs, and then runs the init containers of the pod one after another.
+// An error is returned as soon as an init container fails to start or exits
+// with a non-zero exit code.
+// The pod must be locked.
+func (p *Pod) runInitCtrs(ctx context.Context, initCtrs []*Container, ctrs []*Container) error {
+	if len(initCtrs) == 0 {
+		return nil
+	}
+
+	done, err := p.initCtrsDone(initCtrs, ctrs)
+	if err != nil {
+		return err
+	}
+	if done {
+		logrus.Debugf("Init containers of pod %s already ran, not running them again", p.ID())
+		return nil
+	}
+
+	// Init containers join the namespaces of the infra container
+	for _, ctr := range ctrs {
+		if !ctr.IsInfra() {
+			continue
+		}
+		ctr.lock.Lock()
+		err := ctr.syncContainer()
+		if err == nil {
+			err = ctr.initAndStart(ctx)
+		}
+		ctr.lock.Unlock()
+		if err != nil {
+			return errors.Wrapf(err, "error starting infra container %s of pod %s", ctr.ID(), p.ID())
+		}
+	}
+
+	for _, ctr := range initCtrs {
+		ctr.lock.Lock()
+		err := ctr.syncContainer()
+		if err == nil {
+			err = ctr.initAndStart(ctx)
+		}
+		ctr.lock.Unlock()
+		if err != nil {
+			return errors.Wrapf(err, "error starting init container %s of pod %s", ctr.ID(), p.ID())
+		}
+
+		exitCode, err := ctr.Wait()
+		if err != nil {
+			return errors.Wrapf(err, "error waiting for init container %s of pod %s", ctr.ID(), p.ID())
+		}
+		if exitCode != 0 {
+			return errors.Errorf("init container %s of pod %s exited with code %d", ctr.ID(), p.ID(), exitCode)
+		}
+		logrus.Debugf("Init container %s of pod %s completed", ctr.ID(), p.ID())
+	}
+	return nil
+}
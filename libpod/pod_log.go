@@ -0,0 +1,81 @@
+package libpod
+
+import (
+	"time"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/libpod/logs"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// podLogPollInterval is how often the containers of a pod are listed when
+// following its logs, to follow the containers added to the pod
+const podLogPollInterval = time.Second
+
+// ReadLog reads the logs of the containers in the pod, merged in the order
+// they were logged. When following, the logs of containers added to the pod
+// are followed as well, until the pod is removed.
+func (p *Pod) ReadLog(options *logs.LogOptions, logChannel chan *logs.LogLine) error {
+	options.PrepareColors()
+	merger := logs.NewMerger(logChannel, options.Follow)
+	options.WaitGroup.Add(1)
+	go func() {
+		merger.Run()
+		options.WaitGroup.Done()
+	}()
+
+	read := make(map[string]bool)
+	if err := p.mergeNewLogs(options, merger, read); err != nil {
+		merger.Close()
+		return err
+	}
+	if !options.Follow {
+		merger.Close()
+		return nil
+	}
+
+	go func() {
+		defer merger.Close()
+		for options.Until.IsZero() || time.Now().Before(options.Until) {
+			time.Sleep(podLogPollInterval)
+			if err := p.mergeNewLogs(options, merger, read); err != nil {
+				if errors.Cause(err) != define.ErrNoSuchPod && errors.Cause(err) != define.ErrPodRemoved {
+					logrus.Errorf("error following logs of pod %s: %v", p.ID(), err)
+				}
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// mergeNewLogs adds the logs of the containers in the pod that are not read
+// yet to the inputs of the merger. The infra container is skipped, as are
+// containers that were not created yet and have no log.
+func (p *Pod) mergeNewLogs(options *logs.LogOptions, merger *logs.Merger, read map[string]bool) error {
+	ctrs, err := p.AllContainers()
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		if read[ctr.ID()] || ctr.IsInfra() {
+			continue
+		}
+		state, err := ctr.State()
+		if err != nil {
+			if errors.Cause(err) == define.ErrNoSuchCtr || errors.Cause(err) == define.ErrCtrRemoved {
+				continue
+			}
+			return err
+		}
+		if state == define.ContainerStateConfigured {
+			continue
+		}
+		if err := ctr.mergeLog(options, merger); err != nil {
+			return err
+		}
+		read[ctr.ID()] = true
+	}
+	return nil
+}
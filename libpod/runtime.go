@@ -144,6 +144,10 @@ type RuntimeConfig struct {
 	// under. This convention is followed by the default volume driver, but
 	// may not be by other drivers.
 	VolumePath string `toml:"volume_path"`
+	// VolumePluginDir sets a number of directories where volume plugin
+	// sockets and spec files can be located. Volumes created with a driver
+	// other than "local" are handled by the plugin of the same name.
+	VolumePluginDir []string `toml:"volume_plugin_dir"`
 	// ImageDefaultTransport is the default transport method used to fetch
 	// images
 	ImageDefaultTransport string `toml:"image_default_transport"`
@@ -320,6 +324,7 @@ func defaultRuntimeConfig() (RuntimeConfig, error) {
 		NoPivotRoot:           false,
 		CNIConfigDir:          etcDir + "/cni/net.d/",
 		CNIPluginDir:          []string{"/usr/libexec/cni", "/usr/lib/cni", "/usr/local/lib/cni", "/opt/cni/bin"},
+		VolumePluginDir:       []string{"/run/docker/plugins", "/etc/docker/plugins", "/usr/lib/docker/plugins"},
 		InfraCommand:          define.DefaultInfraCommand,
 		InfraImage:            define.DefaultInfraImage,
 		EnablePortReservation: true,
@@ -1280,7 +1285,8 @@ func (r *Runtime) refresh(alivePath string) error {
 	}
 
 	// Next refresh the state of all containers to recreate dirs and
-	// namespaces, and all the pods to recreate cgroups
+	// namespaces, all the pods to recreate cgroups, and all the volumes to
+	// recreate their locks
 	ctrs, err := r.state.AllContainers()
 	if err != nil {
 		return errors.Wrapf(err, "error retrieving all containers from state")
@@ -1289,6 +1295,10 @@ func (r *Runtime) refresh(alivePath string) error {
 	if err != nil {
 		return errors.Wrapf(err, "error retrieving all pods from state")
 	}
+	vols, err := r.state.AllVolumes()
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving all volumes from state")
+	}
 	// No locks are taken during pod and container refresh.
 	// Furthermore, the pod and container refresh() functions are not
 	// allowed to take locks themselves.
@@ -1306,6 +1316,11 @@ func (r *Runtime) refresh(alivePath string) error {
 			logrus.Errorf("Error refreshing pod %s: %v", pod.ID(), err)
 		}
 	}
+	for _, vol := range vols {
+		if err := vol.refresh(); err != nil {
+			logrus.Errorf("Error refreshing volume %s: %v", vol.Name(), err)
+		}
+	}
 
 	// Create a file indicating the runtime is alive and ready
 	file, err := os.OpenFile(alivePath, os.O_RDONLY|os.O_CREATE, 0644)
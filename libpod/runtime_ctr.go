@@ -174,6 +174,35 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (c *Contai
 		}
 	}
 
+	if ctr.config.IsInitCtr {
+		if pod == nil {
+			return nil, errors.Wrapf(config2.ErrInvalidArg, "init containers must be part of a pod")
+		}
+		if ctr.config.RestartPolicy != "" && ctr.config.RestartPolicy != RestartPolicyNo {
+			return nil, errors.Wrapf(config2.ErrInvalidArg, "init containers cannot have a restart policy")
+		}
+	}
+
+	if ctr.rotatesLog() || ctr.config.LogCompress {
+		if ctr.config.LogDriver == JournaldLogging {
+			return nil, errors.Wrapf(config2.ErrInvalidArg, "log rotation is not supported with the %s log driver", JournaldLogging)
+		}
+		if ctr.config.LogSize <= 0 {
+			return nil, errors.Wrapf(config2.ErrInvalidArg, "log rotation requires a maximum log size")
+		}
+		if ctr.config.LogFiles < 2 {
+			return nil, errors.Wrapf(config2.ErrInvalidArg, "log compression requires keeping more than one log file")
+		}
+	}
+
+	if ctr.config.LogDriver != SyslogLogging && (ctr.config.LogSyslogAddress != "" || ctr.config.LogSyslogFacility != "" || ctr.config.LogSyslogTag != "") {
+		return nil, errors.Wrapf(config2.ErrInvalidArg, "syslog log options require the %s log driver", SyslogLogging)
+	}
+
+	if pod != nil && !ctr.config.IsInfra {
+		ctr.addPodVolumes(pod)
+	}
+
 	if ctr.config.Name == "" {
 		name, err := r.generateName()
 		if err != nil {
@@ -627,3 +656,108 @@ func (r *Runtime) GetLatestContainer() (*Container, error) {
 	}
 	return ctrs[lastCreatedIndex], nil
 }
+
+// RunScheduledRestart restarts the given container by its restart policy once
+// it backed off after exiting. It is run in a separate podman process started
+// when the container is cleaned up.
+func (r *Runtime) RunScheduledRestart(ctx context.Context, nameOrID string) error {
+	ctr, err := r.LookupContainer(nameOrID)
+	if err != nil {
+		return errors.Wrapf(err, "unable to lookup %s to restart it", nameOrID)
+	}
+	return ctr.restartWhenDue(ctx, os.Getpid())
+}
+
+// StartRestartPolicyContainers starts all containers whose restart policy
+// requires them to run after the system booted: containers with the "always"
+// policy, and containers with the "unless-stopped" policy that were not
+// stopped by the user. Their dependencies are started first, following the
+// container dependency graph.
+// The IDs of the started containers are returned. Containers that failed to
+// start are returned in a map of container ID to error, and do not prevent
+// the others from being started.
+func (r *Runtime) StartRestartPolicyContainers(ctx context.Context) ([]string, map[string]error, error) {
+	if !r.valid {
+		return nil, nil, config2.ErrRuntimeStopped
+	}
+
+	allCtrs, err := r.state.AllContainers()
+	if err != nil {
+		return nil, nil, err
+	}
+	allByID := make(map[string]*Container, len(allCtrs))
+	for _, ctr := range allCtrs {
+		allByID[ctr.ID()] = ctr
+	}
+
+	ctrErrors := make(map[string]error)
+
+	// Collect the containers to start along with everything they depend on
+	toStart := make(map[string]*Container)
+	var addCtr func(ctr *Container, deps map[string]*Container) error
+	addCtr = func(ctr *Container, deps map[string]*Container) error {
+		if _, ok := toStart[ctr.ID()]; ok {
+			return nil
+		}
+		if _, ok := deps[ctr.ID()]; ok {
+			return nil
+		}
+		deps[ctr.ID()] = ctr
+		for _, dep := range ctr.Dependencies() {
+			depCtr, ok := allByID[dep]
+			if !ok {
+				return errors.Wrapf(config2.ErrNoSuchCtr, "container %s depends on container %s not found", ctr.ID(), dep)
+			}
+			if err := addCtr(depCtr, deps); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, ctr := range allCtrs {
+		restart, err := ctr.restartAtBoot()
+		if err != nil {
+			ctrErrors[ctr.ID()] = err
+			continue
+		}
+		if !restart {
+			continue
+		}
+		// A container whose dependencies cannot be resolved is not
+		// started, but does not keep the others from starting
+		deps := make(map[string]*Container)
+		if err := addCtr(ctr, deps); err != nil {
+			ctrErrors[ctr.ID()] = err
+			continue
+		}
+		for id, depCtr := range deps {
+			toStart[id] = depCtr
+		}
+	}
+
+	ctrs := make([]*Container, 0, len(toStart))
+	for _, ctr := range allCtrs {
+		if _, ok := toStart[ctr.ID()]; ok {
+			ctrs = append(ctrs, ctr)
+		}
+	}
+
+	graph, err := buildContainerGraph(ctrs)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error generating dependency graph of containers to start")
+	}
+
+	ctrsVisited := make(map[string]bool)
+	for _, node := range graph.noDepNodes {
+		startNode(ctx, node, false, ctrErrors, ctrsVisited, false)
+	}
+
+	started := make([]string, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		if _, ok := ctrErrors[ctr.ID()]; !ok {
+			started = append(started, ctr.ID())
+		}
+	}
+
+	return started, ctrErrors, nil
+}
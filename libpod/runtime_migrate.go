@@ -74,5 +74,40 @@ func (r *Runtime) migrate(ctx context.Context) error {
 		}
 	}
 
+	if err := r.migrateVolumeLocks(); err != nil {
+		return err
+	}
+
 	return stopPauseProcess()
 }
+
+// migrateVolumeLocks allocates a lock for every volume created by an older
+// version of libpod, which did not lock volumes.
+func (r *Runtime) migrateVolumeLocks() error {
+	allVols, err := r.state.AllVolumes()
+	if err != nil {
+		return err
+	}
+
+	for _, vol := range allVols {
+		if vol.lock != nil {
+			continue
+		}
+
+		logrus.Infof("allocating lock for volume %s", vol.Name())
+		lock, err := r.lockManager.AllocateLock()
+		if err != nil {
+			return errors.Wrapf(err, "error allocating lock for volume %s", vol.Name())
+		}
+		vol.config.LockID = lock.ID()
+		if err := r.state.RewriteVolumeConfig(vol, vol.config); err != nil {
+			if err2 := lock.Free(); err2 != nil {
+				logrus.Errorf("Error freeing lock for volume %s after failed migration: %v", vol.Name(), err2)
+			}
+			return errors.Wrapf(err, "error rewriting config for volume %s", vol.Name())
+		}
+		vol.lock = lock
+	}
+
+	return nil
+}
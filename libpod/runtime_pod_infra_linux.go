@@ -69,6 +69,11 @@ func (r *Runtime) makeInfraContainer(ctx context.Context, p *Pod, imgName, imgID
 	g.SetRootReadonly(true)
 	g.SetProcessArgs(entryCmd)
 
+	if p.config.Hostname != "" {
+		g.SetHostname(p.config.Hostname)
+		g.AddProcessEnv("HOSTNAME", p.config.Hostname)
+	}
+
 	logrus.Debugf("Using %q as infra container entrypoint", entryCmd)
 
 	if isRootless {
@@ -97,6 +102,25 @@ func (r *Runtime) makeInfraContainer(ctx context.Context, p *Pod, imgName, imgID
 	}
 	options = append(options, WithNetNS(p.config.InfraContainer.PortBindings, isRootless, netmode, networks))
 
+	// The infra container holds the pod's /etc/resolv.conf and /etc/hosts,
+	// which containers joining its network namespace bind mount
+	infraConfig := p.config.InfraContainer
+	if infraConfig.UseImageResolvConf {
+		options = append(options, WithUseImageResolvConf())
+	}
+	if len(infraConfig.DNSServer) > 0 {
+		options = append(options, WithDNS(infraConfig.DNSServer))
+	}
+	if len(infraConfig.DNSSearch) > 0 {
+		options = append(options, WithDNSSearch(infraConfig.DNSSearch))
+	}
+	if infraConfig.UseImageHosts {
+		options = append(options, WithUseImageHosts())
+	}
+	if len(infraConfig.HostAdd) > 0 {
+		options = append(options, WithHosts(infraConfig.HostAdd))
+	}
+
 	return r.newContainer(ctx, g.Config, options...)
 }
 
@@ -110,14 +110,37 @@ func (r *Runtime) NewPod(ctx context.Context, options ...PodCreateOption) (_ *Po
 
 	if pod.config.UsePodCgroup {
 		logrus.Debugf("Got pod cgroup as %s", pod.state.CgroupPath)
+	} else if pod.config.Resources != nil {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "pod resource limits require the pod to have its own cgroup")
+	}
+	if err := pod.setupCgroupLimits(); err != nil {
+		return nil, err
 	}
 	if !pod.HasInfraContainer() && pod.SharesNamespaces() {
 		return nil, errors.Errorf("Pods must have an infra container to share namespaces")
 	}
+	if !pod.HasInfraContainer() && pod.hasInfraNetworkConfig() {
+		return nil, errors.Wrapf(define.ErrInvalidArg, "pods must have an infra container to set a hostname, DNS or hosts configuration")
+	}
 	if pod.HasInfraContainer() && !pod.SharesNamespaces() {
 		logrus.Warnf("Pod has an infra container, but shares no namespaces")
 	}
 
+	// Create the pod's named volumes which do not exist yet. Unlike the
+	// volumes created for containers, they are not removed with the pod.
+	for _, vol := range pod.config.Volumes {
+		if _, err := r.state.Volume(vol.Name); err == nil {
+			continue
+		} else if errors.Cause(err) != define.ErrNoSuchVolume {
+			return nil, errors.Wrapf(err, "error retrieving named volume %s for new pod", vol.Name)
+		}
+
+		logrus.Debugf("Creating new volume %s for pod", vol.Name)
+		if _, err := r.newVolume(ctx, WithVolumeName(vol.Name)); err != nil {
+			return nil, errors.Wrapf(err, "error creating named volume %q", vol.Name)
+		}
+	}
+
 	if err := r.state.AddPod(pod); err != nil {
 		return nil, errors.Wrapf(err, "error adding pod to state")
 	}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/libpod/libpod/events"
+	"github.com/containers/libpod/libpod/plugin"
+	"github.com/containers/storage/pkg/mount"
 	"github.com/containers/storage/pkg/stringid"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -27,7 +29,7 @@ func (r *Runtime) NewVolume(ctx context.Context, options ...VolumeCreateOption)
 }
 
 // newVolume creates a new empty volume
-func (r *Runtime) newVolume(ctx context.Context, options ...VolumeCreateOption) (*Volume, error) {
+func (r *Runtime) newVolume(ctx context.Context, options ...VolumeCreateOption) (_ *Volume, Err error) {
 	volume, err := newVolume(r)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error creating volume")
@@ -42,11 +44,68 @@ func (r *Runtime) newVolume(ctx context.Context, options ...VolumeCreateOption)
 	if volume.config.Name == "" {
 		volume.config.Name = stringid.GenerateNonCryptoID()
 	}
-	// TODO: support for other volume drivers
 	if volume.config.Driver == "" {
-		volume.config.Driver = "local"
+		volume.config.Driver = define.VolumeDriverLocal
 	}
-	// TODO: determine when the scope is global and set it to that
+
+	// Make sure we don't hand an existing volume's name to its driver, a
+	// plugin would happily reuse it
+	exists, err := r.state.HasVolume(volume.config.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error checking if volume %s exists", volume.config.Name)
+	}
+	if exists {
+		return nil, errors.Wrapf(define.ErrVolumeExists, "volume with name %s already exists", volume.config.Name)
+	}
+
+	if !volume.usesPlugin() {
+		if err := validateLocalVolumeOptions(volume.config.Options); err != nil {
+			return nil, err
+		}
+	}
+
+	// Allocate a lock for the volume
+	lock, err := r.lockManager.AllocateLock()
+	if err != nil {
+		return nil, errors.Wrapf(err, "error allocating lock for new volume")
+	}
+	volume.lock = lock
+	volume.config.LockID = volume.lock.ID()
+
+	defer func() {
+		if Err != nil {
+			if err := volume.lock.Free(); err != nil {
+				logrus.Errorf("Error freeing volume lock after failed creation: %v", err)
+			}
+		}
+	}()
+
+	if volume.usesPlugin() {
+		if err := r.createPluginVolume(volume); err != nil {
+			return nil, err
+		}
+	} else if err := r.createLocalVolume(volume); err != nil {
+		return nil, err
+	}
+
+	volume.valid = true
+
+	// Add the volume to state
+	if err := r.state.AddVolume(volume); err != nil {
+		if volume.usesPlugin() {
+			if err2 := r.removePluginVolume(volume); err2 != nil {
+				logrus.Errorf("Error removing volume %s after failing to add it to state: %v", volume.Name(), err2)
+			}
+		}
+		return nil, errors.Wrapf(err, "error adding volume to state")
+	}
+	defer volume.newVolumeEvent(events.Create)
+	return volume, nil
+}
+
+// createLocalVolume creates the directory backing a volume using the local
+// driver.
+func (r *Runtime) createLocalVolume(volume *Volume) error {
 	if volume.config.Scope == "" {
 		volume.config.Scope = "local"
 	}
@@ -54,31 +113,143 @@ func (r *Runtime) newVolume(ctx context.Context, options ...VolumeCreateOption)
 	// Create the mountpoint of this volume
 	volPathRoot := filepath.Join(r.config.VolumePath, volume.config.Name)
 	if err := os.MkdirAll(volPathRoot, 0700); err != nil {
-		return nil, errors.Wrapf(err, "error creating volume directory %q", volPathRoot)
+		return errors.Wrapf(err, "error creating volume directory %q", volPathRoot)
 	}
 	if err := os.Chown(volPathRoot, volume.config.UID, volume.config.GID); err != nil {
-		return nil, errors.Wrapf(err, "error chowning volume directory %q to %d:%d", volPathRoot, volume.config.UID, volume.config.GID)
+		return errors.Wrapf(err, "error chowning volume directory %q to %d:%d", volPathRoot, volume.config.UID, volume.config.GID)
+	}
+	// The quota is placed on the volume directory before creating _data,
+	// so _data inherits its project
+	size, err := volumeSizeOption(volume.config.Options)
+	if err != nil {
+		return err
+	}
+	if size > 0 {
+		if err := setVolumeQuota(r.config.VolumePath, volPathRoot, size); err != nil {
+			if err2 := os.RemoveAll(volPathRoot); err2 != nil {
+				logrus.Errorf("Error removing volume directory %q after failing to set its size: %v", volPathRoot, err2)
+			}
+			return errors.Wrapf(err, "error setting size of volume %s", volume.Name())
+		}
 	}
 	fullVolPath := filepath.Join(volPathRoot, "_data")
 	if err := os.Mkdir(fullVolPath, 0755); err != nil {
-		return nil, errors.Wrapf(err, "error creating volume directory %q", fullVolPath)
+		return errors.Wrapf(err, "error creating volume directory %q", fullVolPath)
 	}
 	if err := os.Chown(fullVolPath, volume.config.UID, volume.config.GID); err != nil {
-		return nil, errors.Wrapf(err, "error chowning volume directory %q to %d:%d", fullVolPath, volume.config.UID, volume.config.GID)
+		return errors.Wrapf(err, "error chowning volume directory %q to %d:%d", fullVolPath, volume.config.UID, volume.config.GID)
 	}
 	if err := LabelVolumePath(fullVolPath, true); err != nil {
-		return nil, err
+		return err
 	}
 	volume.config.MountPoint = fullVolPath
 
-	volume.valid = true
+	return nil
+}
 
-	// Add the volume to state
+// createPluginVolume creates a volume in the volume plugin named by its
+// driver.
+func (r *Runtime) createPluginVolume(volume *Volume) error {
+	volPlugin, err := volume.plugin()
+	if err != nil {
+		return err
+	}
+
+	caps, err := volPlugin.Capabilities()
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving capabilities of volume plugin %s", volPlugin.Name)
+	}
+	if volume.config.Scope == "" {
+		volume.config.Scope = caps.Scope
+	}
+
+	if err := volPlugin.CreateVolume(volume.Name(), volume.config.Options); err != nil {
+		return errors.Wrapf(err, "error creating volume %s using plugin %s", volume.Name(), volPlugin.Name)
+	}
+	return nil
+}
+
+// AddPluginVolumes adds the volumes of all volume plugins which were not
+// created by libpod, for instance by other clients of the plugins, so they
+// can be listed and used like any other volume.
+func (r *Runtime) AddPluginVolumes() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+
+	for _, volPlugin := range plugin.GetAllVolumePlugins(r.config.VolumePluginDir) {
+		vols, err := volPlugin.ListVolumes()
+		if err != nil {
+			logrus.Warnf("Error listing volumes of volume plugin %s: %v", volPlugin.Name, err)
+			continue
+		}
+		for _, vol := range vols {
+			exists, err := r.state.HasVolume(vol.Name)
+			if err != nil {
+				return errors.Wrapf(err, "error checking if volume %s exists", vol.Name)
+			}
+			if exists {
+				continue
+			}
+			if err := r.addPluginVolume(volPlugin, vol.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addPluginVolume adds a volume which already exists in the given volume
+// plugin to the state.
+func (r *Runtime) addPluginVolume(volPlugin *plugin.VolumePlugin, name string) (Err error) {
+	volume, err := newVolume(r)
+	if err != nil {
+		return errors.Wrapf(err, "error creating volume")
+	}
+	volume.config.Name = name
+	volume.config.Driver = volPlugin.Name
+
+	caps, err := volPlugin.Capabilities()
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving capabilities of volume plugin %s", volPlugin.Name)
+	}
+	volume.config.Scope = caps.Scope
+
+	lock, err := r.lockManager.AllocateLock()
+	if err != nil {
+		return errors.Wrapf(err, "error allocating lock for volume %s", name)
+	}
+	volume.lock = lock
+	volume.config.LockID = volume.lock.ID()
+	defer func() {
+		if Err != nil {
+			if err := volume.lock.Free(); err != nil {
+				logrus.Errorf("Error freeing volume lock after failing to add volume %s: %v", name, err)
+			}
+		}
+	}()
+
+	volume.valid = true
 	if err := r.state.AddVolume(volume); err != nil {
-		return nil, errors.Wrapf(err, "error adding volume to state")
+		return errors.Wrapf(err, "error adding volume %s of plugin %s to state", name, volPlugin.Name)
 	}
-	defer volume.newVolumeEvent(events.Create)
-	return volume, nil
+	logrus.Debugf("Added volume %s of plugin %s", name, volPlugin.Name)
+	return nil
+}
+
+// removePluginVolume removes a volume from the volume plugin managing it.
+func (r *Runtime) removePluginVolume(volume *Volume) error {
+	volPlugin, err := volume.plugin()
+	if err != nil {
+		return err
+	}
+	if err := volPlugin.RemoveVolume(volume.Name()); err != nil {
+		return errors.Wrapf(err, "error removing volume %s using plugin %s", volume.Name(), volPlugin.Name)
+	}
+	return nil
 }
 
 // removeVolume removes the specified volume from state as well tears down its mountpoint and storage
@@ -123,6 +294,20 @@ func (r *Runtime) removeVolume(ctx context.Context, v *Volume, force bool) error
 		}
 	}
 
+	// Never delete the storage of a volume that is still mounted, it may
+	// be an arbitrary host path
+	if err := v.unmountForRemoval(); err != nil {
+		return err
+	}
+
+	// Plugin volumes are removed from the plugin first, so a failure leaves
+	// the volume in the state where the removal can be retried
+	if v.usesPlugin() {
+		if err := r.removePluginVolume(v); err != nil {
+			return err
+		}
+	}
+
 	// Set volume as invalid so it can no longer be used
 	v.valid = false
 
@@ -136,7 +321,42 @@ func (r *Runtime) removeVolume(ctx context.Context, v *Volume, force bool) error
 		return errors.Wrapf(err, "error cleaning up volume storage for %q", v.Name())
 	}
 
+	// Free the volume's lock, volumes created before locks were assigned
+	// to them have none
+	if v.lock != nil {
+		if err := v.lock.Free(); err != nil {
+			logrus.Errorf("Error freeing volume %s lock: %v", v.Name(), err)
+		}
+	}
+
 	defer v.newVolumeEvent(events.Remove)
 	logrus.Debugf("Removed volume %s", v.Name())
 	return nil
 }
+
+// unmountForRemoval unmounts a local volume with mount options that is left
+// mounted on the host, for example because a container using it crashed.
+func (v *Volume) unmountForRemoval() error {
+	if !v.hasMountOptions() {
+		return nil
+	}
+
+	mounted, err := mount.Mounted(v.config.MountPoint)
+	if err != nil {
+		return errors.Wrapf(err, "unable to determine if volume %s is mounted", v.Name())
+	}
+	if !mounted {
+		return nil
+	}
+
+	if err := v.checkLock(); err != nil {
+		return err
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.unmountLocal(); err != nil {
+		return errors.Wrapf(err, "error unmounting volume %s before removal", v.Name())
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// +build linux
+
+package libpod
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/libpod/libpod/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveVolumeWithoutLock(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", tmpDirPrefix)
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	state, err := NewInMemoryState()
+	require.NoError(t, err)
+
+	runtime := new(Runtime)
+	runtime.config = new(RuntimeConfig)
+	runtime.config.VolumePath = tmpDir
+	runtime.state = state
+	runtime.eventer = events.NewNullEventer()
+
+	for _, options := range []map[string]string{
+		{},
+		{"type": "tmpfs", "device": "tmpfs"},
+	} {
+		// Volumes created by older versions have no lock
+		volume, err := newVolume(runtime)
+		require.NoError(t, err)
+		volume.config.Name = "vol" + options["type"]
+		volume.config.Options = options
+		volume.config.MountPoint = filepath.Join(tmpDir, volume.config.Name, "_data")
+		volume.valid = true
+		require.NoError(t, os.MkdirAll(volume.config.MountPoint, 0755))
+		require.NoError(t, state.AddVolume(volume))
+
+		assert.NoError(t, runtime.removeVolume(context.Background(), volume, false))
+
+		exists, err := state.HasVolume(volume.Name())
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		_, err = os.Stat(filepath.Join(tmpDir, volume.Name()))
+		assert.True(t, os.IsNotExist(err))
+	}
+}
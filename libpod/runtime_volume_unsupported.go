@@ -19,3 +19,7 @@ func (r *Runtime) newVolume(ctx context.Context, options ...VolumeCreateOption)
 func (r *Runtime) NewVolume(ctx context.Context, options ...VolumeCreateOption) (*Volume, error) {
 	return nil, define.ErrNotImplemented
 }
+
+func (r *Runtime) AddPluginVolumes() error {
+	return define.ErrNotImplemented
+}
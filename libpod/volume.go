@@ -1,12 +1,21 @@
 package libpod
 
+import (
+	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/libpod/events"
+	"github.com/containers/libpod/libpod/lock"
+	"github.com/sirupsen/logrus"
+)
+
 // Volume is the type used to create named volumes
 // TODO: all volumes should be created using this and the Volume API
 type Volume struct {
 	config *VolumeConfig
+	state  *VolumeState
 
 	valid   bool
 	runtime *Runtime
+	lock    lock.Locker
 }
 
 // VolumeConfig holds the volume's config information
@@ -22,6 +31,21 @@ type VolumeConfig struct {
 	IsCtrSpecific bool              `json:"ctrSpecific"`
 	UID           int               `json:"uid"`
 	GID           int               `json:"gid"`
+	// LockID is the ID of the volume's lock. Volumes created by older
+	// versions of libpod have none until podman system migrate is run.
+	LockID uint32 `json:"lockID"`
+}
+
+// VolumeState holds the volume's mutable state.
+type VolumeState struct {
+	// MountCount is the number of containers presently using the volume.
+	// It is incremented on mount() and decremented on unmount(). Volumes
+	// with mount options are mounted on the host when it goes from 0 to 1,
+	// and unmounted when it drops back to 0.
+	MountCount uint `json:"mountCount"`
+	// MountPoint is the path the volume is mounted at by its plugin.
+	// Only set for volumes managed by a plugin, while they are mounted.
+	MountPoint string `json:"mountPoint,omitempty"`
 }
 
 // Name retrieves the volume's name
@@ -38,9 +62,19 @@ func (v *Volume) Labels() map[string]string {
 	return labels
 }
 
-// MountPoint returns the volume's mountpoint on the host
+// MountPoint returns the volume's mountpoint on the host. The mountpoint of a
+// volume managed by a plugin is queried from the plugin, and is usually only
+// set while the volume is mounted.
 func (v *Volume) MountPoint() string {
-	return v.config.MountPoint
+	if !v.usesPlugin() {
+		return v.config.MountPoint
+	}
+	mountPoint, err := v.mountPoint()
+	if err != nil {
+		logrus.Errorf("Error retrieving mountpoint of volume %s: %v", v.Name(), err)
+		return ""
+	}
+	return mountPoint
 }
 
 // Driver returns the volume's driver
@@ -69,3 +103,31 @@ func (v *Volume) Scope() string {
 func (v *Volume) IsCtrSpecific() bool {
 	return v.config.IsCtrSpecific
 }
+
+// Export writes the contents of the volume to a tar archive at the given path.
+// Ownership and extended attributes of the files are preserved. Volumes that
+// must be mounted are mounted for the duration of the export.
+func (v *Volume) Export(path string) error {
+	if !v.valid {
+		return define.ErrVolumeRemoved
+	}
+
+	defer v.newVolumeEvent(events.Export)
+	return v.withMountPoint(func(mountPoint string) error {
+		return v.export(mountPoint, path)
+	})
+}
+
+// Import extracts the tar archive at the given path into the volume. Files
+// are added to the existing contents of the volume, replacing files with the
+// same name.
+func (v *Volume) Import(path string) error {
+	if !v.valid {
+		return define.ErrVolumeRemoved
+	}
+
+	defer v.newVolumeEvent(events.Import)
+	return v.withMountPoint(func(mountPoint string) error {
+		return v.importArchive(mountPoint, path)
+	})
+}
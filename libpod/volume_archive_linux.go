@@ -0,0 +1,153 @@
+// +build linux
+
+package libpod
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/containers/storage/pkg/archive"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// xattrSELinux is not exported, the volume is relabeled when it is used by a
+// container on the importing host.
+const xattrSELinux = "security.selinux"
+
+// archiveOptions returns the tar options to export or import the volume with,
+// mapping file ownership through the runtime's ID mappings.
+func (v *Volume) archiveOptions() *archive.TarOptions {
+	return &archive.TarOptions{
+		Compression: archive.Uncompressed,
+		UIDMaps:     v.runtime.config.StorageConfig.UIDMap,
+		GIDMaps:     v.runtime.config.StorageConfig.GIDMap,
+		InUserNS:    rootless.IsRootless(),
+	}
+}
+
+// export writes the contents of the volume mounted at mountPoint to a tar
+// archive at path.
+func (v *Volume) export(mountPoint, path string) error {
+	input, err := archive.TarWithOptions(mountPoint, v.archiveOptions())
+	if err != nil {
+		return errors.Wrapf(err, "error reading volume %s", v.Name())
+	}
+	defer input.Close()
+
+	outFile, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "error creating file %q", path)
+	}
+	defer outFile.Close()
+
+	if err := copyTarWithXattrs(mountPoint, input, outFile); err != nil {
+		return errors.Wrapf(err, "error exporting volume %s", v.Name())
+	}
+	return nil
+}
+
+// importArchive extracts the tar archive at path into the volume mounted at
+// mountPoint.
+func (v *Volume) importArchive(mountPoint, path string) error {
+	inFile, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "error opening file %q", path)
+	}
+	defer inFile.Close()
+
+	if err := archive.Untar(inFile, mountPoint, v.archiveOptions()); err != nil {
+		return errors.Wrapf(err, "error importing into volume %s", v.Name())
+	}
+
+	// The root of the volume is not part of the archive, keep the ownership
+	// the volume was created with
+	if !v.usesPlugin() {
+		if err := os.Chown(mountPoint, v.config.UID, v.config.GID); err != nil {
+			return errors.Wrapf(err, "error chowning volume %s to %d:%d", v.Name(), v.config.UID, v.config.GID)
+		}
+	}
+	return nil
+}
+
+// copyTarWithXattrs copies the tar stream in to out, adding the extended
+// attributes of the files under root to their headers. The archive package
+// only records file capabilities on its own.
+func copyTarWithXattrs(root string, in io.Reader, out io.Writer) error {
+	tr := tar.NewReader(in)
+	tw := tar.NewWriter(out)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		xattrs, err := readXattrs(filepath.Join(root, hdr.Name))
+		if err != nil {
+			return err
+		}
+		if len(xattrs) > 0 {
+			if hdr.Xattrs == nil {
+				hdr.Xattrs = make(map[string]string)
+			}
+			for key, value := range xattrs {
+				hdr.Xattrs[key] = value
+			}
+			// Only PAX headers can hold extended attributes
+			hdr.Format = tar.FormatPAX
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// readXattrs returns the extended attributes of path, without following
+// symlinks. Filesystems without xattr support have none.
+func readXattrs(path string) (map[string]string, error) {
+	size, err := unix.Llistxattr(path, nil)
+	if err != nil {
+		if err == unix.ENOTSUP {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "error listing extended attributes of %s", path)
+	}
+	if size == 0 {
+		return nil, nil
+	}
+	buf := make([]byte, size)
+	size, err = unix.Llistxattr(path, buf)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing extended attributes of %s", path)
+	}
+
+	xattrs := make(map[string]string)
+	for _, name := range strings.Split(string(buf[:size]), "\x00") {
+		if name == "" || name == xattrSELinux {
+			continue
+		}
+		valueSize, err := unix.Lgetxattr(path, name, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading extended attribute %s of %s", name, path)
+		}
+		value := make([]byte, valueSize)
+		valueSize, err = unix.Lgetxattr(path, name, value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading extended attribute %s of %s", name, path)
+		}
+		xattrs[name] = string(value[:valueSize])
+	}
+	return xattrs, nil
+}
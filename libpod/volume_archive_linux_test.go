@@ -0,0 +1,59 @@
+// +build linux
+
+package libpod
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/storage/pkg/archive"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestCopyTarWithXattrs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "volume-archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, ioutil.WriteFile(file, []byte("content"), 0644))
+	if err := unix.Lsetxattr(file, "user.volume", []byte("value"), 0); err != nil {
+		t.Skipf("filesystem does not support user xattrs: %v", err)
+	}
+	// Labels are never exported, whether or not the host supports them
+	_ = unix.Lsetxattr(file, xattrSELinux, []byte("system_u:object_r:container_file_t:s0"), 0)
+
+	input, err := archive.Tar(dir, archive.Uncompressed)
+	require.NoError(t, err)
+	defer input.Close()
+
+	out := new(bytes.Buffer)
+	require.NoError(t, copyTarWithXattrs(dir, input, out))
+
+	tr := tar.NewReader(out)
+	found := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if hdr.Name != "file" {
+			continue
+		}
+		found = true
+		assert.Equal(t, "value", hdr.Xattrs["user.volume"])
+		assert.NotContains(t, hdr.Xattrs, xattrSELinux)
+		content, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(content))
+	}
+	assert.True(t, found)
+}
@@ -3,12 +3,32 @@ package libpod
 import (
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/libpod/plugin"
+	"github.com/containers/storage/pkg/stringid"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Options understood by the local volume driver
+const (
+	// volumeOptionType is the filesystem type to mount, as in mount -t
+	volumeOptionType = "type"
+	// volumeOptionDevice is the device, or host path for binds, to mount
+	volumeOptionDevice = "device"
+	// volumeOptionOptions are the mount options, as in mount -o
+	volumeOptionOptions = "o"
+	// volumeOptionSize is the size limit of the volume directory
+	volumeOptionSize = "size"
 )
 
 // Creates a new volume
 func newVolume(runtime *Runtime) (*Volume, error) {
 	volume := new(Volume)
 	volume.config = new(VolumeConfig)
+	volume.state = new(VolumeState)
 	volume.runtime = runtime
 	volume.config.Labels = make(map[string]string)
 	volume.config.Options = make(map[string]string)
@@ -18,5 +38,252 @@ func newVolume(runtime *Runtime) (*Volume, error) {
 
 // teardownStorage deletes the volume from volumePath
 func (v *Volume) teardownStorage() error {
+	// Plugins own the storage of their volumes
+	if v.usesPlugin() {
+		return nil
+	}
 	return os.RemoveAll(filepath.Join(v.runtime.config.VolumePath, v.Name()))
 }
+
+// usesPlugin returns whether the volume is managed by a volume plugin instead
+// of the local driver.
+func (v *Volume) usesPlugin() bool {
+	return v.config.Driver != "" && v.config.Driver != define.VolumeDriverLocal
+}
+
+// plugin retrieves the volume plugin managing the volume.
+func (v *Volume) plugin() (*plugin.VolumePlugin, error) {
+	volPlugin, err := plugin.GetVolumePlugin(v.config.Driver, v.runtime.config.VolumePluginDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving plugin %s for volume %s", v.config.Driver, v.Name())
+	}
+	return volPlugin, nil
+}
+
+// mountPoint returns the path the volume can be found at on the host.
+// For volumes managed by a plugin, the path is only valid while the volume is
+// mounted.
+func (v *Volume) mountPoint() (string, error) {
+	if !v.usesPlugin() {
+		return v.config.MountPoint, nil
+	}
+	if v.state.MountPoint != "" {
+		return v.state.MountPoint, nil
+	}
+
+	volPlugin, err := v.plugin()
+	if err != nil {
+		return "", err
+	}
+	path, err := volPlugin.GetVolumePath(v.Name())
+	if err != nil {
+		return "", errors.Wrapf(err, "error retrieving mountpoint of volume %s", v.Name())
+	}
+	return path, nil
+}
+
+// hasMountOptions returns whether the volume is a local volume that must be
+// mounted on the host, rather than a plain directory.
+func (v *Volume) hasMountOptions() bool {
+	if v.usesPlugin() {
+		return false
+	}
+	_, hasType := v.config.Options[volumeOptionType]
+	return hasType
+}
+
+// needsMount returns whether the volume must be mounted before a container
+// can use it.
+func (v *Volume) needsMount() bool {
+	return v.usesPlugin() || v.hasMountOptions()
+}
+
+// update the volume's state from the database.
+func (v *Volume) update() error {
+	return v.runtime.state.UpdateVolume(v)
+}
+
+// save the volume's state to the database.
+func (v *Volume) save() error {
+	return v.runtime.state.SaveVolume(v)
+}
+
+// refresh the volume after a reboot, picking up a new lock.
+// Like the container and pod equivalents, this must not take any locks.
+func (v *Volume) refresh() error {
+	if v.lock == nil {
+		// Not assigned a lock yet, podman system migrate will
+		// allocate one
+		return nil
+	}
+
+	lock, err := v.runtime.lockManager.AllocateAndRetrieveLock(v.config.LockID)
+	if err != nil {
+		return errors.Wrapf(err, "error acquiring lock %d for volume %s", v.config.LockID, v.Name())
+	}
+	v.lock = lock
+
+	return nil
+}
+
+// checkLock ensures the volume was assigned a lock. Volumes created by older
+// versions of libpod have none until podman system migrate is run.
+func (v *Volume) checkLock() error {
+	if v.lock == nil {
+		return errors.Wrapf(define.ErrInternal, "volume %s has no lock, run podman system migrate to assign one", v.Name())
+	}
+	return nil
+}
+
+// mount mounts the volume on behalf of the given container, if its driver
+// requires it. Local volumes with mount options are mounted on the host by the
+// first container using them, volume plugins are asked to mount the volume for
+// every container.
+func (v *Volume) mount(ctrID string) error {
+	if !v.needsMount() {
+		return nil
+	}
+
+	if err := v.checkLock(); err != nil {
+		return err
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return err
+	}
+
+	if v.usesPlugin() {
+		volPlugin, err := v.plugin()
+		if err != nil {
+			return err
+		}
+		mountPoint, err := volPlugin.MountVolume(v.Name(), ctrID)
+		if err != nil {
+			return errors.Wrapf(err, "error mounting volume %s for container %s", v.Name(), ctrID)
+		}
+		v.state.MountPoint = mountPoint
+	} else if v.state.MountCount == 0 {
+		if err := v.mountLocal(); err != nil {
+			return errors.Wrapf(err, "error mounting volume %s for container %s", v.Name(), ctrID)
+		}
+	}
+
+	v.state.MountCount++
+	logrus.Debugf("Volume %s mount count now at %d", v.Name(), v.state.MountCount)
+
+	return v.save()
+}
+
+// unmount releases the mount of the volume held by the given container.
+// Local volumes with mount options are unmounted from the host once the last
+// container using them releases them.
+func (v *Volume) unmount(ctrID string) error {
+	if !v.needsMount() {
+		return nil
+	}
+
+	if err := v.checkLock(); err != nil {
+		return err
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if err := v.update(); err != nil {
+		return err
+	}
+
+	if v.state.MountCount == 0 {
+		logrus.Debugf("Volume %s is not mounted, refusing to unmount", v.Name())
+		return nil
+	}
+	v.state.MountCount--
+	logrus.Debugf("Volume %s mount count now at %d", v.Name(), v.state.MountCount)
+
+	var unmountErr error
+	if v.usesPlugin() {
+		volPlugin, err := v.plugin()
+		if err != nil {
+			unmountErr = err
+		} else if err := volPlugin.UnmountVolume(v.Name(), ctrID); err != nil {
+			unmountErr = errors.Wrapf(err, "error unmounting volume %s for container %s", v.Name(), ctrID)
+		}
+		if v.state.MountCount == 0 {
+			v.state.MountPoint = ""
+		}
+	} else if v.state.MountCount == 0 {
+		if err := v.unmountLocal(); err != nil {
+			unmountErr = errors.Wrapf(err, "error unmounting volume %s for container %s", v.Name(), ctrID)
+		}
+	}
+
+	// Always save the new count, a failed unmount must not keep the
+	// volume mounted forever
+	if err := v.save(); err != nil {
+		if unmountErr != nil {
+			logrus.Errorf("Error unmounting volume %s: %v", v.Name(), unmountErr)
+		}
+		return err
+	}
+	return unmountErr
+}
+
+// withMountPoint calls fn with the path of the volume's contents on the host.
+// Volumes that must be mounted are mounted for the duration of the call, on
+// behalf of a one-off ID so they remain mounted for any container using them.
+func (v *Volume) withMountPoint(fn func(mountPoint string) error) error {
+	mountID := stringid.GenerateNonCryptoID()
+	if err := v.mount(mountID); err != nil {
+		return err
+	}
+	defer func() {
+		if err := v.unmount(mountID); err != nil {
+			logrus.Errorf("Error unmounting volume %s: %v", v.Name(), err)
+		}
+	}()
+
+	mountPoint, err := v.mountPoint()
+	if err != nil {
+		return err
+	}
+	return fn(mountPoint)
+}
+
+// validateLocalVolumeOptions checks the options given to a volume using the
+// local driver.
+func validateLocalVolumeOptions(options map[string]string) error {
+	for key := range options {
+		switch key {
+		case volumeOptionType, volumeOptionDevice, volumeOptionOptions, volumeOptionSize:
+		default:
+			return errors.Wrapf(define.ErrInvalidArg, "invalid option %q for the local volume driver, must be one of %s", key, strings.Join([]string{volumeOptionType, volumeOptionDevice, volumeOptionOptions, volumeOptionSize}, ", "))
+		}
+	}
+
+	if _, hasSize := options[volumeOptionSize]; hasSize {
+		if len(options) != 1 {
+			return errors.Wrapf(define.ErrInvalidArg, "the %q option cannot be combined with mount options, use %s=size=... for tmpfs", volumeOptionSize, volumeOptionOptions)
+		}
+		_, err := volumeSizeOption(options)
+		return err
+	}
+
+	mountType, hasType := options[volumeOptionType]
+	if !hasType {
+		if len(options) != 0 {
+			return errors.Wrapf(define.ErrInvalidArg, "the %q option is required when mounting a local volume", volumeOptionType)
+		}
+		return nil
+	}
+	if mountType == "" {
+		return errors.Wrapf(define.ErrInvalidArg, "the %q option of a local volume must not be empty", volumeOptionType)
+	}
+	// tmpfs has no backing device, everything else needs one
+	if mountType != "tmpfs" && options[volumeOptionDevice] == "" {
+		return errors.Wrapf(define.ErrInvalidArg, "the %q option is required when mounting a local volume of type %s", volumeOptionDevice, mountType)
+	}
+	return nil
+}
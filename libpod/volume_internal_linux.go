@@ -0,0 +1,78 @@
+// +build linux
+
+package libpod
+
+import (
+	"net"
+	"strings"
+
+	"github.com/containers/storage/pkg/mount"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// mountLocal mounts a local volume with mount options on the host, at the
+// volume's mountpoint.
+// Must be called with the volume locked.
+func (v *Volume) mountLocal() error {
+	mountType := v.config.Options[volumeOptionType]
+	device := v.config.Options[volumeOptionDevice]
+	if device == "" {
+		// Only tmpfs may omit the device, name it after the type
+		device = mountType
+	}
+
+	mountOpts := v.config.Options[volumeOptionOptions]
+	if mountType == "nfs" {
+		// There is no mount.nfs helper to resolve the server for us, the
+		// kernel requires it as an IP address
+		resolved, err := resolveNFSAddr(mountOpts)
+		if err != nil {
+			return err
+		}
+		mountOpts = resolved
+	}
+	flags, data := mount.ParseOptions(mountOpts)
+
+	logrus.Debugf("Mounting %s (type %s, options %q) at %s for volume %s", device, mountType, mountOpts, v.config.MountPoint, v.Name())
+	if err := unix.Mount(device, v.config.MountPoint, mountType, uintptr(flags), data); err != nil {
+		return errors.Wrapf(err, "error mounting %s at %s", device, v.config.MountPoint)
+	}
+
+	logrus.Debugf("Mounted volume %s at %s", v.Name(), v.config.MountPoint)
+	return nil
+}
+
+// resolveNFSAddr replaces the host given by the addr option of an NFS mount
+// with its IP address.
+func resolveNFSAddr(mountOpts string) (string, error) {
+	opts := strings.Split(mountOpts, ",")
+	for i, opt := range opts {
+		if !strings.HasPrefix(opt, "addr=") {
+			continue
+		}
+		addr, err := net.ResolveIPAddr("ip", strings.TrimPrefix(opt, "addr="))
+		if err != nil {
+			return "", errors.Wrapf(err, "error resolving NFS server address %q", strings.TrimPrefix(opt, "addr="))
+		}
+		opts[i] = "addr=" + addr.String()
+	}
+	return strings.Join(opts, ","), nil
+}
+
+// unmountLocal unmounts a local volume with mount options from the host.
+// Must be called with the volume locked.
+func (v *Volume) unmountLocal() error {
+	if err := unix.Unmount(v.config.MountPoint, 0); err != nil {
+		if err == unix.EINVAL {
+			// Not mounted, nothing to do
+			logrus.Debugf("Volume %s is not mounted at %s", v.Name(), v.config.MountPoint)
+			return nil
+		}
+		return errors.Wrapf(err, "error unmounting %s", v.config.MountPoint)
+	}
+
+	logrus.Debugf("Unmounted volume %s from %s", v.Name(), v.config.MountPoint)
+	return nil
+}
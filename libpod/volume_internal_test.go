@@ -0,0 +1,44 @@
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateLocalVolumeOptions(t *testing.T) {
+	assert.NoError(t, validateLocalVolumeOptions(map[string]string{}))
+	assert.NoError(t, validateLocalVolumeOptions(map[string]string{"type": "tmpfs", "o": "size=64m"}))
+	assert.NoError(t, validateLocalVolumeOptions(map[string]string{"type": "none", "o": "bind", "device": "/srv"}))
+	assert.NoError(t, validateLocalVolumeOptions(map[string]string{"type": "ext4", "device": "/dev/sdb1"}))
+
+	assert.Error(t, validateLocalVolumeOptions(map[string]string{"foo": "bar"}))
+	assert.Error(t, validateLocalVolumeOptions(map[string]string{"o": "size=64m"}))
+	assert.Error(t, validateLocalVolumeOptions(map[string]string{"type": ""}))
+	assert.Error(t, validateLocalVolumeOptions(map[string]string{"type": "xfs"}))
+}
+
+func TestValidateLocalVolumeSize(t *testing.T) {
+	assert.NoError(t, validateLocalVolumeOptions(map[string]string{"size": "10G"}))
+
+	assert.Error(t, validateLocalVolumeOptions(map[string]string{"size": "foo"}))
+	assert.Error(t, validateLocalVolumeOptions(map[string]string{"size": "0"}))
+	assert.Error(t, validateLocalVolumeOptions(map[string]string{"size": "10G", "type": "tmpfs"}))
+
+	size, err := volumeSizeOption(map[string]string{"size": "512m"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(512*1024*1024), size)
+}
+
+func TestVolumeConfigHasLock(t *testing.T) {
+	hasLock, err := volumeConfigHasLock([]byte(`{"name":"vol","lockID":0}`))
+	assert.NoError(t, err)
+	assert.True(t, hasLock)
+
+	hasLock, err = volumeConfigHasLock([]byte(`{"name":"vol"}`))
+	assert.NoError(t, err)
+	assert.False(t, hasLock)
+
+	_, err = volumeConfigHasLock([]byte(`not json`))
+	assert.Error(t, err)
+}
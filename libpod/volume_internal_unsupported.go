@@ -0,0 +1,23 @@
+// +build !linux
+
+package libpod
+
+import (
+	"github.com/containers/libpod/libpod/define"
+)
+
+func (v *Volume) mountLocal() error {
+	return define.ErrOSNotSupported
+}
+
+func (v *Volume) unmountLocal() error {
+	return define.ErrOSNotSupported
+}
+
+func (v *Volume) export(mountPoint, path string) error {
+	return define.ErrOSNotSupported
+}
+
+func (v *Volume) importArchive(mountPoint, path string) error {
+	return define.ErrOSNotSupported
+}
@@ -0,0 +1,86 @@
+package libpod
+
+import (
+	"path/filepath"
+
+	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/pkg/rootless"
+	"github.com/containers/storage"
+	"github.com/containers/storage/drivers/quota"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// VolumeQuota is the size limit placed on a volume and the space it
+// presently uses, both in bytes. Used is nil if the space used by the volume
+// could not be determined.
+type VolumeQuota struct {
+	Size uint64  `json:"size"`
+	Used *uint64 `json:"used,omitempty"`
+}
+
+// volumeSizeOption parses the size option of a volume using the local
+// driver. It returns 0 if the volume has no size limit.
+func volumeSizeOption(options map[string]string) (uint64, error) {
+	size, ok := options[volumeOptionSize]
+	if !ok {
+		return 0, nil
+	}
+	bytes, err := units.RAMInBytes(size)
+	if err != nil {
+		return 0, errors.Wrapf(define.ErrInvalidArg, "invalid volume size %q: %v", size, err)
+	}
+	if bytes <= 0 {
+		return 0, errors.Wrapf(define.ErrInvalidArg, "invalid volume size %q: must be greater than 0", size)
+	}
+	return uint64(bytes), nil
+}
+
+// setVolumeQuota places a project quota of the given size on the directory
+// of a volume. The volume path must live on XFS, or on ext4 mounted with
+// project quotas enabled.
+func setVolumeQuota(volumePath, volDir string, size uint64) error {
+	if rootless.IsRootless() {
+		return errors.New("quotas are not supported rootless")
+	}
+
+	// The quota control hands out the project ID following the highest one
+	// found on the volume directories, serialize with other processes
+	// creating volumes so they do not pick the same one
+	lock, err := storage.GetLockfile(filepath.Join(volumePath, "quota.lock"))
+	if err != nil {
+		return errors.Wrapf(err, "error acquiring volume quota lock")
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	control, err := quota.NewControl(volumePath)
+	if err != nil {
+		return errors.Wrapf(err, "the filesystem backing %s does not support project quotas, volume sizes require XFS or ext4 mounted with the prjquota option", volumePath)
+	}
+	if err := control.SetQuota(volDir, quota.Quota{Size: size}); err != nil {
+		return errors.Wrapf(err, "error setting quota of %s on %s", units.BytesSize(float64(size)), volDir)
+	}
+	return nil
+}
+
+// Quota returns the size limit of the volume and the space it uses. It
+// returns nil if no size limit was placed on the volume. A failure to
+// determine the space used is only logged, so a single volume does not
+// prevent listing the others.
+func (v *Volume) Quota() (*VolumeQuota, error) {
+	size, err := volumeSizeOption(v.config.Options)
+	if err != nil || size == 0 || v.usesPlugin() {
+		return nil, err
+	}
+
+	quota := &VolumeQuota{Size: size}
+	used, err := getVolumeDiskUsage(v.runtime.config.VolumePath, filepath.Join(v.runtime.config.VolumePath, v.Name()))
+	if err != nil {
+		logrus.Errorf("Error computing usage of volume %s: %v", v.Name(), err)
+		return quota, nil
+	}
+	quota.Used = &used
+	return quota, nil
+}
@@ -0,0 +1,73 @@
+// +build linux,cgo
+
+package libpod
+
+/*
+#include <stdlib.h>
+#include <linux/fs.h>
+#include <linux/quota.h>
+#include <linux/dqblk_xfs.h>
+
+#ifndef FS_IOC_FSGETXATTR
+struct fsxattr {
+	__u32		fsx_xflags;
+	__u32		fsx_extsize;
+	__u32		fsx_nextents;
+	__u32		fsx_projid;
+	unsigned char	fsx_pad[12];
+};
+#define FS_IOC_FSGETXATTR		_IOR ('X', 31, struct fsxattr)
+#endif
+
+#ifndef PRJQUOTA
+#define PRJQUOTA	2
+#endif
+#ifndef Q_XGETPQUOTA
+#define Q_XGETPQUOTA QCMD(Q_XGETQUOTA, PRJQUOTA)
+#endif
+*/
+import "C"
+import (
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"github.com/containers/storage/drivers/quota"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// getVolumeDiskUsage returns the space used by a volume directory with a
+// project quota, as accounted by the filesystem for the directory's project.
+func getVolumeDiskUsage(volumePath, volDir string) (uint64, error) {
+	// The quota control creates the block device node quotactl is issued
+	// against, it only has to be set up again if it went missing
+	backingFsBlockDev := filepath.Join(volumePath, "backingFsBlockDev")
+	if _, err := os.Stat(backingFsBlockDev); os.IsNotExist(err) {
+		if _, err := quota.NewControl(volumePath); err != nil {
+			return 0, errors.Wrapf(err, "error setting up project quotas on %s", volumePath)
+		}
+	}
+
+	dir, err := os.Open(volDir)
+	if err != nil {
+		return 0, err
+	}
+	defer dir.Close()
+
+	var fsx C.struct_fsxattr
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, dir.Fd(), C.FS_IOC_FSGETXATTR, uintptr(unsafe.Pointer(&fsx))); errno != 0 {
+		return 0, errors.Wrapf(errno, "error getting project ID of %s", volDir)
+	}
+
+	cs := C.CString(backingFsBlockDev)
+	defer C.free(unsafe.Pointer(cs))
+
+	var d C.fs_disk_quota_t
+	if _, _, errno := unix.Syscall6(unix.SYS_QUOTACTL, C.Q_XGETPQUOTA, uintptr(unsafe.Pointer(cs)), uintptr(fsx.fsx_projid), uintptr(unsafe.Pointer(&d)), 0, 0); errno != 0 {
+		return 0, errors.Wrapf(errno, "error getting disk usage of project %d on %s", fsx.fsx_projid, backingFsBlockDev)
+	}
+
+	// Usage is accounted in 512 byte blocks
+	return uint64(d.d_bcount) * 512, nil
+}
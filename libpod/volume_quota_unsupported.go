@@ -0,0 +1,12 @@
+// +build !linux !cgo
+
+package libpod
+
+import (
+	"github.com/containers/libpod/libpod/define"
+	"github.com/pkg/errors"
+)
+
+func getVolumeDiskUsage(volumePath, volDir string) (uint64, error) {
+	return 0, errors.Wrapf(define.ErrOSNotSupported, "volume disk usage requires project quota support")
+}
@@ -0,0 +1,17 @@
+// +build !remoteclient
+
+package adapter
+
+import (
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/pkg/autoupdate"
+)
+
+// AutoUpdate updates the images of containers with an auto-update policy and
+// restarts their systemd units
+func (r *LocalRuntime) AutoUpdate(cli *cliconfig.AutoUpdateValues) ([]autoupdate.Report, []error) {
+	return autoupdate.AutoUpdate(r.Runtime, autoupdate.Options{
+		Authfile: cli.Authfile,
+		DryRun:   cli.DryRun,
+	})
+}
@@ -26,7 +26,6 @@ import (
 	"github.com/containers/libpod/libpod/image"
 	"github.com/containers/libpod/libpod/logs"
 	"github.com/containers/libpod/pkg/adapter/shortcuts"
-	"github.com/containers/libpod/pkg/systemdgen"
 	"github.com/containers/psgo"
 	"github.com/containers/storage"
 	"github.com/pkg/errors"
@@ -64,6 +63,39 @@ func (r *LocalRuntime) LookupContainer(idOrName string) (*Container, error) {
 	return &Container{ctr}, nil
 }
 
+// containerNamesAndCIDFiles returns the given container names together with
+// the container IDs read from the given files.  If ignore is set, containers
+// that do not exist and files that are missing are skipped.
+func (r *LocalRuntime) containerNamesAndCIDFiles(names, cidFiles []string, ignore bool) ([]string, error) {
+	for _, cidFile := range cidFiles {
+		content, err := ioutil.ReadFile(cidFile)
+		if err != nil {
+			if ignore && os.IsNotExist(err) {
+				logrus.Debugf("Ignoring missing CID file %s", cidFile)
+				continue
+			}
+			return nil, errors.Wrapf(err, "error reading CIDFile %s", cidFile)
+		}
+		id := strings.Split(string(content), "\n")[0]
+		names = append(names, id)
+	}
+	if !ignore {
+		return names, nil
+	}
+	var existing []string
+	for _, name := range names {
+		if _, err := r.LookupContainer(name); err != nil {
+			if errors.Cause(err) == define.ErrNoSuchCtr {
+				logrus.Debugf("Ignoring missing container %s", name)
+				continue
+			}
+			return nil, err
+		}
+		existing = append(existing, name)
+	}
+	return existing, nil
+}
+
 // StopContainers stops container(s) based on CLI inputs.
 // Returns list of successful id(s), map of failed id(s) + error, or error not from container
 func (r *LocalRuntime) StopContainers(ctx context.Context, cli *cliconfig.StopValues) ([]string, map[string]error, error) {
@@ -79,7 +111,11 @@ func (r *LocalRuntime) StopContainers(ctx context.Context, cli *cliconfig.StopVa
 	}
 	logrus.Debugf("Setting maximum stop workers to %d", maxWorkers)
 
-	ctrs, err := shortcuts.GetContainersByContext(cli.All, cli.Latest, cli.InputArgs, r.Runtime)
+	names, err := r.containerNamesAndCIDFiles(cli.InputArgs, cli.CIDFiles, cli.Ignore)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctrs, err := shortcuts.GetContainersByContext(cli.All, cli.Latest, names, r.Runtime)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -203,7 +239,11 @@ func (r *LocalRuntime) RemoveContainers(ctx context.Context, cli *cliconfig.RmVa
 		return ok, failures, nil
 	}
 
-	ctrs, err := shortcuts.GetContainersByContext(cli.All, cli.Latest, cli.InputArgs, r.Runtime)
+	names, err := r.containerNamesAndCIDFiles(cli.InputArgs, cli.CIDFiles, cli.Ignore)
+	if err != nil {
+		return ok, failures, err
+	}
+	ctrs, err := shortcuts.GetContainersByContext(cli.All, cli.Latest, names, r.Runtime)
 	if err != nil {
 		return ok, failures, err
 	}
@@ -1092,28 +1132,16 @@ func (r *LocalRuntime) Port(c *cliconfig.PortValues) ([]*Container, error) {
 	return portContainers, nil
 }
 
-// GenerateSystemd creates a unit file for a container
+// GenerateSystemd creates systemd units for a container or a pod and its
+// containers
 func (r *LocalRuntime) GenerateSystemd(c *cliconfig.GenerateSystemdValues) (string, error) {
-	ctr, err := r.Runtime.LookupContainer(c.InputArgs[0])
-	if err != nil {
-		return "", err
-	}
-	timeout := int(ctr.StopTimeout())
-	if c.StopTimeout >= 0 {
-		timeout = c.StopTimeout
-	}
-	name := ctr.ID()
-	if c.Name {
-		name = ctr.Name()
-	}
-
-	config := ctr.Config()
-	conmonPidFile := config.ConmonPidFile
-	if conmonPidFile == "" {
-		return "", errors.Errorf("conmon PID file path is empty, try to recreate the container with --conmon-pidfile flag")
-	}
-
-	return systemdgen.CreateSystemdUnitAsString(name, ctr.ID(), c.RestartPolicy, conmonPidFile, timeout)
+	return shared.GenerateSystemd(r.Runtime, c.InputArgs[0], shared.GenerateSystemdOptions{
+		Files:         c.Files,
+		Name:          c.Name,
+		New:           c.New,
+		RestartPolicy: c.RestartPolicy,
+		StopTimeout:   c.StopTimeout,
+	})
 }
 
 // GetNamespaces returns namespace information about a container for PS
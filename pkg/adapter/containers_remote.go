@@ -417,13 +417,23 @@ func BatchContainerOp(ctr *Container, opts shared.PsOptions) (shared.BatchContai
 // Log one or more containers over a varlink connection
 func (r *LocalRuntime) Log(c *cliconfig.LogsValues, options *logs.LogOptions) error {
 	// GetContainersLogs
-	reply, err := iopodman.GetContainersLogs().Send(r.Conn, uint64(varlink.More), c.InputArgs, c.Follow, c.Latest, options.Since.Format(time.RFC3339Nano), int64(c.Tail), c.Timestamps)
+	var until string
+	if !options.Until.IsZero() {
+		until = options.Until.Format(time.RFC3339Nano)
+	}
+	reply, err := iopodman.GetContainersLogs().Send(r.Conn, uint64(varlink.More), c.InputArgs, c.Follow, c.Latest, options.Since.Format(time.RFC3339Nano), int64(c.Tail), c.Timestamps, until, options.Stream)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get container logs")
 	}
 	if len(c.InputArgs) > 1 {
 		options.Multi = true
 	}
+	return printLogReplies(reply, options)
+}
+
+// printLogReplies prints the log lines replied by a varlink logs endpoint
+// until the end of the stream
+func printLogReplies(reply func() (iopodman.LogLine, uint64, error), options *logs.LogOptions) error {
 	for {
 		log, flags, err := reply()
 		if err != nil {
@@ -443,6 +453,7 @@ func (r *LocalRuntime) Log(c *cliconfig.LogsValues, options *logs.LogOptions) er
 			Time:         lTime,
 			Msg:          log.Msg,
 			CID:          log.Cid,
+			CName:        log.Cname,
 		}
 		fmt.Println(logLine.String(options))
 		if flags&varlink.Continues == 0 {
@@ -949,8 +960,15 @@ func (r *LocalRuntime) Port(c *cliconfig.PortValues) ([]*Container, error) {
 	return containers, nil
 }
 
-// GenerateSystemd creates a systemd until for a container
+// GenerateSystemd creates systemd units for a container or a pod and its
+// containers
 func (r *LocalRuntime) GenerateSystemd(c *cliconfig.GenerateSystemdValues) (string, error) {
+	if c.Files {
+		return "", errors.New("generating files is not supported by the remote client")
+	}
+	if c.New {
+		return "", errors.New("creating new containers is not supported by the remote client")
+	}
 	return iopodman.GenerateSystemd().Call(r.Conn, c.InputArgs[0], c.RestartPolicy, int64(c.StopTimeout), c.Name)
 }
 
@@ -0,0 +1,481 @@
+// +build !remoteclient
+
+package adapter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/containers/buildah/pkg/parse"
+	"github.com/containers/libpod/libpod"
+	"github.com/containers/libpod/libpod/define"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// kubeKindPod is the kind of a kubernetes pod
+	kubeKindPod = "Pod"
+	// kubeKindDeployment is the kind of a kubernetes deployment
+	kubeKindDeployment = "Deployment"
+	// kubeKindConfigMap is the kind of a kubernetes configmap
+	kubeKindConfigMap = "ConfigMap"
+	// kubeKindSecret is the kind of a kubernetes secret
+	kubeKindSecret = "Secret"
+	// kubeKindPersistentVolumeClaim is the kind of a kubernetes persistent
+	// volume claim
+	kubeKindPersistentVolumeClaim = "PersistentVolumeClaim"
+	// kubeVolumeLabel marks the volumes created by play kube
+	kubeVolumeLabel = "io.podman.play.kube"
+	// kubeVolumeFilePermission is the default mode of files created from
+	// configmap and secret volumes
+	kubeVolumeFilePermission = 0644
+)
+
+// kubeDocument is a single document of a kube YAML file along with its kind
+type kubeDocument struct {
+	kind    string
+	content []byte
+}
+
+// kubePod is a pod to be created from a kube YAML file
+type kubePod struct {
+	name string
+	spec *v1.PodSpec
+}
+
+// newKubePod returns the pod to create from spec.  The name of the pod is
+// changed if it collides with the name of one of its containers.
+func newKubePod(name string, spec *v1.PodSpec) kubePod {
+	for _, n := range spec.Containers {
+		if n.Name == name {
+			fmt.Printf("a container exists with the same name (%s) as the pod in your YAML file; changing pod name to %s_pod\n", name, name)
+			name = fmt.Sprintf("%s_pod", name)
+		}
+	}
+	return kubePod{name: name, spec: spec}
+}
+
+// kubePodVolumeNames returns the names of the libpod volumes backing the
+// claim, configmap and secret volumes of a kube pod
+func kubePodVolumeNames(pod kubePod, resources *kubeResources) []string {
+	var names []string
+	for _, volume := range pod.spec.Volumes {
+		switch {
+		case volume.VolumeSource.PersistentVolumeClaim != nil:
+			names = append(names, kubeClaimVolumeName(volume.VolumeSource.PersistentVolumeClaim.ClaimName, resources))
+		case volume.VolumeSource.ConfigMap != nil, volume.VolumeSource.Secret != nil:
+			names = append(names, kubeDataVolumeName(pod.name, volume.Name))
+		}
+	}
+	return names
+}
+
+// kubePodVolumes returns the volumes of a kube pod which become volumes of the
+// libpod pod: volumes backed by libpod volumes which all containers of the pod,
+// init containers included, mount at the same path.  Volumes of pods with a
+// single container stay volumes of the container.  The names of the kube
+// volumes are returned as well.
+func kubePodVolumes(podYAML *v1.PodSpec, volumes map[string]string, namedVolumes map[string]bool) ([]*libpod.ContainerNamedVolume, map[string]bool) {
+	var podVolumes []*libpod.ContainerNamedVolume
+	names := make(map[string]bool)
+	containers := append(append([]v1.Container{}, podYAML.InitContainers...), podYAML.Containers...)
+	if len(containers) < 2 {
+		return nil, names
+	}
+	for _, mount := range containers[0].VolumeMounts {
+		if !namedVolumes[mount.Name] || mount.SubPath != "" || names[mount.Name] {
+			continue
+		}
+		if err := parse.ValidateVolumeCtrDir(mount.MountPath); err != nil {
+			continue
+		}
+		mountedByAll := true
+		for _, container := range containers[1:] {
+			if !hasKubeVolumeMount(container.VolumeMounts, mount) {
+				mountedByAll = false
+				break
+			}
+		}
+		if !mountedByAll {
+			continue
+		}
+		var options []string
+		if mount.ReadOnly {
+			options = append(options, "ro")
+		}
+		podVolumes = append(podVolumes, &libpod.ContainerNamedVolume{
+			Name:    volumes[mount.Name],
+			Dest:    mount.MountPath,
+			Options: options,
+		})
+		names[mount.Name] = true
+	}
+	return podVolumes, names
+}
+
+// hasKubeVolumeMount returns whether mounts contain mount
+func hasKubeVolumeMount(mounts []v1.VolumeMount, mount v1.VolumeMount) bool {
+	for _, m := range mounts {
+		if m.Name == mount.Name && m.MountPath == mount.MountPath && m.ReadOnly == mount.ReadOnly && m.SubPath == mount.SubPath {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutKubeVolumeMounts returns the mounts of volumes not listed in names
+func withoutKubeVolumeMounts(mounts []v1.VolumeMount, names map[string]bool) []v1.VolumeMount {
+	var kept []v1.VolumeMount
+	for _, mount := range mounts {
+		if !names[mount.Name] {
+			kept = append(kept, mount)
+		}
+	}
+	return kept
+}
+
+// kubeResources holds the kube objects pods can refer to by name
+type kubeResources struct {
+	configMaps map[string]v1.ConfigMap
+	secrets    map[string]v1.Secret
+	claims     map[string]v1.PersistentVolumeClaim
+}
+
+func newKubeResources() *kubeResources {
+	return &kubeResources{
+		configMaps: make(map[string]v1.ConfigMap),
+		secrets:    make(map[string]v1.Secret),
+		claims:     make(map[string]v1.PersistentVolumeClaim),
+	}
+}
+
+// splitKubeDocuments splits the content of a kube YAML file into its
+// documents and determines the kind of each of them.  Empty documents are
+// skipped.
+func splitKubeDocuments(content []byte) ([]kubeDocument, error) {
+	var documents []kubeDocument
+	reader := kubeyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to split YAML documents")
+		}
+		var fields map[string]interface{}
+		if err := yaml.Unmarshal(document, &fields); err != nil {
+			return nil, errors.Wrapf(err, "unable to read YAML document")
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(document, &typeMeta); err != nil {
+			return nil, errors.Wrapf(err, "unable to read YAML document")
+		}
+		if typeMeta.Kind == "" {
+			return nil, errors.Errorf("YAML document does not specify a kind")
+		}
+		documents = append(documents, kubeDocument{kind: typeMeta.Kind, content: document})
+	}
+	return documents, nil
+}
+
+// add records the configmap, secret or persistent volume claim described by
+// document.  It returns false if the document is of another kind.
+func (k *kubeResources) add(document kubeDocument) (bool, error) {
+	switch document.kind {
+	case kubeKindConfigMap:
+		var configMap v1.ConfigMap
+		if err := yaml.Unmarshal(document.content, &configMap); err != nil {
+			return false, errors.Wrapf(err, "unable to read YAML as kube configmap")
+		}
+		k.configMaps[configMap.Name] = configMap
+	case kubeKindSecret:
+		var secret v1.Secret
+		if err := yaml.Unmarshal(document.content, &secret); err != nil {
+			return false, errors.Wrapf(err, "unable to read YAML as kube secret")
+		}
+		k.secrets[secret.Name] = secret
+	case kubeKindPersistentVolumeClaim:
+		var claim v1.PersistentVolumeClaim
+		if err := yaml.Unmarshal(document.content, &claim); err != nil {
+			return false, errors.Wrapf(err, "unable to read YAML as kube persistent volume claim")
+		}
+		k.claims[claim.Name] = claim
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// addConfigMapFile records the configmaps and secrets of the YAML file at path
+func (k *kubeResources) addConfigMapFile(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	documents, err := splitKubeDocuments(content)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read %s", path)
+	}
+	for _, document := range documents {
+		if document.kind != kubeKindConfigMap && document.kind != kubeKindSecret {
+			return errors.Errorf("%s contains a %s, only ConfigMaps and Secrets are supported", path, document.kind)
+		}
+		if _, err := k.add(document); err != nil {
+			return errors.Wrapf(err, "unable to read %s", path)
+		}
+	}
+	return nil
+}
+
+// configMapData returns the data of the named configmap.  A missing
+// configmap is only an error if it is not optional.
+func (k *kubeResources) configMapData(name string, optional *bool) (map[string][]byte, error) {
+	configMap, ok := k.configMaps[name]
+	if !ok {
+		if optional != nil && *optional {
+			return nil, nil
+		}
+		return nil, errors.Errorf("configmap %s was not found", name)
+	}
+	data := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+	for key, value := range configMap.BinaryData {
+		data[key] = value
+	}
+	for key, value := range configMap.Data {
+		data[key] = []byte(value)
+	}
+	return data, nil
+}
+
+// secretData returns the data of the named secret.  A missing secret is only
+// an error if it is not optional.
+func (k *kubeResources) secretData(name string, optional *bool) (map[string][]byte, error) {
+	secret, ok := k.secrets[name]
+	if !ok {
+		if optional != nil && *optional {
+			return nil, nil
+		}
+		return nil, errors.Errorf("secret %s was not found", name)
+	}
+	data := make(map[string][]byte, len(secret.Data)+len(secret.StringData))
+	for key, value := range secret.Data {
+		data[key] = value
+	}
+	for key, value := range secret.StringData {
+		data[key] = []byte(value)
+	}
+	return data, nil
+}
+
+// keyValue looks up key in data, which belongs to the configmap or secret
+// described by source.  A missing key is only an error if it is not optional.
+func keyValue(data map[string][]byte, key, source string, optional *bool) (string, bool, error) {
+	value, ok := data[key]
+	if !ok {
+		if (optional != nil && *optional) || data == nil {
+			return "", false, nil
+		}
+		return "", false, errors.Errorf("key %s was not found in %s", key, source)
+	}
+	return string(value), true, nil
+}
+
+// envVariables resolves the environment of a kube container, including the
+// variables it takes from configmaps and secrets
+func (k *kubeResources) envVariables(containerYAML v1.Container) (map[string]string, error) {
+	envs := make(map[string]string)
+	for _, envFrom := range containerYAML.EnvFrom {
+		var (
+			data map[string][]byte
+			err  error
+		)
+		switch {
+		case envFrom.ConfigMapRef != nil:
+			data, err = k.configMapData(envFrom.ConfigMapRef.Name, envFrom.ConfigMapRef.Optional)
+		case envFrom.SecretRef != nil:
+			data, err = k.secretData(envFrom.SecretRef.Name, envFrom.SecretRef.Optional)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range data {
+			envs[envFrom.Prefix+key] = string(value)
+		}
+	}
+
+	for _, e := range containerYAML.Env {
+		if e.ValueFrom == nil {
+			envs[e.Name] = e.Value
+			continue
+		}
+		var (
+			value string
+			found bool
+		)
+		switch source := e.ValueFrom; {
+		case source.ConfigMapKeyRef != nil:
+			ref := source.ConfigMapKeyRef
+			data, err := k.configMapData(ref.Name, ref.Optional)
+			if err != nil {
+				return nil, err
+			}
+			if value, found, err = keyValue(data, ref.Key, "configmap "+ref.Name, ref.Optional); err != nil {
+				return nil, err
+			}
+		case source.SecretKeyRef != nil:
+			ref := source.SecretKeyRef
+			data, err := k.secretData(ref.Name, ref.Optional)
+			if err != nil {
+				return nil, err
+			}
+			if value, found, err = keyValue(data, ref.Key, "secret "+ref.Name, ref.Optional); err != nil {
+				return nil, err
+			}
+		default:
+			logrus.Warnf("Environment variable %s is only supported with configmap and secret sources, skipping it", e.Name)
+		}
+		if found {
+			envs[e.Name] = value
+		}
+	}
+	return envs, nil
+}
+
+// kubeDataVolumeName returns the name of the libpod volume backing a pod volume which
+// is populated from a configmap or a secret
+func kubeDataVolumeName(podName, volumeName string) string {
+	return podName + "-" + volumeName
+}
+
+// createDataVolume creates (or reuses) a libpod volume and fills it with the
+// data of a configmap or secret volume source.  If items is set only the
+// listed keys are written to the paths they specify.  The volume is added to
+// created if it did not exist.
+func (r *LocalRuntime) createDataVolume(ctx context.Context, name string, data map[string][]byte, items []v1.KeyToPath, defaultMode *int32, source string, optional *bool, created map[string]bool) error {
+	vol, err := r.GetVolume(name)
+	if err != nil {
+		if errors.Cause(err) != define.ErrNoSuchVolume {
+			return err
+		}
+		if vol, err = r.NewVolume(ctx, libpod.WithVolumeName(name), libpod.WithVolumeLabels(map[string]string{kubeVolumeLabel: "true"})); err != nil {
+			return errors.Wrapf(err, "error creating volume %s", name)
+		}
+		created[name] = true
+	}
+
+	mode := os.FileMode(kubeVolumeFilePermission)
+	if defaultMode != nil {
+		mode = os.FileMode(*defaultMode)
+	}
+	files := make(map[string]os.FileMode)
+	if len(items) == 0 {
+		for key := range data {
+			files[key] = mode
+		}
+	}
+	paths := make(map[string]string)
+	for _, item := range items {
+		if _, ok := data[item.Key]; !ok {
+			if (optional != nil && *optional) || data == nil {
+				continue
+			}
+			return errors.Errorf("key %s was not found in %s", item.Key, source)
+		}
+		paths[item.Key] = item.Path
+		files[item.Key] = mode
+		if item.Mode != nil {
+			files[item.Key] = os.FileMode(*item.Mode)
+		}
+	}
+
+	for key, fileMode := range files {
+		path := key
+		if p, ok := paths[key]; ok {
+			path = p
+		}
+		target := filepath.Join(vol.MountPoint(), filepath.Clean("/"+path))
+		if err := os.MkdirAll(filepath.Dir(target), createDirectoryPermission); err != nil {
+			return errors.Wrapf(err, "error creating directory for %s in volume %s", path, name)
+		}
+		if err := ioutil.WriteFile(target, data[key], fileMode); err != nil {
+			return errors.Wrapf(err, "error writing %s to volume %s", path, name)
+		}
+		// WriteFile only applies the mode to new files
+		if err := os.Chmod(target, fileMode); err != nil {
+			return errors.Wrapf(err, "error setting permissions of %s in volume %s", path, name)
+		}
+	}
+	return nil
+}
+
+// kubeClaimVolumeName returns the name of the libpod volume backing a
+// persistent volume claim.  generate kube records the name of the volume in
+// an annotation of the claim if it is not a valid claim name.
+func kubeClaimVolumeName(claimName string, resources *kubeResources) string {
+	if claim, ok := resources.claims[claimName]; ok {
+		if name := claim.Annotations[libpod.KubeVolumeNameAnnotation]; name != "" {
+			return name
+		}
+	}
+	return claimName
+}
+
+// createClaimVolume creates the libpod volume backing a persistent volume
+// claim unless it already exists, and returns its name.  The volume is added
+// to created if it did not exist.
+func (r *LocalRuntime) createClaimVolume(ctx context.Context, claimName string, resources *kubeResources, created map[string]bool) (string, error) {
+	name := kubeClaimVolumeName(claimName, resources)
+	exists, err := r.HasVolume(name)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return name, nil
+	}
+	labels := map[string]string{kubeVolumeLabel: "true"}
+	if claim, ok := resources.claims[claimName]; ok {
+		for key, value := range claim.Labels {
+			labels[key] = value
+		}
+	}
+	if _, err := r.NewVolume(ctx, libpod.WithVolumeName(name), libpod.WithVolumeLabels(labels)); err != nil {
+		return "", errors.Wrapf(err, "error creating volume for persistent volume claim %s", claimName)
+	}
+	created[name] = true
+	return name, nil
+}
+
+// removeKubeVolume removes the named volume if it was created by play kube.
+// Volumes which do not exist or were created otherwise are left alone.
+func (r *LocalRuntime) removeKubeVolume(ctx context.Context, name string) error {
+	vol, err := r.GetVolume(name)
+	if err != nil {
+		if errors.Cause(err) == define.ErrNoSuchVolume {
+			return nil
+		}
+		return err
+	}
+	if _, ok := vol.Labels()[kubeVolumeLabel]; !ok {
+		logrus.Debugf("Volume %s was not created by play kube, not removing it", name)
+		return nil
+	}
+	if err := r.RemoveVolume(ctx, vol, false); err != nil {
+		return errors.Wrapf(err, "error removing volume %s", name)
+	}
+	return nil
+}
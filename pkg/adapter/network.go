@@ -0,0 +1,268 @@
+// +build !remoteclient
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containers/libpod/cmd/podman/cliconfig"
+	"github.com/containers/libpod/libpod"
+	"github.com/containers/libpod/pkg/network"
+	"github.com/pkg/errors"
+)
+
+// cniConfigDir returns the directory holding the CNI network configurations
+// of the runtime
+func (r *LocalRuntime) cniConfigDir() (string, error) {
+	config, err := r.GetConfig()
+	if err != nil {
+		return "", err
+	}
+	return config.CNIConfigDir, nil
+}
+
+// NetworkList returns the CNI networks podman knows about
+func (r *LocalRuntime) NetworkList(cli *cliconfig.NetworkListValues) ([]*libcni.NetworkConfigList, error) {
+	dir, err := r.cniConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	networks, err := network.LoadCNIConfsFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if cli.Filter == "" {
+		return networks, nil
+	}
+	filterSplit := strings.SplitN(cli.Filter, "=", 2)
+	if len(filterSplit) != 2 {
+		return nil, errors.Errorf("filter input must be in the form of filter=value: %s is invalid", cli.Filter)
+	}
+	var filtered []*libcni.NetworkConfigList
+	for _, n := range networks {
+		switch filterSplit[0] {
+		case "name":
+			if strings.Contains(n.Name, filterSplit[1]) {
+				filtered = append(filtered, n)
+			}
+		case "plugin":
+			for _, plugin := range n.Plugins {
+				if plugin.Network.Type == filterSplit[1] {
+					filtered = append(filtered, n)
+					break
+				}
+			}
+		default:
+			return nil, errors.Errorf("%s is an invalid filter", filterSplit[0])
+		}
+	}
+	return filtered, nil
+}
+
+// NetworkInspect returns the raw CNI configurations of the given networks
+func (r *LocalRuntime) NetworkInspect(cli *cliconfig.NetworkInspectValues) ([]map[string]interface{}, error) {
+	var rawCNINetworks []map[string]interface{}
+	dir, err := r.cniConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range cli.InputArgs {
+		rawList := make(map[string]interface{})
+		b, err := network.ReadRawCNIConfByName(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &rawList); err != nil {
+			return nil, errors.Wrapf(err, "error parsing configuration of network %s", name)
+		}
+		rawCNINetworks = append(rawCNINetworks, rawList)
+	}
+	return rawCNINetworks, nil
+}
+
+// NetworkRemove removes the given CNI networks. A network that containers
+// still reference is only removed, together with those containers, when
+// force is set.
+func (r *LocalRuntime) NetworkRemove(ctx context.Context, cli *cliconfig.NetworkRmValues) ([]string, error) {
+	var networkRmSuccesses []string
+	dir, err := r.cniConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range cli.InputArgs {
+		if _, err := network.GetCNIConfigPathByName(dir, name); err != nil {
+			return networkRmSuccesses, err
+		}
+		ctrs, err := r.containersUsingNetwork(name)
+		if err != nil {
+			return networkRmSuccesses, err
+		}
+		if len(ctrs) > 0 {
+			if !cli.Force {
+				ids := make([]string, 0, len(ctrs))
+				for _, ctr := range ctrs {
+					ids = append(ids, ctr.ID())
+				}
+				return networkRmSuccesses, errors.Errorf("network %s is being used by containers %s", name, strings.Join(ids, ", "))
+			}
+			for _, ctr := range ctrs {
+				if err := r.RemoveContainer(ctx, ctr, true, false); err != nil {
+					return networkRmSuccesses, errors.Wrapf(err, "error removing container %s using network %s", ctr.ID(), name)
+				}
+			}
+		}
+		if err := network.RemoveNetwork(dir, name); err != nil {
+			return networkRmSuccesses, err
+		}
+		networkRmSuccesses = append(networkRmSuccesses, name)
+	}
+	return networkRmSuccesses, nil
+}
+
+// containersUsingNetwork returns the containers in the state that join the
+// given network, either explicitly or because it is the default network
+func (r *LocalRuntime) containersUsingNetwork(name string) ([]*libpod.Container, error) {
+	allCtrs, err := r.Runtime.GetAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	var ctrs []*libpod.Container
+	for _, ctr := range allCtrs {
+		if !ctr.Config().CreateNetNS {
+			continue
+		}
+		networks, err := ctr.Networks()
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range networks {
+			if n == name {
+				ctrs = append(ctrs, ctr)
+				break
+			}
+		}
+	}
+	return ctrs, nil
+}
+
+// NetworkCreate writes a new bridge network with portmap and firewall
+// plugins to the CNI configuration directory and returns its path
+func (r *LocalRuntime) NetworkCreate(cli *cliconfig.NetworkCreateValues) (string, error) {
+	var (
+		err     error
+		subnet  *net.IPNet
+		ipRange *net.IPNet
+		name    string
+	)
+	if err := network.IsSupportedDriver(cli.Driver); err != nil {
+		return "", err
+	}
+	dir, err := r.cniConfigDir()
+	if err != nil {
+		return "", err
+	}
+	// if subnet is provided, check that it is not used by an existing
+	// network or a host interface
+	if cli.Network.IP != nil {
+		subnet = &cli.Network
+		if err := network.ValidateUserNetworkIsAvailable(dir, subnet); err != nil {
+			return "", err
+		}
+	} else {
+		subnet, err = network.GetFreeNetwork(dir)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	gateway := cli.Gateway
+	if gateway == nil {
+		// if no gateway is provided, the first address of the subnet is
+		// used
+		gateway, err = network.FirstIPInSubnet(subnet)
+		if err != nil {
+			return "", err
+		}
+	} else if !subnet.Contains(gateway) {
+		return "", errors.Errorf("gateway %s is not in the subnet %s", gateway.String(), subnet.String())
+	}
+
+	if cli.IPRange.IP != nil {
+		startIP, err := network.FirstIPInSubnet(&cli.IPRange)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid IP range %s", cli.IPRange.String())
+		}
+		endIP, err := network.LastIPInSubnet(&cli.IPRange)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid IP range %s", cli.IPRange.String())
+		}
+		if !subnet.Contains(startIP) || !subnet.Contains(endIP) {
+			return "", errors.Errorf("the IP range %s does not fall within the subnet %s", cli.IPRange.String(), subnet.String())
+		}
+		ipRange = &cli.IPRange
+	}
+
+	bridgeDeviceName, err := network.GetFreeDeviceName(dir)
+	if err != nil {
+		return "", err
+	}
+	if len(cli.InputArgs) > 0 {
+		name = cli.InputArgs[0]
+		if !libpod.NameRegex.MatchString(name) {
+			return "", errors.Wrapf(libpod.RegexError, "invalid network name %s", name)
+		}
+	} else {
+		// default to the device name as network name
+		name = bridgeDeviceName
+	}
+
+	var routes []network.IPAMRoute
+	// an internal network has no route to the outside world
+	if !cli.Internal {
+		defaultRoute, err := network.NewIPAMDefaultRoute(subnet.IP.To4() == nil)
+		if err != nil {
+			return "", err
+		}
+		routes = append(routes, defaultRoute)
+	}
+	ipamConfig, err := network.NewIPAMHostLocalConf(subnet, routes, ipRange, gateway)
+	if err != nil {
+		return "", err
+	}
+
+	var plugins []network.CNIPlugins
+	bridge := network.NewHostLocalBridge(bridgeDeviceName, true, false, !cli.Internal, ipamConfig)
+	plugins = append(plugins, bridge)
+	plugins = append(plugins, network.NewPortMapPlugin())
+	plugins = append(plugins, network.NewFirewallPlugin())
+
+	ncList := network.NewNcList(name, network.CNIVersion)
+	ncList["plugins"] = plugins
+	cniPath, err := network.WriteCNIConfig(dir, name, ncList)
+	if err != nil {
+		return "", err
+	}
+	return cniPath, nil
+}
+
+// NetworkConnect connects a container to a CNI network
+func (r *LocalRuntime) NetworkConnect(cli *cliconfig.NetworkConnectValues) error {
+	ctr, err := r.LookupContainer(cli.InputArgs[0])
+	if err != nil {
+		return err
+	}
+	return ctr.NetworkConnect(cli.InputArgs[1])
+}
+
+// NetworkDisconnect disconnects a container from a CNI network
+func (r *LocalRuntime) NetworkDisconnect(cli *cliconfig.NetworkDisconnectValues) error {
+	ctr, err := r.LookupContainer(cli.InputArgs[0])
+	if err != nil {
+		return err
+	}
+	return ctr.NetworkDisconnect(cli.InputArgs[1])
+}
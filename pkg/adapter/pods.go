@@ -8,14 +8,18 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/containers/buildah/pkg/parse"
 	"github.com/containers/image/types"
 	"github.com/containers/libpod/cmd/podman/cliconfig"
 	"github.com/containers/libpod/cmd/podman/shared"
 	"github.com/containers/libpod/libpod"
+	"github.com/containers/libpod/libpod/define"
 	"github.com/containers/libpod/libpod/image"
+	"github.com/containers/libpod/libpod/logs"
 	"github.com/containers/libpod/pkg/adapter/shortcuts"
 	ns "github.com/containers/libpod/pkg/namespaces"
 	createconfig "github.com/containers/libpod/pkg/spec"
@@ -24,6 +28,7 @@ import (
 	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 )
 
@@ -272,6 +277,24 @@ func (r *LocalRuntime) CreatePod(ctx context.Context, cli *cliconfig.PodCreateVa
 		options = append(options, libpod.WithInfraContainerPorts(portBindings))
 
 	}
+	networkOptions, err := shared.GetPodNetworkOptions(cli.Hostname, cli.DNS, cli.DNSSearch, cli.AddHost, cli.NoHosts)
+	if err != nil {
+		return "", err
+	}
+	options = append(options, networkOptions...)
+
+	volumeOptions, err := shared.GetPodVolumeOptions(cli.Volume)
+	if err != nil {
+		return "", err
+	}
+	options = append(options, volumeOptions...)
+
+	resourceOptions, err := shared.GetPodResourceOptions(cli.CPUs, cli.Memory, cli.PidsLimit, cli.BlkioWeight)
+	if err != nil {
+		return "", err
+	}
+	options = append(options, resourceOptions...)
+
 	// always have containers use pod cgroups
 	// User Opt out is not yet supported
 	options = append(options, libpod.WithPodCgroups())
@@ -416,6 +439,37 @@ func (r *LocalRuntime) PodTop(c *cliconfig.PodTopValues, descriptors []string) (
 	return pod.GetPodPidInformation(descriptors)
 }
 
+// PodLog logs the containers of a pod
+func (r *LocalRuntime) PodLog(c *cliconfig.PodLogsValues, options *logs.LogOptions) error {
+	var (
+		pod *Pod
+		err error
+		wg  sync.WaitGroup
+	)
+
+	if c.Latest {
+		pod, err = r.GetLatestPod()
+	} else {
+		pod, err = r.LookupPod(c.InputArgs[0])
+	}
+	if err != nil {
+		return errors.Wrapf(err, "unable to lookup requested pod")
+	}
+	options.WaitGroup = &wg
+	logChannel := make(chan *logs.LogLine, int(c.Tail)+1)
+	if err := pod.ReadLog(options, logChannel); err != nil {
+		return err
+	}
+	go func() {
+		wg.Wait()
+		close(logChannel)
+	}()
+	for line := range logChannel {
+		fmt.Println(line.String(options))
+	}
+	return nil
+}
+
 // GetStatPods returns pods for use in pod stats
 func (r *LocalRuntime) GetStatPods(c *cliconfig.PodStatsValues) ([]*Pod, error) {
 	var (
@@ -444,74 +498,284 @@ func (r *LocalRuntime) GetStatPods(c *cliconfig.PodStatsValues) ([]*Pod, error)
 
 // PlayKubeYAML creates pods and containers from a kube YAML file
 func (r *LocalRuntime) PlayKubeYAML(ctx context.Context, c *cliconfig.KubePlayValues, yamlFile string) (*Pod, error) {
+	pods, resources, err := readKubeYAML(yamlFile, c.ConfigMaps)
+	if err != nil {
+		return nil, err
+	}
 	var (
-		containers    []*libpod.Container
-		pod           *libpod.Pod
-		podOptions    []libpod.PodCreateOption
-		podYAML       v1.Pod
-		registryCreds *types.DockerAuthConfig
-		writer        io.Writer
+		played     []*libpod.Pod
+		containers [][]*libpod.Container
 	)
+	createdVolumes := make(map[string]bool)
+	for _, kubePod := range pods {
+		pod, ctrs, err := r.playKubePod(ctx, c, kubePod.name, kubePod.spec, resources, createdVolumes)
+		if pod != nil {
+			played = append(played, pod)
+		}
+		if err != nil {
+			// Remove the pods and volumes played so far, so the
+			// file is either played entirely or not at all
+			for _, pod := range played {
+				if err := r.RemovePod(ctx, pod, true, true); err != nil {
+					logrus.Errorf("unable to remove pod %s after failing to play kube: %v", pod.ID(), err)
+				}
+			}
+			for name := range createdVolumes {
+				if err := r.removeKubeVolume(ctx, name); err != nil {
+					logrus.Errorf("unable to remove volume %s after failing to play kube: %v", name, err)
+				}
+			}
+			return nil, err
+		}
+		containers = append(containers, ctrs)
+	}
 
+	// We've now successfully converted this YAML into pods
+	// print our pods and containers, signifying we succeeded
+	for i, pod := range played {
+		fmt.Printf("Pod:\n%s\n", pod.ID())
+		if len(containers[i]) == 1 {
+			fmt.Printf("Container:\n")
+		}
+		if len(containers[i]) > 1 {
+			fmt.Printf("Containers:\n")
+		}
+		for _, ctr := range containers[i] {
+			fmt.Println(ctr.ID())
+		}
+	}
+	return nil, nil
+}
+
+// TeardownKubeYAML stops and removes the pods and containers created from a
+// kube YAML file.  If removeVolumes is set the volumes play kube created for
+// them are removed as well.
+func (r *LocalRuntime) TeardownKubeYAML(ctx context.Context, c *cliconfig.KubePlayValues, yamlFile string) error {
+	pods, resources, err := readKubeYAML(yamlFile, nil)
+	if err != nil {
+		return err
+	}
+
+	var lastError error
+	volumes := make(map[string]bool)
+	for _, kubePod := range pods {
+		pod, err := r.Runtime.LookupPod(kubePod.name)
+		if err != nil {
+			if errors.Cause(err) == define.ErrNoSuchPod {
+				logrus.Warnf("pod %s does not exist, skipping it", kubePod.name)
+				continue
+			}
+			if lastError != nil {
+				logrus.Error(lastError)
+			}
+			lastError = err
+			continue
+		}
+		if err := r.RemovePod(ctx, pod, true, true); err != nil {
+			if lastError != nil {
+				logrus.Error(lastError)
+			}
+			lastError = errors.Wrapf(err, "error removing pod %s", kubePod.name)
+			continue
+		}
+		fmt.Println(pod.ID())
+		for _, name := range kubePodVolumeNames(kubePod, resources) {
+			volumes[name] = true
+		}
+	}
+
+	if c.Volumes {
+		for name := range volumes {
+			if err := r.removeKubeVolume(ctx, name); err != nil {
+				if lastError != nil {
+					logrus.Error(lastError)
+				}
+				lastError = err
+			}
+		}
+	}
+	return lastError
+}
+
+// readKubeYAML reads the pods described by a kube YAML file along with the
+// configmaps, secrets and claims they can refer to
+func readKubeYAML(yamlFile string, configMapFiles []string) ([]kubePod, *kubeResources, error) {
 	content, err := ioutil.ReadFile(yamlFile)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	documents, err := splitKubeDocuments(content)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "unable to read %s as YAML", yamlFile)
 	}
 
-	if err := yaml.Unmarshal(content, &podYAML); err != nil {
-		return nil, errors.Wrapf(err, "unable to read %s as YAML", yamlFile)
+	resources := newKubeResources()
+	for _, configMapFile := range configMapFiles {
+		if err := resources.addConfigMapFile(configMapFile); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	// check for name collision between pod and container
-	podName := podYAML.ObjectMeta.Name
-	for _, n := range podYAML.Spec.Containers {
-		if n.Name == podName {
-			fmt.Printf("a container exists with the same name (%s) as the pod in your YAML file; changing pod name to %s_pod\n", podName, podName)
-			podName = fmt.Sprintf("%s_pod", podName)
+	var pods []kubePod
+	for _, document := range documents {
+		isResource, err := resources.add(document)
+		if err != nil {
+			return nil, nil, err
+		}
+		if isResource {
+			continue
+		}
+		switch document.kind {
+		case kubeKindPod:
+			var podYAML v1.Pod
+			if err := yaml.Unmarshal(document.content, &podYAML); err != nil {
+				return nil, nil, errors.Wrapf(err, "unable to read YAML as kube pod")
+			}
+			pods = append(pods, newKubePod(podYAML.Name, &podYAML.Spec))
+		case kubeKindDeployment:
+			var deploymentYAML appsv1.Deployment
+			if err := yaml.Unmarshal(document.content, &deploymentYAML); err != nil {
+				return nil, nil, errors.Wrapf(err, "unable to read YAML as kube deployment")
+			}
+			replicas := int32(1)
+			if deploymentYAML.Spec.Replicas != nil {
+				replicas = *deploymentYAML.Spec.Replicas
+			}
+			for i := int32(0); i < replicas; i++ {
+				podName := fmt.Sprintf("%s-pod-%d", deploymentYAML.Name, i)
+				// container names must be unique across all replicas
+				podSpec := deploymentYAML.Spec.Template.Spec.DeepCopy()
+				for j := range podSpec.Containers {
+					podSpec.Containers[j].Name = fmt.Sprintf("%s-%s", podName, podSpec.Containers[j].Name)
+				}
+				for j := range podSpec.InitContainers {
+					podSpec.InitContainers[j].Name = fmt.Sprintf("%s-%s", podName, podSpec.InitContainers[j].Name)
+				}
+				pods = append(pods, newKubePod(podName, podSpec))
+			}
+		default:
+			logrus.Warnf("Kube kind %s is not supported, skipping it", document.kind)
 		}
 	}
+	// Pods may refer to configmaps, secrets and claims defined anywhere in
+	// the file, so they are only played once the whole file is read
+	return pods, resources, nil
+}
+
+// playKubePod creates and starts a pod named podName and its containers from
+// a kube pod spec.  The pod is returned along with the error if it was
+// created before failing, and the volumes it creates are added to
+// createdVolumes, so they can be removed again.
+func (r *LocalRuntime) playKubePod(ctx context.Context, c *cliconfig.KubePlayValues, podName string, podYAML *v1.PodSpec, resources *kubeResources, createdVolumes map[string]bool) (*libpod.Pod, []*libpod.Container, error) {
+	var (
+		containers    []*libpod.Container
+		pod           *libpod.Pod
+		podOptions    []libpod.PodCreateOption
+		registryCreds *types.DockerAuthConfig
+		writer        io.Writer
+	)
 
 	podOptions = append(podOptions, libpod.WithInfraContainer())
 	podOptions = append(podOptions, libpod.WithPodName(podName))
-	// TODO for now we just used the default kernel namespaces; we need to add/subtract this from yaml
+	if podYAML.Hostname != "" {
+		podOptions = append(podOptions, libpod.WithPodHostname(podYAML.Hostname))
+	}
 
-	nsOptions, err := shared.GetNamespaceOptions(strings.Split(shared.DefaultKernelNamespaces, ","))
+	sharedNamespaces := kubePodNamespaces(podYAML)
+	nsOptions, err := shared.GetNamespaceOptions(sharedNamespaces)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	podOptions = append(podOptions, nsOptions...)
-	podPorts := getPodPorts(podYAML.Spec.Containers)
-	podOptions = append(podOptions, libpod.WithInfraContainerPorts(podPorts))
+	if !podYAML.HostNetwork {
+		podPorts := getPodPorts(podYAML.Containers)
+		podOptions = append(podOptions, libpod.WithInfraContainerPorts(podPorts))
+	}
+
+	// map from name to mount point or libpod volume name
+	volumes := make(map[string]string)
+	// names of the volumes backed by libpod volumes
+	namedVolumes := make(map[string]bool)
+	for _, volume := range podYAML.Volumes {
+		switch {
+		case volume.VolumeSource.HostPath != nil:
+			hostPath := volume.VolumeSource.HostPath
+			if err := prepareHostPathVolume(volume.Name, hostPath); err != nil {
+				return nil, nil, err
+			}
+			volumes[volume.Name] = hostPath.Path
+		case volume.VolumeSource.PersistentVolumeClaim != nil:
+			volumeName, err := r.createClaimVolume(ctx, volume.VolumeSource.PersistentVolumeClaim.ClaimName, resources, createdVolumes)
+			if err != nil {
+				return nil, nil, err
+			}
+			volumes[volume.Name] = volumeName
+			namedVolumes[volume.Name] = true
+		case volume.VolumeSource.ConfigMap != nil:
+			source := volume.VolumeSource.ConfigMap
+			data, err := resources.configMapData(source.Name, source.Optional)
+			if err != nil {
+				return nil, nil, err
+			}
+			volumeName := kubeDataVolumeName(podName, volume.Name)
+			if err := r.createDataVolume(ctx, volumeName, data, source.Items, source.DefaultMode, "configmap "+source.Name, source.Optional, createdVolumes); err != nil {
+				return nil, nil, err
+			}
+			volumes[volume.Name] = volumeName
+			namedVolumes[volume.Name] = true
+		case volume.VolumeSource.Secret != nil:
+			source := volume.VolumeSource.Secret
+			data, err := resources.secretData(source.SecretName, source.Optional)
+			if err != nil {
+				return nil, nil, err
+			}
+			volumeName := kubeDataVolumeName(podName, volume.Name)
+			if err := r.createDataVolume(ctx, volumeName, data, source.Items, source.DefaultMode, "secret "+source.SecretName, source.Optional, createdVolumes); err != nil {
+				return nil, nil, err
+			}
+			volumes[volume.Name] = volumeName
+			namedVolumes[volume.Name] = true
+		default:
+			return nil, nil, errors.Errorf("volume %s: HostPath, PersistentVolumeClaim, ConfigMap and Secret are the only supported VolumeSources", volume.Name)
+		}
+	}
+
+	// Volumes all containers mount the same way become volumes of the pod
+	podVolumes, podVolumeNames := kubePodVolumes(podYAML, volumes, namedVolumes)
+	if len(podVolumes) > 0 {
+		podOptions = append(podOptions, libpod.WithPodVolumes(podVolumes))
+	}
 
 	// Create the Pod
 	pod, err = r.NewPod(ctx, podOptions...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	podInfraID, err := pod.InfraContainerID()
 	if err != nil {
-		return nil, err
+		return pod, nil, err
 	}
 	hasUserns := false
 	if podInfraID != "" {
 		podCtr, err := r.GetContainer(podInfraID)
 		if err != nil {
-			return nil, err
+			return pod, nil, err
 		}
 		mappings, err := podCtr.IDMappings()
 		if err != nil {
-			return nil, err
+			return pod, nil, err
 		}
 		hasUserns = len(mappings.UIDMap) > 0
 	}
 
-	namespaces := map[string]string{
-		// Disabled during code review per mheon
-		//"pid":  fmt.Sprintf("container:%s", podInfraID),
-		"net": fmt.Sprintf("container:%s", podInfraID),
-		"ipc": fmt.Sprintf("container:%s", podInfraID),
-		"uts": fmt.Sprintf("container:%s", podInfraID),
+	namespaces := make(map[string]string)
+	for _, namespace := range sharedNamespaces {
+		namespaces[namespace] = fmt.Sprintf("container:%s", podInfraID)
+	}
+	if podYAML.HostNetwork {
+		namespaces["net"] = "host"
 	}
 	if hasUserns {
 		namespaces["user"] = fmt.Sprintf("container:%s", podInfraID)
@@ -528,97 +792,90 @@ func (r *LocalRuntime) PlayKubeYAML(ctx context.Context, c *cliconfig.KubePlayVa
 		dockerRegistryOptions.DockerInsecureSkipTLSVerify = types.NewOptionalBool(!c.TlsVerify)
 	}
 
-	// map from name to mount point
-	volumes := make(map[string]string)
-	for _, volume := range podYAML.Spec.Volumes {
-		hostPath := volume.VolumeSource.HostPath
-		if hostPath == nil {
-			return nil, errors.Errorf("HostPath is currently the only supported VolumeSource")
-		}
-		if hostPath.Type != nil {
-			switch *hostPath.Type {
-			case v1.HostPathDirectoryOrCreate:
-				if _, err := os.Stat(hostPath.Path); os.IsNotExist(err) {
-					if err := os.Mkdir(hostPath.Path, createDirectoryPermission); err != nil {
-						return nil, errors.Errorf("Error creating HostPath %s at %s", volume.Name, hostPath.Path)
-					}
-				}
-				// unconditionally label a newly created volume as private
-				if err := libpod.LabelVolumePath(hostPath.Path, false); err != nil {
-					return nil, errors.Wrapf(err, "Error giving %s a label", hostPath.Path)
-				}
-			case v1.HostPathFileOrCreate:
-				if _, err := os.Stat(hostPath.Path); os.IsNotExist(err) {
-					f, err := os.OpenFile(hostPath.Path, os.O_RDONLY|os.O_CREATE, createFilePermission)
-					if err != nil {
-						return nil, errors.Errorf("Error creating HostPath %s at %s", volume.Name, hostPath.Path)
-					}
-					if err := f.Close(); err != nil {
-						logrus.Warnf("Error in closing newly created HostPath file: %v", err)
-					}
-				}
-				// unconditionally label a newly created volume as private
-				if err := libpod.LabelVolumePath(hostPath.Path, false); err != nil {
-					return nil, errors.Wrapf(err, "Error giving %s a label", hostPath.Path)
-				}
-			case v1.HostPathDirectory:
-			case v1.HostPathFile:
-			case v1.HostPathUnset:
-				// do nothing here because we will verify the path exists in validateVolumeHostDir
-				break
-			default:
-				return nil, errors.Errorf("Directories are the only supported HostPath type")
-			}
-		}
-
-		if err := parse.ValidateVolumeHostDir(hostPath.Path); err != nil {
-			return nil, errors.Wrapf(err, "Error in parsing HostPath in YAML")
-		}
-		volumes[volume.Name] = hostPath.Path
-	}
-
-	for _, container := range podYAML.Spec.Containers {
+	// Init containers are created first, as they run in the order they
+	// were created
+	kubeContainers := append(append([]v1.Container{}, podYAML.InitContainers...), podYAML.Containers...)
+	for i, container := range kubeContainers {
 		newImage, err := r.ImageRuntime().New(ctx, container.Image, c.SignaturePolicy, c.Authfile, writer, &dockerRegistryOptions, image.SigningOptions{}, false, nil)
 		if err != nil {
-			return nil, err
+			return pod, nil, err
 		}
-		createConfig, err := kubeContainerToCreateConfig(ctx, container, r.Runtime, newImage, namespaces, volumes, pod.ID())
+		// The pod volumes are mounted by the pod
+		container.VolumeMounts = withoutKubeVolumeMounts(container.VolumeMounts, podVolumeNames)
+		createConfig, err := kubeContainerToCreateConfig(ctx, container, podYAML, r.Runtime, newImage, namespaces, volumes, resources, pod.ID())
 		if err != nil {
-			return nil, err
+			return pod, nil, err
+		}
+		if i < len(podYAML.InitContainers) {
+			createConfig.InitCtr = true
+			createConfig.RestartPolicy = ""
 		}
 		ctr, err := shared.CreateContainerFromCreateConfig(r.Runtime, createConfig, ctx, pod)
 		if err != nil {
-			return nil, err
+			return pod, nil, err
 		}
 		containers = append(containers, ctr)
 	}
 
-	// start the containers
-	for _, ctr := range containers {
-		if err := ctr.Start(ctx, true); err != nil {
-			// Making this a hard failure here to avoid a mess
-			// the other containers are in created status
-			return nil, err
+	// start the pod, running its init containers first
+	if ctrErrors, err := pod.Start(ctx); err != nil {
+		// Making this a hard failure here to avoid a mess
+		// the other containers are in created status
+		for id, ctrError := range ctrErrors {
+			logrus.Errorf("unable to start container %s: %v", id, ctrError)
 		}
+		return pod, nil, err
 	}
 
-	// We've now successfully converted this YAML into a pod
-	// print our pod and containers, signifying we succeeded
-	fmt.Printf("Pod:\n%s\n", pod.ID())
-	if len(containers) == 1 {
-		fmt.Printf("Container:\n")
-	}
-	if len(containers) > 1 {
-		fmt.Printf("Containers:\n")
+	if err := playcleanup(ctx, r, pod, nil); err != nil {
+		logrus.Errorf("unable to remove pod %s after failing to play kube", pod.ID())
 	}
-	for _, ctr := range containers {
-		fmt.Println(ctr.ID())
+	return pod, containers, nil
+}
+
+// prepareHostPathVolume creates the directory or file of a HostPath volume
+// if its type asks for it and validates the path
+func prepareHostPathVolume(name string, hostPath *v1.HostPathVolumeSource) error {
+	if hostPath.Type != nil {
+		switch *hostPath.Type {
+		case v1.HostPathDirectoryOrCreate:
+			if _, err := os.Stat(hostPath.Path); os.IsNotExist(err) {
+				if err := os.Mkdir(hostPath.Path, createDirectoryPermission); err != nil {
+					return errors.Errorf("Error creating HostPath %s at %s", name, hostPath.Path)
+				}
+			}
+			// unconditionally label a newly created volume as private
+			if err := libpod.LabelVolumePath(hostPath.Path, false); err != nil {
+				return errors.Wrapf(err, "Error giving %s a label", hostPath.Path)
+			}
+		case v1.HostPathFileOrCreate:
+			if _, err := os.Stat(hostPath.Path); os.IsNotExist(err) {
+				f, err := os.OpenFile(hostPath.Path, os.O_RDONLY|os.O_CREATE, createFilePermission)
+				if err != nil {
+					return errors.Errorf("Error creating HostPath %s at %s", name, hostPath.Path)
+				}
+				if err := f.Close(); err != nil {
+					logrus.Warnf("Error in closing newly created HostPath file: %v", err)
+				}
+			}
+			// unconditionally label a newly created volume as private
+			if err := libpod.LabelVolumePath(hostPath.Path, false); err != nil {
+				return errors.Wrapf(err, "Error giving %s a label", hostPath.Path)
+			}
+		case v1.HostPathDirectory:
+		case v1.HostPathFile:
+		case v1.HostPathUnset:
+			// do nothing here because we will verify the path exists in validateVolumeHostDir
+			break
+		default:
+			return errors.Errorf("Directories are the only supported HostPath type")
+		}
 	}
 
-	if err := playcleanup(ctx, r, pod, nil); err != nil {
-		logrus.Errorf("unable to remove pod %s after failing to play kube", pod.ID())
+	if err := parse.ValidateVolumeHostDir(hostPath.Path); err != nil {
+		return errors.Wrapf(err, "Error in parsing HostPath in YAML")
 	}
-	return nil, nil
+	return nil
 }
 
 func playcleanup(ctx context.Context, runtime *LocalRuntime, pod *libpod.Pod, err error) error {
@@ -649,7 +906,7 @@ func getPodPorts(containers []v1.Container) []ocicni.PortMapping {
 }
 
 // kubeContainerToCreateConfig takes a v1.Container and returns a createconfig describing a container
-func kubeContainerToCreateConfig(ctx context.Context, containerYAML v1.Container, runtime *libpod.Runtime, newImage *image.Image, namespaces map[string]string, volumes map[string]string, podID string) (*createconfig.CreateConfig, error) {
+func kubeContainerToCreateConfig(ctx context.Context, containerYAML v1.Container, podYAML *v1.PodSpec, runtime *libpod.Runtime, newImage *image.Image, namespaces map[string]string, volumes map[string]string, resources *kubeResources, podID string) (*createconfig.CreateConfig, error) {
 	var (
 		containerConfig createconfig.CreateConfig
 	)
@@ -672,26 +929,17 @@ func kubeContainerToCreateConfig(ctx context.Context, containerYAML v1.Container
 		containerConfig.User = imageData.Config.User
 	}
 
-	if containerConfig.SecurityOpts != nil {
-		if containerYAML.SecurityContext.ReadOnlyRootFilesystem != nil {
-			containerConfig.ReadOnlyRootfs = *containerYAML.SecurityContext.ReadOnlyRootFilesystem
-		}
-		if containerYAML.SecurityContext.Privileged != nil {
-			containerConfig.Privileged = *containerYAML.SecurityContext.Privileged
-		}
-
-		if containerYAML.SecurityContext.AllowPrivilegeEscalation != nil {
-			containerConfig.NoNewPrivs = !*containerYAML.SecurityContext.AllowPrivilegeEscalation
-		}
+	setKubeSecurityContext(&containerConfig, containerYAML.SecurityContext, podYAML.SecurityContext)
+	setKubeResources(&containerConfig, containerYAML.Resources)
 
-	}
-	if caps := containerYAML.SecurityContext.Capabilities; caps != nil {
-		for _, capability := range caps.Add {
-			containerConfig.CapAdd = append(containerConfig.CapAdd, string(capability))
-		}
-		for _, capability := range caps.Drop {
-			containerConfig.CapDrop = append(containerConfig.CapDrop, string(capability))
-		}
+	// Kubernetes restarts the containers of pods without a restart policy
+	switch podYAML.RestartPolicy {
+	case "", v1.RestartPolicyAlways:
+		containerConfig.RestartPolicy = libpod.RestartPolicyAlways
+	case v1.RestartPolicyOnFailure:
+		containerConfig.RestartPolicy = libpod.RestartPolicyOnFailure
+	case v1.RestartPolicyNever:
+		containerConfig.RestartPolicy = libpod.RestartPolicyNo
 	}
 
 	containerConfig.Command = []string{}
@@ -717,8 +965,7 @@ func kubeContainerToCreateConfig(ctx context.Context, containerYAML v1.Container
 	containerConfig.NetMode = ns.NetworkMode(namespaces["net"])
 	containerConfig.IpcMode = ns.IpcMode(namespaces["ipc"])
 	containerConfig.UtsMode = ns.UTSMode(namespaces["uts"])
-	// disabled in code review per mheon
-	//containerConfig.PidMode = ns.PidMode(namespaces["pid"])
+	containerConfig.PidMode = ns.PidMode(namespaces["pid"])
 	containerConfig.UsernsMode = ns.UsernsMode(namespaces["user"])
 	if len(containerConfig.WorkDir) == 0 {
 		containerConfig.WorkDir = "/"
@@ -728,8 +975,12 @@ func kubeContainerToCreateConfig(ctx context.Context, containerYAML v1.Container
 	envs := shared.EnvVariablesFromData(imageData)
 
 	// Environment Variables
-	for _, e := range containerYAML.Env {
-		envs[e.Name] = e.Value
+	kubeEnvs, err := resources.envVariables(containerYAML)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range kubeEnvs {
+		envs[name] = value
 	}
 	containerConfig.Env = envs
 
@@ -741,7 +992,127 @@ func kubeContainerToCreateConfig(ctx context.Context, containerYAML v1.Container
 		if err := parse.ValidateVolumeCtrDir(volume.MountPath); err != nil {
 			return nil, errors.Wrapf(err, "error in parsing MountPath")
 		}
-		containerConfig.Volumes = append(containerConfig.Volumes, fmt.Sprintf("%s:%s", hostPath, volume.MountPath))
+		mount := fmt.Sprintf("%s:%s", hostPath, volume.MountPath)
+		if volume.ReadOnly {
+			mount += ":ro"
+		}
+		containerConfig.Volumes = append(containerConfig.Volumes, mount)
 	}
 	return &containerConfig, nil
 }
+
+// kubePodNamespaces returns the kernel namespaces the containers of a kube pod
+// share with each other
+func kubePodNamespaces(podYAML *v1.PodSpec) []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(shared.DefaultKernelNamespaces, ",") {
+		switch {
+		case namespace == "net" && podYAML.HostNetwork:
+			// the containers join the network namespace of the host
+		default:
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	if podYAML.ShareProcessNamespace != nil && *podYAML.ShareProcessNamespace {
+		namespaces = append(namespaces, "pid")
+	}
+	return namespaces
+}
+
+// setKubeSecurityContext applies the security context of a kube container
+// and the security context of its pod to a createconfig.  Settings of the
+// container take precedence over those of the pod.
+func setKubeSecurityContext(containerConfig *createconfig.CreateConfig, securityContext *v1.SecurityContext, podSecurityContext *v1.PodSecurityContext) {
+	var (
+		runAsUser      *int64
+		runAsGroup     *int64
+		seLinuxOptions *v1.SELinuxOptions
+	)
+	if podSecurityContext != nil {
+		runAsUser = podSecurityContext.RunAsUser
+		runAsGroup = podSecurityContext.RunAsGroup
+		seLinuxOptions = podSecurityContext.SELinuxOptions
+		for _, group := range podSecurityContext.SupplementalGroups {
+			containerConfig.GroupAdd = append(containerConfig.GroupAdd, strconv.FormatInt(group, 10))
+		}
+		if len(podSecurityContext.Sysctls) > 0 {
+			containerConfig.Sysctl = make(map[string]string, len(podSecurityContext.Sysctls))
+			for _, sysctl := range podSecurityContext.Sysctls {
+				containerConfig.Sysctl[sysctl.Name] = sysctl.Value
+			}
+		}
+	}
+
+	if securityContext != nil {
+		if securityContext.ReadOnlyRootFilesystem != nil {
+			containerConfig.ReadOnlyRootfs = *securityContext.ReadOnlyRootFilesystem
+		}
+		if securityContext.Privileged != nil {
+			containerConfig.Privileged = *securityContext.Privileged
+		}
+		if securityContext.AllowPrivilegeEscalation != nil {
+			containerConfig.NoNewPrivs = !*securityContext.AllowPrivilegeEscalation
+		}
+		if caps := securityContext.Capabilities; caps != nil {
+			for _, capability := range caps.Add {
+				containerConfig.CapAdd = append(containerConfig.CapAdd, string(capability))
+			}
+			for _, capability := range caps.Drop {
+				containerConfig.CapDrop = append(containerConfig.CapDrop, string(capability))
+			}
+		}
+		if securityContext.RunAsUser != nil {
+			runAsUser = securityContext.RunAsUser
+		}
+		if securityContext.RunAsGroup != nil {
+			runAsGroup = securityContext.RunAsGroup
+		}
+		if securityContext.SELinuxOptions != nil {
+			seLinuxOptions = securityContext.SELinuxOptions
+		}
+	}
+
+	if runAsUser != nil {
+		containerConfig.User = strconv.FormatInt(*runAsUser, 10)
+	}
+	if runAsGroup != nil {
+		user := strings.SplitN(containerConfig.User, ":", 2)[0]
+		if user == "" {
+			user = "0"
+		}
+		containerConfig.User = fmt.Sprintf("%s:%d", user, *runAsGroup)
+	}
+
+	if seLinuxOptions != nil {
+		if seLinuxOptions.User != "" {
+			containerConfig.LabelOpts = append(containerConfig.LabelOpts, "user:"+seLinuxOptions.User)
+		}
+		if seLinuxOptions.Role != "" {
+			containerConfig.LabelOpts = append(containerConfig.LabelOpts, "role:"+seLinuxOptions.Role)
+		}
+		if seLinuxOptions.Type != "" {
+			containerConfig.LabelOpts = append(containerConfig.LabelOpts, "type:"+seLinuxOptions.Type)
+		}
+		if seLinuxOptions.Level != "" {
+			containerConfig.LabelOpts = append(containerConfig.LabelOpts, "level:"+seLinuxOptions.Level)
+		}
+	}
+}
+
+// setKubeResources applies the cpu and memory requests and limits of a kube
+// container to a createconfig
+func setKubeResources(containerConfig *createconfig.CreateConfig, resources v1.ResourceRequirements) {
+	if cpu, ok := resources.Limits[v1.ResourceCPU]; ok {
+		containerConfig.Resources.CPUs = float64(cpu.MilliValue()) / 1000
+	}
+	if memory, ok := resources.Limits[v1.ResourceMemory]; ok {
+		containerConfig.Resources.Memory = memory.Value()
+	}
+	if cpu, ok := resources.Requests[v1.ResourceCPU]; ok {
+		// kubernetes translates a request of one cpu into 1024 shares
+		containerConfig.Resources.CPUShares = uint64(cpu.MilliValue() * 1024 / 1000)
+	}
+	if memory, ok := resources.Requests[v1.ResourceMemory]; ok {
+		containerConfig.Resources.MemoryReservation = memory.Value()
+	}
+}
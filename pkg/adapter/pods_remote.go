@@ -13,9 +13,11 @@ import (
 	"github.com/containers/libpod/cmd/podman/varlink"
 	"github.com/containers/libpod/libpod"
 	"github.com/containers/libpod/libpod/define"
+	"github.com/containers/libpod/libpod/logs"
 	"github.com/containers/libpod/pkg/varlinkapi"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/varlink/go/varlink"
 )
 
 // PodContainerStats is struct containing an adapter Pod and a libpod
@@ -187,6 +189,36 @@ func (r *LocalRuntime) CreatePod(ctx context.Context, cli *cliconfig.PodCreateVa
 		InfraImage:   cli.InfraCommand,
 		Publish:      cli.Publish,
 	}
+	if cli.Flag("cpus").Changed {
+		pc.Cpus = &cli.CPUs
+	}
+	if cli.Flag("memory").Changed {
+		pc.Memory = &cli.Memory
+	}
+	if cli.Flag("pids-limit").Changed {
+		pc.PidsLimit = &cli.PidsLimit
+	}
+	if cli.Flag("blkio-weight").Changed {
+		pc.BlkioWeight = &cli.BlkioWeight
+	}
+	if cli.Flag("hostname").Changed {
+		pc.Hostname = &cli.Hostname
+	}
+	if cli.Flag("dns").Changed {
+		pc.Dns = &cli.DNS
+	}
+	if cli.Flag("dns-search").Changed {
+		pc.DnsSearch = &cli.DNSSearch
+	}
+	if cli.Flag("add-host").Changed {
+		pc.AddHost = &cli.AddHost
+	}
+	if cli.Flag("no-hosts").Changed {
+		pc.NoHosts = &cli.NoHosts
+	}
+	if cli.Flag("volume").Changed {
+		pc.Volumes = &cli.Volume
+	}
 
 	return iopodman.CreatePod().Call(r.Conn, pc)
 }
@@ -434,6 +466,28 @@ func (r *LocalRuntime) PodTop(c *cliconfig.PodTopValues, descriptors []string) (
 	return iopodman.TopPod().Call(r.Conn, podName, latest, descriptors)
 }
 
+// PodLog logs the containers of a pod over a varlink connection
+func (r *LocalRuntime) PodLog(c *cliconfig.PodLogsValues, options *logs.LogOptions) error {
+	var (
+		podName      string
+		since, until string
+	)
+	if !c.Latest {
+		podName = c.InputArgs[0]
+	}
+	if !options.Since.IsZero() {
+		since = options.Since.Format(time.RFC3339Nano)
+	}
+	if !options.Until.IsZero() {
+		until = options.Until.Format(time.RFC3339Nano)
+	}
+	reply, err := iopodman.GetPodLogs().Send(r.Conn, uint64(varlink.More), podName, c.Latest, c.Follow, since, int64(c.Tail), c.Timestamps, until, options.Stream)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get pod logs")
+	}
+	return printLogReplies(reply, options)
+}
+
 // GetStatPods returns pods for use in pod stats
 func (r *LocalRuntime) GetStatPods(c *cliconfig.PodStatsValues) ([]*Pod, error) {
 	var (
@@ -569,3 +623,9 @@ func (r *LocalRuntime) PrunePods(ctx context.Context, cli *cliconfig.PodPruneVal
 func (r *LocalRuntime) PlayKubeYAML(ctx context.Context, c *cliconfig.KubePlayValues, yamlFile string) (*Pod, error) {
 	return nil, define.ErrNotImplemented
 }
+
+// TeardownKubeYAML stops and removes the pods and containers created from a
+// kube YAML file
+func (r *LocalRuntime) TeardownKubeYAML(ctx context.Context, c *cliconfig.KubePlayValues, yamlFile string) error {
+	return define.ErrNotImplemented
+}
@@ -26,7 +26,6 @@ import (
 	"github.com/containers/libpod/pkg/rootless"
 	"github.com/containers/storage/pkg/archive"
 	"github.com/pkg/errors"
-	"k8s.io/api/core/v1"
 )
 
 // LocalRuntime describes a typical libpod runtime
@@ -184,7 +183,7 @@ func (r *LocalRuntime) CreateVolume(ctx context.Context, c *cliconfig.VolumeCrea
 		options = append(options, libpod.WithVolumeLabels(labels))
 	}
 
-	if len(options) != 0 {
+	if len(opts) != 0 {
 		options = append(options, libpod.WithVolumeOptions(opts))
 	}
 	newVolume, err := r.NewVolume(ctx, options...)
@@ -199,6 +198,24 @@ func (r *LocalRuntime) RemoveVolumes(ctx context.Context, c *cliconfig.VolumeRmV
 	return r.Runtime.RemoveVolumes(ctx, c.InputArgs, c.All, c.Force)
 }
 
+// ExportVolume writes the contents of a volume to a tar archive at path
+func (r *LocalRuntime) ExportVolume(name, path string) error {
+	vol, err := r.GetVolume(name)
+	if err != nil {
+		return err
+	}
+	return vol.Export(path)
+}
+
+// ImportVolume extracts the tar archive at path into a volume
+func (r *LocalRuntime) ImportVolume(name, path string) error {
+	vol, err := r.GetVolume(name)
+	if err != nil {
+		return err
+	}
+	return vol.Import(path)
+}
+
 // Push is a wrapper to push an image to a registry
 func (r *LocalRuntime) Push(ctx context.Context, srcName, destination, manifestMIMEType, authfile, signaturePolicyPath string, writer io.Writer, forceCompress bool, signingOptions image.SigningOptions, dockerRegistryOptions *image.DockerRegistryOptions, additionalDockerArchiveTags []reference.NamedTagged) error {
 	newImage, err := r.ImageRuntime().NewFromLocal(srcName)
@@ -234,6 +251,9 @@ func (r *LocalRuntime) InspectVolumes(ctx context.Context, c *cliconfig.VolumeIn
 
 // Volumes returns a slice of localruntime volumes
 func (r *LocalRuntime) Volumes(ctx context.Context) ([]*Volume, error) {
+	if err := r.AddPluginVolumes(); err != nil {
+		return nil, err
+	}
 	vols, err := r.GetAllVolumes()
 	if err != nil {
 		return nil, err
@@ -406,8 +426,8 @@ func (r *LocalRuntime) Diff(c *cliconfig.DiffValues, to string) ([]archive.Chang
 }
 
 // GenerateKube creates kubernetes email from containers and pods
-func (r *LocalRuntime) GenerateKube(c *cliconfig.GenerateKubeValues) (*v1.Pod, *v1.Service, error) {
-	return shared.GenerateKube(c.InputArgs[0], c.Service, r.Runtime)
+func (r *LocalRuntime) GenerateKube(c *cliconfig.GenerateKubeValues) (*shared.KubeObjects, error) {
+	return shared.GenerateKube(c.InputArgs, c.Service, r.Runtime)
 }
 
 // GetPodsByStatus returns a slice of pods filtered by a libpod status
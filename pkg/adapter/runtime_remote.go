@@ -21,6 +21,7 @@ import (
 	"github.com/containers/image/types"
 	"github.com/containers/libpod/cmd/podman/cliconfig"
 	"github.com/containers/libpod/cmd/podman/remoteclientconfig"
+	"github.com/containers/libpod/cmd/podman/shared"
 	"github.com/containers/libpod/cmd/podman/varlink"
 	"github.com/containers/libpod/libpod"
 	"github.com/containers/libpod/libpod/define"
@@ -173,6 +174,7 @@ type Volume struct {
 type remoteVolume struct {
 	Runtime *LocalRuntime
 	config  *libpod.VolumeConfig
+	quota   *libpod.VolumeQuota
 }
 
 // GetImages returns a slice of containerimages over a varlink connection
@@ -618,6 +620,26 @@ func (r *LocalRuntime) RemoveVolumes(ctx context.Context, c *cliconfig.VolumeRmV
 	return iopodman.VolumeRemove().Call(r.Conn, rmOpts)
 }
 
+// ExportVolume exports a volume on the remote host and retrieves the archive
+// over a varlink connection
+func (r *LocalRuntime) ExportVolume(name, path string) error {
+	tempPath, err := iopodman.VolumeExport().Call(r.Conn, name, "")
+	if err != nil {
+		return err
+	}
+	return r.GetFileFromRemoteHost(tempPath, path, true)
+}
+
+// ImportVolume sends the archive at path to the remote host over a varlink
+// connection and imports it into a volume there
+func (r *LocalRuntime) ImportVolume(name, path string) error {
+	tempFile, err := r.SendFileOverVarlink(path)
+	if err != nil {
+		return err
+	}
+	return iopodman.VolumeImport().Call(r.Conn, name, strings.TrimRight(tempFile, ":"), true)
+}
+
 func (r *LocalRuntime) Push(ctx context.Context, srcName, destination, manifestMIMEType, authfile, signaturePolicyPath string, writer io.Writer, forceCompress bool, signingOptions image.SigningOptions, dockerRegistryOptions *image.DockerRegistryOptions, additionalDockerArchiveTags []reference.NamedTagged) error {
 
 	reply, err := iopodman.PushImage().Send(r.Conn, varlink.More, srcName, destination, forceCompress, manifestMIMEType, signingOptions.RemoveSignatures, signingOptions.SignBy)
@@ -674,6 +696,15 @@ func varlinkVolumeToVolume(r *LocalRuntime, volumes []iopodman.Volume) []*Volume
 			Runtime: r,
 			config:  &volumeConfig,
 		}
+		if v.Quota != nil {
+			n.quota = &libpod.VolumeQuota{
+				Size: uint64(v.Quota.Size),
+			}
+			if v.Quota.Used != nil {
+				used := uint64(*v.Quota.Used)
+				n.quota.Used = &used
+			}
+		}
 		newVol := Volume{
 			n,
 		}
@@ -867,6 +898,9 @@ func (r *LocalRuntime) Events(c *cliconfig.EventValues) error {
 			Time:   eTime,
 			Type:   eType,
 		}
+		if returnedEvent.HealthStatus != nil {
+			event.HealthStatus = *returnedEvent.HealthStatus
+		}
 		if c.Format == formats.JSONString {
 			jsonStr, err := event.ToJSONString()
 			if err != nil {
@@ -925,20 +959,41 @@ func stringToChangeType(change string) archive.ChangeType {
 }
 
 // GenerateKube creates kubernetes email from containers and pods
-func (r *LocalRuntime) GenerateKube(c *cliconfig.GenerateKubeValues) (*v1.Pod, *v1.Service, error) {
-	var (
-		pod     v1.Pod
-		service v1.Service
-	)
-	reply, err := iopodman.GenerateKube().Call(r.Conn, c.InputArgs[0], c.Service)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "unable to create kubernetes YAML")
-	}
-	if err := json.Unmarshal([]byte(reply.Pod), &pod); err != nil {
-		return nil, nil, err
+func (r *LocalRuntime) GenerateKube(c *cliconfig.GenerateKubeValues) (*shared.KubeObjects, error) {
+	var objects shared.KubeObjects
+	seenClaims := make(map[string]bool)
+	for _, name := range c.InputArgs {
+		var (
+			pod     v1.Pod
+			service v1.Service
+		)
+		reply, err := iopodman.GenerateKube().Call(r.Conn, name, c.Service)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to create kubernetes YAML")
+		}
+		if err := json.Unmarshal([]byte(reply.Pod), &pod); err != nil {
+			return nil, err
+		}
+		objects.Pods = append(objects.Pods, &pod)
+		if c.Service {
+			if err := json.Unmarshal([]byte(reply.Service), &service); err != nil {
+				return nil, err
+			}
+			objects.Services = append(objects.Services, &service)
+		}
+		for _, claimJSON := range reply.Claims {
+			claim := new(v1.PersistentVolumeClaim)
+			if err := json.Unmarshal([]byte(claimJSON), claim); err != nil {
+				return nil, err
+			}
+			if seenClaims[claim.Name] {
+				continue
+			}
+			seenClaims[claim.Name] = true
+			objects.Claims = append(objects.Claims, claim)
+		}
 	}
-	err = json.Unmarshal([]byte(reply.Service), &service)
-	return &pod, &service, err
+	return &objects, nil
 }
 
 // GetContainersByContext looks up containers based on the cli input of all, latest, or a list
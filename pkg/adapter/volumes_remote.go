@@ -2,6 +2,10 @@
 
 package adapter
 
+import (
+	"github.com/containers/libpod/libpod"
+)
+
 // Name returns the name of the volume
 func (v *Volume) Name() string {
 	return v.config.Name
@@ -31,3 +35,9 @@ func (v *Volume) MountPoint() string {
 func (v *Volume) Scope() string {
 	return v.config.Scope
 }
+
+// Quota returns the size limit and usage of the volume, or nil if it has no
+// size limit
+func (v *Volume) Quota() (*libpod.VolumeQuota, error) {
+	return v.quota, nil
+}
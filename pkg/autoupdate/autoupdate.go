@@ -0,0 +1,279 @@
+package autoupdate
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/containers/image/docker"
+	"github.com/containers/image/docker/reference"
+	"github.com/containers/libpod/libpod"
+	"github.com/containers/libpod/libpod/image"
+	"github.com/containers/libpod/pkg/systemd"
+	"github.com/containers/libpod/pkg/systemdgen"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// dockerTransportPrefix is the optional prefix of image references to the
+// docker transport.
+const dockerTransportPrefix = "docker://"
+
+// Label denotes the container/pod label key to specify auto-update policies in
+// container labels.
+const Label = "io.containers.autoupdate"
+
+// Policy represents an auto-update policy.
+type Policy string
+
+const (
+	// PolicyDefault is the default policy denoting no auto updates.
+	PolicyDefault Policy = "disabled"
+	// PolicyNewImage is the policy to update as soon as there's a new image found.
+	PolicyNewImage Policy = "image"
+)
+
+// Map for easy lookups of supported policies.
+var supportedPolicies = map[string]Policy{
+	"":         PolicyDefault,
+	"disabled": PolicyDefault,
+	"image":    PolicyNewImage,
+}
+
+// LookupPolicy looks up the corresponding Policy for the specified
+// string. If none is found, an error is returned including the list of
+// supported policies.
+//
+// Note that an empty string resolves to PolicyDefault.
+func LookupPolicy(s string) (Policy, error) {
+	policy, exists := supportedPolicies[s]
+	if exists {
+		return policy, nil
+	}
+
+	// Sort the keys first as maps are non-deterministic.
+	keys := []string{}
+	for k := range supportedPolicies {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return "", errors.Errorf("invalid auto-update policy %q: valid policies are %+q", s, keys)
+}
+
+// ValidateImageReference checks if the specified imageName is a fully-qualified
+// image reference to the docker transport (without digest).  Such a reference
+// includes a domain, name and tag (e.g., quay.io/podman/stable:latest).  The
+// tag is required, so the image looked up in the registry does not change with
+// the default tag.  The reference may also be prefixed with "docker://"
+// explicitly indicating that it's a reference to the docker transport.
+func ValidateImageReference(imageName string) error {
+	named, err := reference.ParseNamed(strings.TrimPrefix(imageName, dockerTransportPrefix))
+	if err != nil {
+		return errors.Wrapf(err, "auto updates require fully-qualified image references of the docker transport (e.g., quay.io/podman/stable:latest): %q", imageName)
+	}
+	if _, isDigested := named.(reference.Digested); isDigested {
+		return errors.Errorf("auto updates require fully-qualified image references without digest: %q", imageName)
+	}
+	if _, isTagged := named.(reference.Tagged); !isTagged {
+		return errors.Errorf("auto updates require fully-qualified image references with a tag (e.g., quay.io/podman/stable:latest): %q", imageName)
+	}
+	return nil
+}
+
+// Options are the options of an auto update.
+type Options struct {
+	// Authfile is the path to the authentication file used to talk to
+	// the registries.
+	Authfile string
+	// DryRun only reports which containers would be updated without
+	// pulling images or restarting systemd units.
+	DryRun bool
+}
+
+// Report describes the outcome of checking a container for updates.
+type Report struct {
+	// ContainerID is the ID of the container.
+	ContainerID string
+	// ContainerName is the name of the container.
+	ContainerName string
+	// Image is the fully-qualified image reference the container was
+	// created from.
+	Image string
+	// Unit is the systemd unit running the container.
+	Unit string
+	// Updated is set if a newer image is available in the registry.
+	// Unless running in dry-run mode, it is only set once the new image
+	// has been pulled and the unit restarted.
+	Updated bool
+}
+
+// AutoUpdate looks up containers with a specified auto-update policy and acts
+// accordingly.  If the policy is set to PolicyNewImage, it checks if the image
+// on the remote registry is different than the local one.  If the image digests
+// differ, it pulls the remote image and restarts the systemd unit running the
+// container.
+//
+// It returns a report for each container with an auto-update policy and a
+// slice of errors encountered while checking or updating them.
+func AutoUpdate(runtime *libpod.Runtime, options Options) ([]Report, []error) {
+	// Create a map from `image ID -> []*Container`.
+	containerMap, errs := imageContainersMap(runtime)
+	if len(containerMap) == 0 {
+		return nil, errs
+	}
+
+	// Create a map from `image ID -> *image.Image` for image lookups.
+	imagesSlice, err := runtime.ImageRuntime().GetImages()
+	if err != nil {
+		return nil, []error{err}
+	}
+	imageMap := make(map[string]*image.Image)
+	for i := range imagesSlice {
+		imageMap[imagesSlice[i].ID()] = imagesSlice[i]
+	}
+
+	sys := image.GetSystemContext(runtime.ImageRuntime().SignaturePolicyPath, options.Authfile, false)
+	ctx := context.Background()
+
+	var reports []Report
+	// Update images.
+	for imageID, containers := range containerMap {
+		img, exists := imageMap[imageID]
+		if !exists {
+			errs = append(errs, errors.Errorf("error auto-updating container %q: image ID %q not found in local storage", containers[0].ID(), imageID))
+			continue
+		}
+		// Containers with the same image ID share the image reference
+		// they were created with.
+		_, rawImageName := containers[0].Image()
+		imageRef, err := docker.ParseReference("//" + strings.TrimPrefix(rawImageName, dockerTransportPrefix))
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "error auto-updating container %q: parsing image reference %q", containers[0].ID(), rawImageName))
+			continue
+		}
+		needsUpdate, err := img.HasDifferentDigest(ctx, imageRef, sys)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "error auto-updating container %q: checking image %q", containers[0].ID(), rawImageName))
+			continue
+		}
+
+		var units []string
+		imageReports := make([]Report, 0, len(containers))
+		for _, ctr := range containers {
+			unit := ctr.Labels()[systemdgen.EnvVariable]
+			imageReports = append(imageReports, Report{
+				ContainerID:   ctr.ID(),
+				ContainerName: ctr.Name(),
+				Image:         rawImageName,
+				Unit:          unit,
+				Updated:       needsUpdate,
+			})
+			if unit == "" {
+				errs = append(errs, errors.Errorf("error auto-updating container %q: no %s label found", ctr.ID(), systemdgen.EnvVariable))
+				continue
+			}
+			units = append(units, unit)
+		}
+
+		if needsUpdate && !options.DryRun {
+			logrus.Infof("Auto-updating image %q of containers using it", rawImageName)
+			restarted := make(map[string]bool)
+			if _, err := runtime.ImageRuntime().New(ctx, rawImageName, "", options.Authfile, os.Stderr, &image.DockerRegistryOptions{}, image.SigningOptions{}, true, nil); err != nil {
+				errs = append(errs, errors.Wrapf(err, "error auto-updating image %q", rawImageName))
+			} else {
+				for unit, err := range restartSystemdUnits(units) {
+					if err != nil {
+						errs = append(errs, err)
+						continue
+					}
+					restarted[unit] = true
+				}
+			}
+			// Only report containers as updated once their unit
+			// has been restarted with the new image.
+			for i := range imageReports {
+				imageReports[i].Updated = restarted[imageReports[i].Unit]
+			}
+		}
+		reports = append(reports, imageReports...)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].ContainerName < reports[j].ContainerName
+	})
+	return reports, errs
+}
+
+// restartSystemdUnits restarts the specified systemd units and waits for the
+// jobs to finish.  It returns the outcome of the restart of each unit.
+func restartSystemdUnits(units []string) map[string]error {
+	results := make(map[string]error, len(units))
+	if len(units) == 0 {
+		return results
+	}
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		err = errors.Wrapf(err, "unable to get systemd connection to restart units")
+		for _, unit := range units {
+			results[unit] = err
+		}
+		return results
+	}
+	defer conn.Close()
+
+	for _, unit := range units {
+		if _, done := results[unit]; done {
+			continue
+		}
+		restartChan := make(chan string)
+		if _, err := conn.RestartUnit(unit, "replace", restartChan); err != nil {
+			results[unit] = errors.Wrapf(err, "error restarting systemd unit %q", unit)
+			continue
+		}
+		// Wait for the restart to finish and check its result.
+		if result := <-restartChan; result != "done" {
+			results[unit] = errors.Errorf("error restarting systemd unit %q: job finished with %q", unit, result)
+			continue
+		}
+		logrus.Infof("Successfully restarted systemd unit %q", unit)
+		results[unit] = nil
+	}
+	return results
+}
+
+// imageContainersMap generates a map[image ID] -> [containers using the image]
+// of all containers with a valid auto-update policy.
+func imageContainersMap(runtime *libpod.Runtime) (map[string][]*libpod.Container, []error) {
+	allContainers, err := runtime.GetAllContainers()
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var errs []error
+	imageMap := make(map[string][]*libpod.Container)
+	for i, ctr := range allContainers {
+		// Only update containers with the specific label/policy set.
+		labels := ctr.Labels()
+		value, exists := labels[Label]
+		if !exists {
+			continue
+		}
+		policy, err := LookupPolicy(value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if policy != PolicyNewImage {
+			continue
+		}
+
+		id, _ := ctr.Image()
+		imageMap[id] = append(imageMap[id], allContainers[i])
+	}
+
+	return imageMap, errs
+}
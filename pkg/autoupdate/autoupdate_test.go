@@ -0,0 +1,51 @@
+package autoupdate
+
+import (
+	"testing"
+)
+
+func TestLookupPolicy(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Policy
+		wantErr  bool
+	}{
+		{"", PolicyDefault, false},
+		{"disabled", PolicyDefault, false},
+		{"image", PolicyNewImage, false},
+		{"foobar", "", true},
+		{"Image", "", true},
+	}
+	for _, tt := range tests {
+		policy, err := LookupPolicy(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("LookupPolicy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if policy != tt.expected {
+			t.Errorf("LookupPolicy(%q) = %q, expected %q", tt.input, policy, tt.expected)
+		}
+	}
+}
+
+func TestValidateImageReference(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{"quay.io/podman/stable:latest", false},
+		{"docker://quay.io/podman/stable:latest", false},
+		{"docker.io/library/alpine", true},
+		{"localhost:5000/alpine:latest", false},
+		{"alpine", true},
+		{"alpine:latest", true},
+		{"quay.io/podman/stable@sha256:6e9e3a5e2b4a2b2bee6bd2ba0a1bac7a66e2f27bc9c7d5c72d3db45d9e5e1a71", true},
+		{"oci-archive:/tmp/alpine.tar", true},
+	}
+	for _, tt := range tests {
+		err := ValidateImageReference(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateImageReference(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+	}
+}
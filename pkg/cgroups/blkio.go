@@ -24,7 +24,37 @@ func (c *blkioHandler) Apply(ctr *CgroupControl, res *spec.LinuxResources) error
 	if res.BlockIO == nil {
 		return nil
 	}
-	return fmt.Errorf("blkio apply function not implemented yet")
+	if len(res.BlockIO.WeightDevice) > 0 || len(res.BlockIO.ThrottleReadBpsDevice) > 0 || len(res.BlockIO.ThrottleWriteBpsDevice) > 0 || len(res.BlockIO.ThrottleReadIOPSDevice) > 0 || len(res.BlockIO.ThrottleWriteIOPSDevice) > 0 {
+		return fmt.Errorf("blkio apply of per device limits not implemented yet")
+	}
+	if res.BlockIO.Weight == nil {
+		return nil
+	}
+
+	if ctr.cgroup2 {
+		weight := blkioWeightToIOWeight(*res.BlockIO.Weight)
+		return writeCgroupFile(filepath.Join(cgroupRoot, ctr.path), "io.weight", fmt.Sprintf("default %d", weight))
+	}
+
+	path := ctr.getCgroupv1Path(Blkio)
+	name := "blkio.weight"
+	// Kernels using the BFQ scheduler only have the BFQ weight
+	if _, err := os.Stat(filepath.Join(path, name)); os.IsNotExist(err) {
+		name = "blkio.bfq.weight"
+	}
+	return writeCgroupFile(path, name, strconv.FormatUint(uint64(*res.BlockIO.Weight), 10))
+}
+
+// blkioWeightToIOWeight converts a cgroup v1 blkio weight, in the range
+// [10, 1000], to a cgroup v2 io weight, in the range [1, 10000]
+func blkioWeightToIOWeight(weight uint16) uint64 {
+	if weight < 10 {
+		weight = 10
+	}
+	if weight > 1000 {
+		weight = 1000
+	}
+	return 1 + (uint64(weight)-10)*9999/990
 }
 
 // Create the cgroup
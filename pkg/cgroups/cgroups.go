@@ -258,6 +258,24 @@ func (c *CgroupControl) createCgroupDirectory(controller string) (bool, error) {
 	return true, nil
 }
 
+// writeCgroupFile writes a value to the file name in the cgroup directory dir
+func writeCgroupFile(dir, name, value string) error {
+	p := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(p, []byte(value+"\n"), 0644); err != nil {
+		return errors.Wrapf(err, "write %s", p)
+	}
+	return nil
+}
+
+// limitToString formats a limit for a cgroup file, where a negative limit
+// means no limit
+func limitToString(limit int64) string {
+	if limit < 0 {
+		return "max"
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
 func readFileAsUint64(path string) (uint64, error) {
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -415,6 +433,9 @@ func (c *CgroupControl) DeleteByPath(path string) error {
 
 // Update updates the cgroups
 func (c *CgroupControl) Update(resources *spec.LinuxResources) error {
+	if c.systemd {
+		return systemdUpdate(c.path, resources, c.cgroup2)
+	}
 	for _, h := range handlers {
 		if err := h.Apply(c, resources); err != nil {
 			return err
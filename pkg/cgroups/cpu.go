@@ -55,7 +55,67 @@ func (c *cpuHandler) Apply(ctr *CgroupControl, res *spec.LinuxResources) error {
 	if res.CPU == nil {
 		return nil
 	}
-	return fmt.Errorf("cpu apply not implemented yet")
+
+	if ctr.cgroup2 {
+		path := filepath.Join(cgroupRoot, ctr.path)
+		if res.CPU.Shares != nil {
+			if err := writeCgroupFile(path, "cpu.weight", strconv.FormatUint(cpuSharesToWeight(*res.CPU.Shares), 10)); err != nil {
+				return err
+			}
+		}
+		if res.CPU.Quota != nil || res.CPU.Period != nil {
+			quota := int64(-1)
+			if res.CPU.Quota != nil && *res.CPU.Quota > 0 {
+				quota = *res.CPU.Quota
+			}
+			period := uint64(defaultCPUPeriod)
+			if res.CPU.Period != nil && *res.CPU.Period > 0 {
+				period = *res.CPU.Period
+			}
+			if err := writeCgroupFile(path, "cpu.max", fmt.Sprintf("%s %d", limitToString(quota), period)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path := ctr.getCgroupv1Path(CPU)
+	if res.CPU.Shares != nil {
+		if err := writeCgroupFile(path, "cpu.shares", strconv.FormatUint(*res.CPU.Shares, 10)); err != nil {
+			return err
+		}
+	}
+	// The period must be set first, the quota is validated against it
+	if res.CPU.Period != nil {
+		if err := writeCgroupFile(path, "cpu.cfs_period_us", strconv.FormatUint(*res.CPU.Period, 10)); err != nil {
+			return err
+		}
+	}
+	if res.CPU.Quota != nil {
+		quota := *res.CPU.Quota
+		if quota <= 0 {
+			quota = -1
+		}
+		if err := writeCgroupFile(path, "cpu.cfs_quota_us", strconv.FormatInt(quota, 10)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defaultCPUPeriod is the CFS period used when only a quota is given
+const defaultCPUPeriod = 100000
+
+// cpuSharesToWeight converts cgroup v1 CPU shares, in the range [2, 262144],
+// to a cgroup v2 CPU weight, in the range [1, 10000]
+func cpuSharesToWeight(shares uint64) uint64 {
+	if shares < 2 {
+		shares = 2
+	}
+	if shares > 262144 {
+		shares = 262144
+	}
+	return 1 + ((shares-2)*9999)/262142
 }
 
 // Create the cgroup
@@ -54,10 +54,25 @@ func getCpusetHandler() *cpusetHandler {
 
 // Apply set the specified constraints
 func (c *cpusetHandler) Apply(ctr *CgroupControl, res *spec.LinuxResources) error {
-	if res.CPU == nil {
+	if res.CPU == nil || (res.CPU.Cpus == "" && res.CPU.Mems == "") {
 		return nil
 	}
-	return fmt.Errorf("cpuset apply not implemented yet")
+
+	path := ctr.getCgroupv1Path(CPUset)
+	if ctr.cgroup2 {
+		path = filepath.Join(cgroupRoot, ctr.path)
+	}
+	if res.CPU.Cpus != "" {
+		if err := writeCgroupFile(path, "cpuset.cpus", res.CPU.Cpus); err != nil {
+			return err
+		}
+	}
+	if res.CPU.Mems != "" {
+		if err := writeCgroupFile(path, "cpuset.mems", res.CPU.Mems); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Create the cgroup
@@ -3,6 +3,7 @@ package cgroups
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -19,7 +20,52 @@ func (c *memHandler) Apply(ctr *CgroupControl, res *spec.LinuxResources) error {
 	if res.Memory == nil {
 		return nil
 	}
-	return fmt.Errorf("memory apply not implemented yet")
+
+	if ctr.cgroup2 {
+		path := filepath.Join(cgroupRoot, ctr.path)
+		if res.Memory.Limit != nil {
+			if err := writeCgroupFile(path, "memory.max", limitToString(*res.Memory.Limit)); err != nil {
+				return err
+			}
+		}
+		if res.Memory.Reservation != nil {
+			if err := writeCgroupFile(path, "memory.low", limitToString(*res.Memory.Reservation)); err != nil {
+				return err
+			}
+		}
+		if res.Memory.Swap != nil {
+			// cgroup v2 limits swap alone, the OCI limit covers memory and swap
+			swap := *res.Memory.Swap
+			if swap > 0 && res.Memory.Limit != nil && *res.Memory.Limit > 0 {
+				if swap < *res.Memory.Limit {
+					return fmt.Errorf("memory and swap limit %d is lower than the memory limit %d", swap, *res.Memory.Limit)
+				}
+				swap -= *res.Memory.Limit
+			}
+			if err := writeCgroupFile(path, "memory.swap.max", limitToString(swap)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	path := ctr.getCgroupv1Path(Memory)
+	if res.Memory.Limit != nil {
+		if err := writeCgroupFile(path, "memory.limit_in_bytes", strconv.FormatInt(*res.Memory.Limit, 10)); err != nil {
+			return err
+		}
+	}
+	if res.Memory.Reservation != nil {
+		if err := writeCgroupFile(path, "memory.soft_limit_in_bytes", strconv.FormatInt(*res.Memory.Reservation, 10)); err != nil {
+			return err
+		}
+	}
+	if res.Memory.Swap != nil {
+		if err := writeCgroupFile(path, "memory.memsw.limit_in_bytes", strconv.FormatInt(*res.Memory.Swap, 10)); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Create the cgroup
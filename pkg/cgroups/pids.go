@@ -1,8 +1,6 @@
 package cgroups
 
 import (
-	"fmt"
-	"io/ioutil"
 	"path/filepath"
 
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -28,8 +26,11 @@ func (c *pidHandler) Apply(ctr *CgroupControl, res *spec.LinuxResources) error {
 		PIDRoot = ctr.getCgroupv1Path(Pids)
 	}
 
-	p := filepath.Join(PIDRoot, "pids.max")
-	return ioutil.WriteFile(p, []byte(fmt.Sprintf("%d\n", res.Pids.Limit)), 0644)
+	limit := res.Pids.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	return writeCgroupFile(PIDRoot, "pids.max", limitToString(limit))
 }
 
 // Create the cgroup
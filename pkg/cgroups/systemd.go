@@ -2,15 +2,27 @@ package cgroups
 
 import (
 	"fmt"
+	"math"
 	"path/filepath"
 	"strings"
 
+	"github.com/containers/libpod/pkg/rootless"
 	systemdDbus "github.com/coreos/go-systemd/dbus"
 	"github.com/godbus/dbus"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 )
 
+// systemdConnection connects to the systemd instance managing our cgroups,
+// the user instance when running rootless.
+func systemdConnection() (*systemdDbus.Conn, error) {
+	if rootless.IsRootless() {
+		return systemdDbus.NewUserConnection()
+	}
+	return systemdDbus.New()
+}
+
 func systemdCreate(path string) error {
-	c, err := systemdDbus.New()
+	c, err := systemdConnection()
 	if err != nil {
 		return err
 	}
@@ -74,7 +86,7 @@ func systemdCreate(path string) error {
 */
 
 func systemdDestroy(path string) error {
-	c, err := systemdDbus.New()
+	c, err := systemdConnection()
 	if err != nil {
 		return err
 	}
@@ -90,3 +102,76 @@ func systemdDestroy(path string) error {
 	<-ch
 	return nil
 }
+
+// systemdUpdate sets the resource limits on the systemd unit owning the
+// cgroup at path.
+func systemdUpdate(path string, resources *spec.LinuxResources, cgroup2 bool) error {
+	var properties []systemdDbus.Property
+	addProperty := func(name string, value interface{}) {
+		properties = append(properties, systemdDbus.Property{
+			Name:  name,
+			Value: dbus.MakeVariant(value),
+		})
+	}
+
+	if resources.CPU != nil {
+		if resources.CPU.Shares != nil {
+			if cgroup2 {
+				addProperty("CPUWeight", cpuSharesToWeight(*resources.CPU.Shares))
+			} else {
+				addProperty("CPUShares", *resources.CPU.Shares)
+			}
+		}
+		if resources.CPU.Quota != nil {
+			quota := uint64(math.MaxUint64)
+			if *resources.CPU.Quota > 0 {
+				period := uint64(defaultCPUPeriod)
+				if resources.CPU.Period != nil && *resources.CPU.Period > 0 {
+					period = *resources.CPU.Period
+				}
+				// systemd takes the CPU time allowed per second of wall time
+				quota = uint64(*resources.CPU.Quota) * 1000000 / period
+			}
+			addProperty("CPUQuotaPerSecUSec", quota)
+		}
+		if resources.CPU.Cpus != "" || resources.CPU.Mems != "" {
+			return fmt.Errorf("cpuset limits are not supported with the systemd cgroup manager")
+		}
+	}
+	if resources.Memory != nil && resources.Memory.Limit != nil {
+		limit := uint64(math.MaxUint64)
+		if *resources.Memory.Limit > 0 {
+			limit = uint64(*resources.Memory.Limit)
+		}
+		if cgroup2 {
+			addProperty("MemoryMax", limit)
+		} else {
+			addProperty("MemoryLimit", limit)
+		}
+	}
+	if resources.Pids != nil {
+		limit := uint64(math.MaxUint64)
+		if resources.Pids.Limit > 0 {
+			limit = uint64(resources.Pids.Limit)
+		}
+		addProperty("TasksMax", limit)
+	}
+	if resources.BlockIO != nil && resources.BlockIO.Weight != nil {
+		if cgroup2 {
+			addProperty("IOWeight", blkioWeightToIOWeight(*resources.BlockIO.Weight))
+		} else {
+			addProperty("BlockIOWeight", uint64(*resources.BlockIO.Weight))
+		}
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+
+	c, err := systemdConnection()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	return c.SetUnitProperties(filepath.Base(path), true, properties...)
+}
@@ -0,0 +1,103 @@
+package network
+
+import (
+	"net"
+)
+
+const (
+	// CNIDeviceName is the prefix used for the bridge devices of networks
+	// created by podman. An integer is appended to it (e.g. cni-podman1).
+	CNIDeviceName = "cni-podman"
+	// DefaultDriver is the only network driver currently supported
+	DefaultDriver = "bridge"
+	// DefaultIPAMDriver is the IPAM driver used for podman networks
+	DefaultIPAMDriver = "host-local"
+	// CNIVersion is the version of the CNI spec written to new conflists
+	CNIVersion = "0.4.0"
+)
+
+// SupportedDrivers lists the network drivers podman can create networks with
+var SupportedDrivers = []string{DefaultDriver}
+
+// GetDefaultPodmanNetwork returns the subnet from which podman starts
+// looking for a free subnet when none is given on creation
+func GetDefaultPodmanNetwork() (*net.IPNet, error) {
+	_, n, err := net.ParseCIDR("10.89.0.0/24")
+	return n, err
+}
+
+// CNIPlugins is a way of marshalling a CNI network configuration to disk
+type CNIPlugins interface {
+	Bytes() interface{}
+}
+
+// HostLocalBridge describes a configuration for a bridge plugin
+// https://github.com/containernetworking/plugins/tree/master/plugins/main/bridge#network-configuration-reference
+type HostLocalBridge struct {
+	PluginType   string            `json:"type"`
+	BrName       string            `json:"bridge,omitempty"`
+	IsGW         bool              `json:"isGateway"`
+	IsDefaultGW  bool              `json:"isDefaultGateway,omitempty"`
+	ForceAddress bool              `json:"forceAddress,omitempty"`
+	IPMasq       bool              `json:"ipMasq,omitempty"`
+	MTU          int               `json:"mtu,omitempty"`
+	HairpinMode  bool              `json:"hairpinMode"`
+	PromiscMode  bool              `json:"promiscMode,omitempty"`
+	Vlan         int               `json:"vlan,omitempty"`
+	IPAM         IPAMHostLocalConf `json:"ipam"`
+}
+
+// Bytes outputs []byte
+func (h *HostLocalBridge) Bytes() interface{} {
+	return h
+}
+
+// IPAMHostLocalConf describes an IPAM configuration
+// https://github.com/containernetworking/plugins/tree/master/plugins/ipam/host-local#network-configuration-reference
+type IPAMHostLocalConf struct {
+	PluginType  string                     `json:"type"`
+	Routes      []IPAMRoute                `json:"routes,omitempty"`
+	ResolveConf string                     `json:"resolveConf,omitempty"`
+	DataDir     string                     `json:"dataDir,omitempty"`
+	Ranges      [][]IPAMLocalHostRangeConf `json:"ranges,omitempty"`
+}
+
+// IPAMLocalHostRangeConf describes the new style IPAM ranges
+type IPAMLocalHostRangeConf struct {
+	Subnet     string `json:"subnet"`
+	RangeStart string `json:"rangeStart,omitempty"`
+	RangeEnd   string `json:"rangeEnd,omitempty"`
+	Gateway    string `json:"gateway,omitempty"`
+}
+
+// Bytes outputs the configuration as []byte
+func (i IPAMHostLocalConf) Bytes() interface{} {
+	return i
+}
+
+// IPAMRoute describes a route in an ipam config
+type IPAMRoute struct {
+	Dest string `json:"dst"`
+}
+
+// PortMapConfig describes the default portmapping config
+type PortMapConfig struct {
+	PluginType   string          `json:"type"`
+	Capabilities map[string]bool `json:"capabilities"`
+}
+
+// Bytes outputs the configuration as []byte
+func (p PortMapConfig) Bytes() interface{} {
+	return p
+}
+
+// FirewallConfig describes the firewall plugin
+type FirewallConfig struct {
+	PluginType string `json:"type"`
+	Backend    string `json:"backend"`
+}
+
+// Bytes outputs the configuration as []byte
+func (f FirewallConfig) Bytes() interface{} {
+	return f
+}
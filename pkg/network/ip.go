@@ -0,0 +1,75 @@
+package network
+
+import (
+	"math/big"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+func ipToInt(ip net.IP) *big.Int {
+	if v := ip.To4(); v != nil {
+		return big.NewInt(0).SetBytes(v)
+	}
+	return big.NewInt(0).SetBytes(ip.To16())
+}
+
+func intToIP(i *big.Int, length int) net.IP {
+	b := i.Bytes()
+	if len(b) > length {
+		return nil
+	}
+	ip := make(net.IP, length)
+	copy(ip[length-len(b):], b)
+	return ip
+}
+
+// NextSubnet returns the subnet of the same size that immediately follows
+// the given one
+func NextSubnet(subnet *net.IPNet) (*net.IPNet, error) {
+	if subnet == nil {
+		return nil, errors.New("no subnet given")
+	}
+	ones, bits := subnet.Mask.Size()
+	if bits == 0 {
+		return nil, errors.Errorf("subnet %s has a non-canonical mask", subnet.String())
+	}
+	size := big.NewInt(0).Lsh(big.NewInt(1), uint(bits-ones))
+	next := ipToInt(subnet.IP.Mask(subnet.Mask))
+	next.Add(next, size)
+	ip := intToIP(next, bits/8)
+	if ip == nil {
+		return nil, errors.Errorf("no subnet follows %s", subnet.String())
+	}
+	return &net.IPNet{IP: ip, Mask: subnet.Mask}, nil
+}
+
+// FirstIPInSubnet returns the first usable host address of a subnet
+func FirstIPInSubnet(addr *net.IPNet) (net.IP, error) {
+	ones, bits := addr.Mask.Size()
+	if bits-ones < 2 {
+		return nil, errors.Errorf("subnet %s is too small to hold any addresses", addr.String())
+	}
+	first := ipToInt(addr.IP.Mask(addr.Mask))
+	first.Add(first, big.NewInt(1))
+	return intToIP(first, bits/8), nil
+}
+
+// LastIPInSubnet returns the last usable host address of a subnet, that is
+// the address before the broadcast address
+func LastIPInSubnet(addr *net.IPNet) (net.IP, error) {
+	ones, bits := addr.Mask.Size()
+	if bits-ones < 2 {
+		return nil, errors.Errorf("subnet %s is too small to hold any addresses", addr.String())
+	}
+	last := ipToInt(addr.IP.Mask(addr.Mask))
+	size := big.NewInt(0).Lsh(big.NewInt(1), uint(bits-ones))
+	last.Add(last, size)
+	last.Sub(last, big.NewInt(2))
+	return intToIP(last, bits/8), nil
+}
+
+// NetworkIntersect reports whether two subnets share any addresses
+func NetworkIntersect(n1, n2 *net.IPNet) bool {
+	return n2.Contains(n1.IP) || n1.Contains(n2.IP)
+}
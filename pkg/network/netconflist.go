@@ -0,0 +1,115 @@
+package network
+
+import (
+	"net"
+)
+
+// NcList describes a generic map
+type NcList map[string]interface{}
+
+// NewNcList creates a generic map of values with string
+// keys and adds in version and network name
+func NewNcList(name, cniVersion string) NcList {
+	n := NcList{}
+	n["cniVersion"] = cniVersion
+	n["name"] = name
+	return n
+}
+
+// NewHostLocalBridge creates a new LocalBridge for host-local
+func NewHostLocalBridge(name string, isGateWay, isDefaultGW, ipMasq bool, ipamConf IPAMHostLocalConf) *HostLocalBridge {
+	hostLocalBridge := HostLocalBridge{
+		PluginType:  "bridge",
+		BrName:      name,
+		IPMasq:      ipMasq,
+		HairpinMode: true,
+		IPAM:        ipamConf,
+	}
+	if isGateWay {
+		hostLocalBridge.IsGW = true
+	}
+	if isDefaultGW {
+		hostLocalBridge.IsDefaultGW = true
+	}
+	return &hostLocalBridge
+}
+
+// NewIPAMHostLocalConf creates a new IPAMHostLocal configfuration
+func NewIPAMHostLocalConf(subnet *net.IPNet, routes []IPAMRoute, ipRange *net.IPNet, gw net.IP) (IPAMHostLocalConf, error) {
+	var ipamRanges [][]IPAMLocalHostRangeConf
+	ipamConf := IPAMHostLocalConf{
+		PluginType: DefaultIPAMDriver,
+		Routes:     routes,
+	}
+	IPAMRange, err := newIPAMLocalHostRange(subnet, ipRange, gw)
+	if err != nil {
+		return ipamConf, err
+	}
+	ipamRanges = append(ipamRanges, IPAMRange)
+	ipamConf.Ranges = ipamRanges
+	return ipamConf, nil
+}
+
+func newIPAMLocalHostRange(subnet *net.IPNet, ipRange *net.IPNet, gw net.IP) ([]IPAMLocalHostRangeConf, error) {
+	var ranges []IPAMLocalHostRangeConf
+	hostRange := IPAMLocalHostRangeConf{
+		Subnet: subnet.String(),
+	}
+	// an user provided a range, we add it here
+	if ipRange != nil && ipRange.IP != nil {
+		first, err := FirstIPInSubnet(ipRange)
+		if err != nil {
+			return nil, err
+		}
+		last, err := LastIPInSubnet(ipRange)
+		if err != nil {
+			return nil, err
+		}
+		hostRange.RangeStart = first.String()
+		hostRange.RangeEnd = last.String()
+	}
+	if gw != nil {
+		hostRange.Gateway = gw.String()
+	}
+	ranges = append(ranges, hostRange)
+	return ranges, nil
+}
+
+// NewIPAMRoute creates a new IPAM route configuration
+func NewIPAMRoute(r *net.IPNet) IPAMRoute {
+	return IPAMRoute{Dest: r.String()}
+}
+
+// NewIPAMDefaultRoute creates a new IPAMDefault route of
+// 0.0.0.0/0, or ::/0 for IPv6
+func NewIPAMDefaultRoute(isIPv6 bool) (IPAMRoute, error) {
+	route := "0.0.0.0/0"
+	if isIPv6 {
+		route = "::/0"
+	}
+	_, n, err := net.ParseCIDR(route)
+	if err != nil {
+		return IPAMRoute{}, err
+	}
+	return NewIPAMRoute(n), nil
+}
+
+// NewPortMapPlugin creates a predefined, default portmapping
+// configuration
+func NewPortMapPlugin() PortMapConfig {
+	caps := make(map[string]bool)
+	caps["portMappings"] = true
+	p := PortMapConfig{
+		PluginType:   "portmap",
+		Capabilities: caps,
+	}
+	return p
+}
+
+// NewFirewallPlugin creates a generic firewall plugin
+func NewFirewallPlugin() FirewallConfig {
+	return FirewallConfig{
+		PluginType: "firewall",
+		Backend:    "iptables",
+	}
+}
@@ -0,0 +1,358 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+)
+
+// ErrNetworkNotFound indicates that no CNI network with the given name exists
+var ErrNetworkNotFound = errors.New("no such network")
+
+// cniConfExtensions are the file extensions ocicni loads configurations from
+var cniConfExtensions = []string{".conf", ".conflist", ".json"}
+
+// IsSupportedDriver checks if the user provided driver is supported
+func IsSupportedDriver(driver string) error {
+	for _, d := range SupportedDrivers {
+		if driver == d {
+			return nil
+		}
+	}
+	return errors.Errorf("driver %q is not supported, must be one of %s", driver, strings.Join(SupportedDrivers, ", "))
+}
+
+// LoadCNIConfsFromDir loads all the CNI configurations from a directory.
+// Single network configurations are converted to configuration lists.
+func LoadCNIConfsFromDir(dir string) ([]*libcni.NetworkConfigList, error) {
+	var configs []*libcni.NetworkConfigList
+	files, err := libcni.ConfFiles(dir, cniConfExtensions)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	for _, confFile := range files {
+		conf, err := readConfList(confFile)
+		if err != nil {
+			logrus.Warnf("Error loading CNI config file %s: %v", confFile, err)
+			continue
+		}
+		configs = append(configs, conf)
+	}
+	return configs, nil
+}
+
+func readConfList(confFile string) (*libcni.NetworkConfigList, error) {
+	if strings.HasSuffix(confFile, ".conflist") {
+		return libcni.ConfListFromFile(confFile)
+	}
+	conf, err := libcni.ConfFromFile(confFile)
+	if err != nil {
+		return nil, err
+	}
+	return libcni.ConfListFromConf(conf)
+}
+
+// GetCNIConfigPathByName finds the configuration file of a CNI network by
+// name
+func GetCNIConfigPathByName(dir, name string) (string, error) {
+	files, err := libcni.ConfFiles(dir, cniConfExtensions)
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+	for _, confFile := range files {
+		conf, err := readConfList(confFile)
+		if err != nil {
+			continue
+		}
+		if conf.Name == name {
+			return confFile, nil
+		}
+	}
+	return "", errors.Wrapf(ErrNetworkNotFound, "unable to find network configuration for %s", name)
+}
+
+// ReadRawCNIConfByName reads the raw CNI configuration for a CNI network by
+// name
+func ReadRawCNIConfByName(dir, name string) ([]byte, error) {
+	confFile, err := GetCNIConfigPathByName(dir, name)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(confFile)
+}
+
+// GetCNIPlugins returns the plugin types of a network as a comma separated
+// string
+func GetCNIPlugins(list *libcni.NetworkConfigList) string {
+	var plugins []string
+	for _, plugin := range list.Plugins {
+		plugins = append(plugins, plugin.Network.Type)
+	}
+	return strings.Join(plugins, ",")
+}
+
+// GetDefaultNetworkName returns the name of the network ocicni uses when a
+// container does not request a specific one. The configured name wins if it
+// exists, otherwise the first configuration in lexical order is used.
+func GetDefaultNetworkName(dir, configured string) (string, error) {
+	configs, err := LoadCNIConfsFromDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, conf := range configs {
+		if configured == "" || conf.Name == configured {
+			return conf.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// ipamRanges is the subset of a plugin configuration needed to learn which
+// subnets a network allocates addresses from
+type ipamRanges struct {
+	Bridge string `json:"bridge"`
+	IPAM   struct {
+		Subnet string `json:"subnet"`
+		Ranges [][]struct {
+			Subnet string `json:"subnet"`
+		} `json:"ranges"`
+	} `json:"ipam"`
+}
+
+func parseIPAMRanges(list *libcni.NetworkConfigList) []ipamRanges {
+	var ranges []ipamRanges
+	for _, plugin := range list.Plugins {
+		var r ipamRanges
+		if err := json.Unmarshal(plugin.Bytes, &r); err != nil {
+			logrus.Debugf("unable to parse plugin %s of network %s: %v", plugin.Network.Type, list.Name, err)
+			continue
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// GetNetworkSubnets returns the subnets a network allocates addresses from
+func GetNetworkSubnets(list *libcni.NetworkConfigList) []*net.IPNet {
+	var subnets []*net.IPNet
+	addSubnet := func(s string) {
+		if s == "" {
+			return
+		}
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			logrus.Debugf("network %s has an invalid subnet %q: %v", list.Name, s, err)
+			return
+		}
+		subnets = append(subnets, n)
+	}
+	for _, r := range parseIPAMRanges(list) {
+		addSubnet(r.IPAM.Subnet)
+		for _, rangeSet := range r.IPAM.Ranges {
+			for _, rr := range rangeSet {
+				addSubnet(rr.Subnet)
+			}
+		}
+	}
+	return subnets
+}
+
+// GetNetworksFromFilesystem returns every subnet used by the CNI
+// configurations in dir
+func GetNetworksFromFilesystem(dir string) ([]*net.IPNet, error) {
+	var cniNetworks []*net.IPNet
+	configs, err := LoadCNIConfsFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, conf := range configs {
+		cniNetworks = append(cniNetworks, GetNetworkSubnets(conf)...)
+	}
+	return cniNetworks, nil
+}
+
+// GetBridgeNamesFromFileSystem returns the bridge device names used by the
+// CNI configurations in dir
+func GetBridgeNamesFromFileSystem(dir string) ([]string, error) {
+	var bridgeNames []string
+	configs, err := LoadCNIConfsFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, conf := range configs {
+		for _, r := range parseIPAMRanges(conf) {
+			if r.Bridge != "" {
+				bridgeNames = append(bridgeNames, r.Bridge)
+			}
+		}
+	}
+	return bridgeNames, nil
+}
+
+// getLiveNetworks returns the subnets of the addresses configured on the
+// host's interfaces
+func getLiveNetworks() ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	addrs, err := netlink.AddrList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if addr.IPNet != nil {
+			nets = append(nets, &net.IPNet{IP: addr.IP.Mask(addr.Mask), Mask: addr.Mask})
+		}
+	}
+	return nets, nil
+}
+
+// getUsedNetworks returns all subnets that are either configured in CNI or
+// live on the host
+func getUsedNetworks(dir string) ([]*net.IPNet, error) {
+	networks, err := GetNetworksFromFilesystem(dir)
+	if err != nil {
+		return nil, err
+	}
+	liveNetworks, err := getLiveNetworks()
+	if err != nil {
+		logrus.Debugf("unable to list the host's addresses: %v", err)
+	}
+	return append(networks, liveNetworks...), nil
+}
+
+func networkIsUsed(n *net.IPNet, used []*net.IPNet) bool {
+	for _, u := range used {
+		if NetworkIntersect(n, u) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateUserNetworkIsAvailable returns an error if the subnet requested by
+// the user overlaps with an existing CNI network or a host interface
+func ValidateUserNetworkIsAvailable(dir string, userNet *net.IPNet) error {
+	used, err := getUsedNetworks(dir)
+	if err != nil {
+		return err
+	}
+	for _, u := range used {
+		if NetworkIntersect(userNet, u) {
+			return errors.Errorf("network %s is already being used by %s", userNet.String(), u.String())
+		}
+	}
+	return nil
+}
+
+// GetFreeNetwork looks for a /24 subnet that no CNI network and no host
+// interface is using yet
+func GetFreeNetwork(dir string) (*net.IPNet, error) {
+	used, err := getUsedNetworks(dir)
+	if err != nil {
+		return nil, err
+	}
+	n, err := GetDefaultPodmanNetwork()
+	if err != nil {
+		return nil, err
+	}
+	// walk no further than the end of the 10.0.0.0/8 private range
+	_, limit, err := net.ParseCIDR("10.0.0.0/8")
+	if err != nil {
+		return nil, err
+	}
+	for limit.Contains(n.IP) {
+		if !networkIsUsed(n, used) {
+			return n, nil
+		}
+		if n, err = NextSubnet(n); err != nil {
+			return nil, err
+		}
+	}
+	return nil, errors.New("unable to find a free subnet for the network")
+}
+
+// GetFreeDeviceName returns a bridge device name that is neither used by a
+// CNI configuration nor present on the host
+func GetFreeDeviceName(dir string) (string, error) {
+	bridgeNames, err := GetBridgeNamesFromFileSystem(dir)
+	if err != nil {
+		return "", err
+	}
+	used := make(map[string]bool, len(bridgeNames))
+	for _, name := range bridgeNames {
+		used[name] = true
+	}
+	for i := 0; ; i++ {
+		deviceName := fmt.Sprintf("%s%d", CNIDeviceName, i)
+		if used[deviceName] {
+			continue
+		}
+		if _, err := net.InterfaceByName(deviceName); err == nil {
+			continue
+		}
+		return deviceName, nil
+	}
+}
+
+// WriteCNIConfig writes a new conflist for the network with the given name
+// into dir and returns the path of the file. It refuses to overwrite existing
+// networks.
+func WriteCNIConfig(dir, name string, ncList NcList) (string, error) {
+	if _, err := GetCNIConfigPathByName(dir, name); err == nil {
+		return "", errors.Errorf("the network name %s is already used", name)
+	}
+	b, err := json.MarshalIndent(ncList, "", "   ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", errors.Wrapf(err, "error creating CNI configuration directory %s", dir)
+	}
+	cniPathName := filepath.Join(dir, fmt.Sprintf("%s.conflist", name))
+	if err := ioutil.WriteFile(cniPathName, b, 0644); err != nil {
+		return "", errors.Wrapf(err, "error writing CNI configuration %s", cniPathName)
+	}
+	return cniPathName, nil
+}
+
+// RemoveNetwork removes the CNI configuration of a network by name and the
+// bridge device podman created for it
+func RemoveNetwork(dir, name string) error {
+	cniPath, err := GetCNIConfigPathByName(dir, name)
+	if err != nil {
+		return err
+	}
+	conf, err := readConfList(cniPath)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(cniPath); err != nil {
+		return errors.Wrapf(err, "error removing CNI configuration %s", cniPath)
+	}
+	for _, r := range parseIPAMRanges(conf) {
+		if !strings.HasPrefix(r.Bridge, CNIDeviceName) {
+			continue
+		}
+		link, err := netlink.LinkByName(r.Bridge)
+		if err != nil {
+			// the bridge is only created once a container joins
+			continue
+		}
+		if err := netlink.LinkDel(link); err != nil {
+			logrus.Warnf("unable to remove bridge %s of network %s: %v", r.Bridge, name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package network
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parseCIDR(t *testing.T, cidr string) *net.IPNet {
+	_, n, err := net.ParseCIDR(cidr)
+	assert.NoError(t, err)
+	return n
+}
+
+func TestNextSubnet(t *testing.T) {
+	tests := []struct {
+		subnet string
+		want   string
+	}{
+		{"10.89.0.0/24", "10.89.1.0/24"},
+		{"10.89.255.0/24", "10.90.0.0/24"},
+		{"192.168.0.0/16", "192.169.0.0/16"},
+		{"fd00::/64", "fd00:0:0:1::/64"},
+	}
+	for _, tt := range tests {
+		next, err := NextSubnet(parseCIDR(t, tt.subnet))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, next.String())
+	}
+
+	_, err := NextSubnet(parseCIDR(t, "255.255.255.0/24"))
+	assert.Error(t, err)
+}
+
+func TestFirstAndLastIPInSubnet(t *testing.T) {
+	subnet := parseCIDR(t, "10.89.3.0/24")
+	first, err := FirstIPInSubnet(subnet)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.89.3.1", first.String())
+	last, err := LastIPInSubnet(subnet)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.89.3.254", last.String())
+
+	_, err = FirstIPInSubnet(parseCIDR(t, "10.89.3.1/32"))
+	assert.Error(t, err)
+}
+
+func TestNetworkIntersect(t *testing.T) {
+	assert.True(t, NetworkIntersect(parseCIDR(t, "10.88.0.0/16"), parseCIDR(t, "10.88.3.0/24")))
+	assert.True(t, NetworkIntersect(parseCIDR(t, "10.88.3.0/24"), parseCIDR(t, "10.88.0.0/16")))
+	assert.False(t, NetworkIntersect(parseCIDR(t, "10.88.0.0/16"), parseCIDR(t, "10.89.0.0/24")))
+}
+
+func TestNewIPAMDefaultRoute(t *testing.T) {
+	route, err := NewIPAMDefaultRoute(false)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0.0/0", route.Dest)
+
+	route, err = NewIPAMDefaultRoute(true)
+	assert.NoError(t, err)
+	assert.Equal(t, "::/0", route.Dest)
+}
+
+func TestNetworkConfigsFromDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cni-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ipam, err := NewIPAMHostLocalConf(parseCIDR(t, "10.89.7.0/24"), nil, nil, nil)
+	assert.NoError(t, err)
+	ncList := NewNcList("mynet", CNIVersion)
+	ncList["plugins"] = []CNIPlugins{
+		NewHostLocalBridge(CNIDeviceName+"0", true, false, true, ipam),
+		NewPortMapPlugin(),
+		NewFirewallPlugin(),
+	}
+	path, err := WriteCNIConfig(dir, "mynet", ncList)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "mynet.conflist"), path)
+
+	// a second network of the same name is refused
+	_, err = WriteCNIConfig(dir, "mynet", ncList)
+	assert.Error(t, err)
+
+	configs, err := LoadCNIConfsFromDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, configs, 1)
+	assert.Equal(t, "bridge,portmap,firewall", GetCNIPlugins(configs[0]))
+
+	subnets, err := GetNetworksFromFilesystem(dir)
+	assert.NoError(t, err)
+	assert.Len(t, subnets, 1)
+	assert.Equal(t, "10.89.7.0/24", subnets[0].String())
+
+	bridges, err := GetBridgeNamesFromFileSystem(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{CNIDeviceName + "0"}, bridges)
+
+	name, err := GetDefaultNetworkName(dir, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "mynet", name)
+
+	assert.NoError(t, RemoveNetwork(dir, "mynet"))
+	_, err = GetCNIConfigPathByName(dir, "mynet")
+	assert.Error(t, err)
+}
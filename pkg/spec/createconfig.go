@@ -63,6 +63,7 @@ type CreateConfig struct {
 	CapDrop            []string // cap-drop
 	CidFile            string
 	ConmonPidFile      string
+	CreateCommand      []string // full command line used to create the container
 	Cgroupns           string
 	CgroupParent       string            // cgroup-parent
 	Command            []string          // Full command that will be used
@@ -77,11 +78,13 @@ type CreateConfig struct {
 	ExposedPorts       map[nat.Port]struct{}
 	GroupAdd           []string // group-add
 	HealthCheck        *manifest.Schema2HealthConfig
+	HealthOnFailure    string // health-on-failure
 	NoHosts            bool
 	HostAdd            []string //add-host
 	Hostname           string   //hostname
 	HTTPProxy          bool
 	Init               bool   // init
+	InitCtr            bool   // init-ctr
 	InitPath           string //init-path
 	Image              string
 	ImageID            string
@@ -206,6 +209,9 @@ func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *l
 		logrus.Debugf("adding container to pod %s", c.Pod)
 		options = append(options, runtime.WithPod(pod))
 	}
+	if c.InitCtr {
+		options = append(options, libpod.WithInitCtr())
+	}
 	if len(c.PortBindings) > 0 {
 		portBindings, err = c.CreatePortBindings()
 		if err != nil {
@@ -368,6 +374,23 @@ func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *l
 	if logPath != "" {
 		options = append(options, libpod.WithLogPath(logPath))
 	}
+	logSize, logFiles, logCompress, err := getLoggingRotation(c.LogDriverOpt)
+	if err != nil {
+		return nil, err
+	}
+	if logSize > 0 {
+		options = append(options, libpod.WithLogSize(logSize))
+	}
+	if logFiles > 0 {
+		options = append(options, libpod.WithLogFiles(logFiles))
+	}
+	if logCompress {
+		options = append(options, libpod.WithLogCompress())
+	}
+	syslogAddress, syslogFacility, syslogTag := getLoggingSyslog(c.LogDriverOpt)
+	if syslogAddress != "" || syslogFacility != "" || syslogTag != "" {
+		options = append(options, libpod.WithLogSyslog(syslogAddress, syslogFacility, syslogTag))
+	}
 
 	if c.LogDriver != "" {
 		options = append(options, libpod.WithLogDriver(c.LogDriver))
@@ -390,6 +413,9 @@ func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *l
 	options = append(options, libpod.WithRootFSFromImage(c.ImageID, c.Image, useImageVolumes))
 	options = append(options, libpod.WithSecLabels(c.LabelOpts))
 	options = append(options, libpod.WithConmonPidFile(c.ConmonPidFile))
+	if len(c.CreateCommand) > 0 {
+		options = append(options, libpod.WithCreateCommand(c.CreateCommand))
+	}
 	options = append(options, libpod.WithLabels(c.Labels))
 	options = append(options, libpod.WithUser(c.User))
 	if c.IpcMode.IsHost() {
@@ -414,10 +440,6 @@ func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *l
 	}
 
 	if c.RestartPolicy != "" {
-		if c.RestartPolicy == "unless-stopped" {
-			return nil, errors.Wrapf(define.ErrInvalidArg, "the unless-stopped restart policy is not supported")
-		}
-
 		split := strings.Split(c.RestartPolicy, ":")
 		if len(split) > 1 {
 			numTries, err := strconv.Atoi(split[1])
@@ -442,6 +464,9 @@ func (c *CreateConfig) getContainerCreateOptions(runtime *libpod.Runtime, pod *l
 	if c.HealthCheck != nil {
 		options = append(options, libpod.WithHealthCheck(c.HealthCheck))
 		logrus.Debugf("New container has a health check")
+		if c.HealthOnFailure != "" {
+			options = append(options, libpod.WithHealthCheckOnFailureAction(c.HealthOnFailure))
+		}
 	}
 	return options, nil
 }
@@ -144,6 +144,68 @@ func getLoggingPath(opts []string) string {
 	return ""
 }
 
+// getLoggingRotation returns the maximum size of the log file, the number of
+// log files to keep and whether rotated log files are compressed, from the
+// max-size, max-file and compress log options
+func getLoggingRotation(opts []string) (int64, uint, bool, error) {
+	var (
+		size     int64
+		files    uint
+		compress bool
+	)
+	for _, opt := range opts {
+		arr := strings.SplitN(opt, "=", 2)
+		if len(arr) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(arr[1])
+		switch strings.TrimSpace(arr[0]) {
+		case "max-size":
+			maxSize, err := units.RAMInBytes(value)
+			if err != nil || maxSize <= 0 {
+				return 0, 0, false, fmt.Errorf("invalid max-size log option %q", value)
+			}
+			size = maxSize
+		case "max-file":
+			maxFile, err := strconv.ParseUint(value, 10, 32)
+			if err != nil || maxFile == 0 {
+				return 0, 0, false, fmt.Errorf("invalid max-file log option %q: must be a positive number", value)
+			}
+			files = uint(maxFile)
+		case "compress":
+			c, err := strconv.ParseBool(value)
+			if err != nil {
+				return 0, 0, false, fmt.Errorf("invalid compress log option %q: must be true or false", value)
+			}
+			compress = c
+		}
+	}
+	return size, files, compress, nil
+}
+
+// getLoggingSyslog returns the address of the syslog endpoint, the syslog
+// facility and the tag of the log lines forwarded with the syslog log driver,
+// from the syslog-address, syslog-facility and tag log options
+func getLoggingSyslog(opts []string) (string, string, string) {
+	var address, facility, tag string
+	for _, opt := range opts {
+		arr := strings.SplitN(opt, "=", 2)
+		if len(arr) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(arr[1])
+		switch strings.TrimSpace(arr[0]) {
+		case "syslog-address":
+			address = value
+		case "syslog-facility":
+			facility = value
+		case "tag":
+			tag = value
+		}
+	}
+	return address, facility, tag
+}
+
 // ParseDevice parses device mapping string to a src, dest & permissions string
 func ParseDevice(device string) (string, string, string, error) { //nolint
 	src := ""
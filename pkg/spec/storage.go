@@ -658,6 +658,45 @@ func (config *CreateConfig) getVolumeMounts() (map[string]spec.Mount, map[string
 	return mounts, volumes, nil
 }
 
+// ParsePodVolumes parses the named volumes of a pod, given in the
+// name:ctr-dir[:option] format of the --volume option
+func ParsePodVolumes(volumes []string) ([]*libpod.ContainerNamedVolume, error) {
+	var namedVolumes []*libpod.ContainerNamedVolume
+
+	volumeFormatErr := errors.Errorf("incorrect volume format, should be name:ctr-dir[:option]")
+
+	for _, vol := range volumes {
+		var options []string
+
+		splitVol := strings.Split(vol, ":")
+		if len(splitVol) < 2 || len(splitVol) > 3 {
+			return nil, errors.Wrapf(volumeFormatErr, vol)
+		}
+		if len(splitVol) > 2 {
+			var err error
+			if options, err = parse.ValidateVolumeOpts(strings.Split(splitVol[2], ",")); err != nil {
+				return nil, err
+			}
+		}
+
+		name, dest := splitVol[0], splitVol[1]
+		if name == "" || strings.HasPrefix(name, "/") || strings.HasPrefix(name, ".") {
+			return nil, errors.Errorf("invalid volume %s, pods only support named volumes", vol)
+		}
+		if err := parse.ValidateVolumeCtrDir(dest); err != nil {
+			return nil, err
+		}
+
+		namedVolumes = append(namedVolumes, &libpod.ContainerNamedVolume{
+			Name:    name,
+			Dest:    dest,
+			Options: options,
+		})
+	}
+
+	return namedVolumes, nil
+}
+
 // Get mounts for container's image volumes
 func (config *CreateConfig) getImageVolumes() (map[string]spec.Mount, map[string]*libpod.ContainerNamedVolume, error) {
 	mounts := make(map[string]spec.Mount)
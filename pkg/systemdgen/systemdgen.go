@@ -1,24 +1,107 @@
 package systemdgen
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
-var template = `[Unit]
-Description=%s Podman Container
+// EnvVariable is the environment variable a systemd unit sets to its own
+// name (i.e., %n).  Containers created within the unit record it in a label
+// of the same name, which allows `podman auto-update` to restart the unit.
+const EnvVariable = "PODMAN_SYSTEMD_UNIT"
+
+// ContainerInfo contains data required for generating a container's systemd
+// unit file.
+type ContainerInfo struct {
+	// ServiceName of the systemd service.
+	ServiceName string
+	// Name or ID of the container.
+	ContainerName string
+	// Name or ID of the pod.  Only set for the unit of a pod, which
+	// drives the pod's infra container.
+	PodName string
+	// RestartPolicy of the systemd unit (e.g., no, on-failure, always).
+	RestartPolicy string
+	// PIDFile of the service. Required for forking services. Must point
+	// to the PID of the associated conmon process.
+	PIDFile string
+	// StopTimeout sets the timeout Podman waits before killing the
+	// container during service stop.
+	StopTimeout int
+	// Executable is the path to the podman executable. Will be auto
+	// filled if left empty.
+	Executable string
+	// BoundToServices are the services this service binds to.  Note that
+	// this service runs after them.
+	BoundToServices []string
+	// RequiredServices are services this service requires.  Note that
+	// this service runs before them.
+	RequiredServices []string
+	// CreateCommand is the full command plus arguments of the process the
+	// container has been created with.
+	CreateCommand []string
+	// New controls if a new container is created from the CreateCommand
+	// on each start of the unit instead of starting an existing one.
+	New bool
+	// RunCommand is the command the unit creates and runs the container
+	// with.  It is derived from the CreateCommand if New is set.
+	RunCommand string
+}
+
+// containerTemplate is the template of the unit of a container.  Units of
+// pods drive the infra container and require the units of the containers in
+// the pod, which are bound to it.  Units of new containers create the
+// container on start and remove it on stop.  The IDs of the container and of
+// its conmon process are written to files in the runtime directory of the
+// unit (i.e., %t).
+var containerTemplate = `# {{.ServiceName}}.service
+
+[Unit]
+{{- if .PodName}}
+Description=Podman pod-{{.PodName}}.service
+{{- else}}
+Description={{.ContainerName}} Podman Container
+{{- end}}
+{{- if .BoundToServices}}
+RefuseManualStart=yes
+RefuseManualStop=yes
+BindsTo={{range $index, $value := .BoundToServices}}{{if $index}} {{end}}{{$value}}.service{{end}}
+After={{range $index, $value := .BoundToServices}}{{if $index}} {{end}}{{$value}}.service{{end}}
+{{- end}}
+{{- if .RequiredServices}}
+Requires={{range $index, $value := .RequiredServices}}{{if $index}} {{end}}{{$value}}.service{{end}}
+Before={{range $index, $value := .RequiredServices}}{{if $index}} {{end}}{{$value}}.service{{end}}
+{{- end}}
+
 [Service]
-Restart=%s
-ExecStart=%s start %s
-ExecStop=%s stop -t %d %s
+{{- if .New}}
+Environment={{.EnvVariable}}=%n
+{{- end}}
+Restart={{.RestartPolicy}}
+{{- if .New}}
+ExecStartPre=/bin/rm -f %t/%n-pid %t/%n-cid
+ExecStart={{.RunCommand}}
+ExecStop={{.Executable}} stop --ignore --cidfile %t/%n-cid -t {{.StopTimeout}}
+ExecStopPost={{.Executable}} rm --ignore -f --cidfile %t/%n-cid
+{{- else}}
+ExecStart={{.Executable}} start {{.ContainerName}}
+ExecStop={{.Executable}} stop -t {{.StopTimeout}} {{.ContainerName}}
+{{- end}}
 KillMode=none
 Type=forking
-PIDFile=%s
+PIDFile={{.PIDFile}}
+
 [Install]
-WantedBy=multi-user.target`
+WantedBy=multi-user.target
+`
 
 var restartPolicies = []string{"no", "on-success", "on-failure", "on-abnormal", "on-watchdog", "on-abort", "always"}
 
@@ -32,20 +115,182 @@ func ValidateRestartPolicy(restart string) error {
 	return errors.Errorf("%s is not a valid restart policy", restart)
 }
 
-// CreateSystemdUnitAsString takes variables to create a systemd unit file used to control
-// a libpod container
-func CreateSystemdUnitAsString(name, cid, restart, pidFile string, stopTimeout int) (string, error) {
-	podmanExe := getPodmanExecutable()
-	return createSystemdUnitAsString(podmanExe, name, cid, restart, pidFile, stopTimeout)
-}
+// CreateContainerSystemdUnit creates a systemd unit file for a container.
+// If generateFiles is set, the unit is written to a file named after the
+// service in the current working directory and the path of the file is
+// returned.  Otherwise, the content of the unit is returned.
+func CreateContainerSystemdUnit(info *ContainerInfo, generateFiles bool) (string, error) {
+	if err := ValidateRestartPolicy(info.RestartPolicy); err != nil {
+		return "", err
+	}
+
+	// Make sure the executable is set.
+	if info.Executable == "" {
+		info.Executable = getPodmanExecutable()
+	}
 
-func createSystemdUnitAsString(exe, name, cid, restart, pidFile string, stopTimeout int) (string, error) {
-	if err := ValidateRestartPolicy(restart); err != nil {
+	if info.New {
+		runCommand, err := createRunCommand(info.Executable, info.CreateCommand)
+		if err != nil {
+			return "", err
+		}
+		info.RunCommand = runCommand
+		info.PIDFile = "%t/%n-pid"
+	}
+
+	templ, err := template.New("systemd_template").Parse(containerTemplate)
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing systemd service template")
+	}
+
+	var buf bytes.Buffer
+	data := struct {
+		*ContainerInfo
+		EnvVariable string
+	}{info, EnvVariable}
+	if err := templ.Execute(&buf, data); err != nil {
 		return "", err
 	}
 
-	unit := fmt.Sprintf(template, name, restart, exe, name, exe, stopTimeout, name, pidFile)
-	return unit, nil
+	if !generateFiles {
+		return buf.String(), nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", errors.Wrap(err, "error getting current working directory")
+	}
+	path := filepath.Join(cwd, fmt.Sprintf("%s.service", info.ServiceName))
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return "", errors.Wrapf(err, "error writing systemd unit %s", path)
+	}
+	return path, nil
+}
+
+// createRunCommand turns the command a container has been created with into
+// a command running a new container detached, which writes the IDs of the
+// container and its conmon process to the runtime directory of the unit.
+// Global options of the original command are preserved.
+func createRunCommand(executable string, createCommand []string) (string, error) {
+	if len(createCommand) == 0 || !strings.HasPrefix(filepath.Base(createCommand[0]), "podman") {
+		return "", errors.Errorf("container was not created with podman create or podman run, cannot create it from its create command: %v", createCommand)
+	}
+	index := -1
+	for i, arg := range createCommand {
+		if i > 0 && (arg == "create" || arg == "run") {
+			index = i
+			break
+		}
+	}
+	if index < 0 || index == len(createCommand)-1 {
+		return "", errors.Errorf("container was not created with podman create or podman run, cannot create it from its create command: %v", createCommand)
+	}
+
+	command := []string{executable}
+	command = append(command, createCommand[1:index]...)
+	command = append(command,
+		"run",
+		"--conmon-pidfile", "%t/%n-pid",
+		"--cidfile", "%t/%n-cid",
+		"-d",
+	)
+	args := createCommand[index+1:]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		// the image and the command of the container follow the options
+		if arg == "--" || !strings.HasPrefix(arg, "-") {
+			command = append(command, args[i:]...)
+			break
+		}
+		// the unit decides where the IDs are written to
+		if arg == "--cidfile" || arg == "--conmon-pidfile" {
+			i++
+			continue
+		}
+		if strings.HasPrefix(arg, "--cidfile=") || strings.HasPrefix(arg, "--conmon-pidfile=") {
+			continue
+		}
+		command = append(command, arg)
+		if optionTakesValue(arg) && i+1 < len(args) {
+			i++
+			command = append(command, args[i])
+		}
+	}
+
+	escaped := make([]string, 0, len(command))
+	for i, arg := range command {
+		// the arguments added above contain specifiers for systemd
+		if i > 0 && strings.HasPrefix(arg, "%t/%n-") {
+			escaped = append(escaped, arg)
+			continue
+		}
+		escaped = append(escaped, escapeSystemdArgument(arg))
+	}
+	return strings.Join(escaped, " "), nil
+}
+
+// boolOptions are the options of podman create and podman run that do not
+// take a value, all other options are followed by their value unless it is
+// given with "=".
+var boolOptions = map[string]bool{
+	"--detach":           true,
+	"--env-host":         true,
+	"--help":             true,
+	"--http-proxy":       true,
+	"--init":             true,
+	"--init-ctr":         true,
+	"--interactive":      true,
+	"--no-hosts":         true,
+	"--oom-kill-disable": true,
+	"--privileged":       true,
+	"--publish-all":      true,
+	"--quiet":            true,
+	"--read-only":        true,
+	"--read-only-tmpfs":  true,
+	"--rm":               true,
+	"--rootfs":           true,
+	"--sig-proxy":        true,
+	"--syslog":           true,
+	"--systemd":          true,
+	"--trace":            true,
+	"--tty":              true,
+}
+
+// boolShortOptions are the shorthands of boolOptions
+const boolShortOptions = "dPiqt"
+
+// optionTakesValue returns whether the option of podman create or podman run
+// is followed by its value as a separate argument
+func optionTakesValue(arg string) bool {
+	if strings.Contains(arg, "=") {
+		return false
+	}
+	if strings.HasPrefix(arg, "--") {
+		return !boolOptions[arg]
+	}
+	// shorthands can be combined, only the last one may take a value
+	// which is either attached or the next argument
+	for i, c := range arg[1:] {
+		if !strings.ContainsRune(boolShortOptions, c) {
+			return i == len(arg)-2
+		}
+	}
+	return false
+}
+
+// escapeSystemdArgument escapes an argument of a command line in a unit file,
+// so that neither specifiers nor environment variables are expanded and
+// arguments containing whitespace or quotes are kept together.
+func escapeSystemdArgument(arg string) string {
+	arg = strings.Replace(arg, "%", "%%", -1)
+	arg = strings.Replace(arg, "$", "$$", -1)
+	if arg != "" && !strings.ContainsAny(arg, " \t\n\"'\\;") {
+		return arg
+	}
+	arg = strings.Replace(arg, "\\", "\\\\", -1)
+	arg = strings.Replace(arg, "\"", "\\\"", -1)
+	arg = strings.Replace(arg, "\n", "\\n", -1)
+	return "\"" + arg + "\""
 }
 
 func getPodmanExecutable() string {
@@ -1,6 +1,7 @@
 package systemdgen
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -32,9 +33,12 @@ func TestValidateRestartPolicy(t *testing.T) {
 	}
 }
 
-func TestCreateSystemdUnitAsString(t *testing.T) {
-	goodID := `[Unit]
+func TestCreateContainerSystemdUnit(t *testing.T) {
+	goodID := `# container-639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401.service
+
+[Unit]
 Description=639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401 Podman Container
+
 [Service]
 Restart=always
 ExecStart=/usr/bin/podman start 639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401
@@ -42,11 +46,16 @@ ExecStop=/usr/bin/podman stop -t 10 639c53578af4d84b8800b4635fa4e680ee80fd67e0e6
 KillMode=none
 Type=forking
 PIDFile=/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid
+
 [Install]
-WantedBy=multi-user.target`
+WantedBy=multi-user.target
+`
 
-	goodName := `[Unit]
+	goodName := `# container-foobar.service
+
+[Unit]
 Description=foobar Podman Container
+
 [Service]
 Restart=always
 ExecStart=/usr/bin/podman start foobar
@@ -54,70 +63,242 @@ ExecStop=/usr/bin/podman stop -t 10 foobar
 KillMode=none
 Type=forking
 PIDFile=/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid
+
 [Install]
-WantedBy=multi-user.target`
+WantedBy=multi-user.target
+`
+
+	goodNameBoundTo := `# container-foobar.service
+
+[Unit]
+Description=foobar Podman Container
+RefuseManualStart=yes
+RefuseManualStop=yes
+BindsTo=pod-a.service pod-b.service
+After=pod-a.service pod-b.service
+
+[Service]
+Restart=always
+ExecStart=/usr/bin/podman start foobar
+ExecStop=/usr/bin/podman stop -t 10 foobar
+KillMode=none
+Type=forking
+PIDFile=/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid
+
+[Install]
+WantedBy=multi-user.target
+`
+
+	podGoodName := `# pod-123abc.service
+
+[Unit]
+Description=Podman pod-123abc.service
+Requires=container-1.service container-2.service
+Before=container-1.service container-2.service
+
+[Service]
+Restart=always
+ExecStart=/usr/bin/podman start jadda-jadda-infra
+ExecStop=/usr/bin/podman stop -t 10 jadda-jadda-infra
+KillMode=none
+Type=forking
+PIDFile=/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid
+
+[Install]
+WantedBy=multi-user.target
+`
+
+	goodNameNew := `# container-jadda-jadda.service
+
+[Unit]
+Description=jadda-jadda Podman Container
+
+[Service]
+Environment=PODMAN_SYSTEMD_UNIT=%n
+Restart=always
+ExecStartPre=/bin/rm -f %t/%n-pid %t/%n-cid
+ExecStart=/usr/bin/podman run --conmon-pidfile %t/%n-pid --cidfile %t/%n-cid -d --name jadda-jadda --hostname hello-world awesome-image:latest command arg1 ... argN
+ExecStop=/usr/bin/podman stop --ignore --cidfile %t/%n-cid -t 42
+ExecStopPost=/usr/bin/podman rm --ignore -f --cidfile %t/%n-cid
+KillMode=none
+Type=forking
+PIDFile=%t/%n-pid
+
+[Install]
+WantedBy=multi-user.target
+`
 
-	type args struct {
-		exe         string
-		name        string
-		cid         string
-		restart     string
-		pidFile     string
-		stopTimeout int
-	}
 	tests := []struct {
 		name    string
-		args    args
+		info    ContainerInfo
 		want    string
 		wantErr bool
 	}{
 
 		{"good with id",
-			args{
-				"/usr/bin/podman",
-				"639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401",
-				"639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401",
-				"always",
-				"/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid",
-				10,
+			ContainerInfo{
+				Executable:    "/usr/bin/podman",
+				ServiceName:   "container-639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401",
+				ContainerName: "639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401",
+				RestartPolicy: "always",
+				PIDFile:       "/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid",
+				StopTimeout:   10,
 			},
 			goodID,
 			false,
 		},
 		{"good with name",
-			args{
-				"/usr/bin/podman",
-				"foobar",
-				"639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401",
-				"always",
-				"/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid",
-				10,
+			ContainerInfo{
+				Executable:    "/usr/bin/podman",
+				ServiceName:   "container-foobar",
+				ContainerName: "foobar",
+				RestartPolicy: "always",
+				PIDFile:       "/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid",
+				StopTimeout:   10,
 			},
 			goodName,
 			false,
 		},
+		{"good with name and bound to",
+			ContainerInfo{
+				Executable:      "/usr/bin/podman",
+				ServiceName:     "container-foobar",
+				ContainerName:   "foobar",
+				RestartPolicy:   "always",
+				PIDFile:         "/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid",
+				StopTimeout:     10,
+				BoundToServices: []string{"pod-a", "pod-b"},
+			},
+			goodNameBoundTo,
+			false,
+		},
+		{"pod",
+			ContainerInfo{
+				Executable:       "/usr/bin/podman",
+				ServiceName:      "pod-123abc",
+				ContainerName:    "jadda-jadda-infra",
+				PodName:          "123abc",
+				RestartPolicy:    "always",
+				PIDFile:          "/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid",
+				StopTimeout:      10,
+				RequiredServices: []string{"container-1", "container-2"},
+			},
+			podGoodName,
+			false,
+		},
+		{"good with name and new",
+			ContainerInfo{
+				Executable:    "/usr/bin/podman",
+				ServiceName:   "container-jadda-jadda",
+				ContainerName: "jadda-jadda",
+				RestartPolicy: "always",
+				PIDFile:       "/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid",
+				StopTimeout:   42,
+				New:           true,
+				CreateCommand: []string{"/usr/bin/podman", "container", "run", "--name", "jadda-jadda", "--cidfile", "/tmp/cid", "--hostname", "hello-world", "awesome-image:latest", "command", "arg1", "...", "argN"},
+			},
+			strings.Replace(goodNameNew, "podman run", "podman container run", 1),
+			false,
+		},
+		{"new with invalid create command",
+			ContainerInfo{
+				Executable:    "/usr/bin/podman",
+				ServiceName:   "container-jadda-jadda",
+				ContainerName: "jadda-jadda",
+				RestartPolicy: "always",
+				StopTimeout:   42,
+				New:           true,
+				CreateCommand: []string{"podman", "ps"},
+			},
+			"",
+			true,
+		},
 		{"bad restart policy",
-			args{
-				"/usr/bin/podman",
-				"639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401",
-				"639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401",
-				"never",
-				"/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid",
-				10,
+			ContainerInfo{
+				Executable:    "/usr/bin/podman",
+				ServiceName:   "container-639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401",
+				ContainerName: "639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401",
+				RestartPolicy: "never",
+				PIDFile:       "/var/run/containers/storage/overlay-containers/639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401/userdata/conmon.pid",
+				StopTimeout:   10,
 			},
 			"",
 			true,
 		},
 	}
 	for _, tt := range tests {
+		test := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CreateContainerSystemdUnit(&test.info, false)
+			if (err != nil) != test.wantErr {
+				t.Errorf("CreateContainerSystemdUnit() error = %v, wantErr %v", err, test.wantErr)
+				return
+			}
+			if got != test.want {
+				t.Errorf("CreateContainerSystemdUnit() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCreateRunCommand(t *testing.T) {
+	tests := []struct {
+		name          string
+		createCommand []string
+		want          string
+		wantErr       bool
+	}{
+		{"create becomes run",
+			[]string{"podman", "create", "alpine", "top"},
+			"/usr/bin/podman run --conmon-pidfile %t/%n-pid --cidfile %t/%n-cid -d alpine top",
+			false,
+		},
+		{"global options are kept",
+			[]string{"podman", "--log-level", "debug", "run", "-d", "alpine"},
+			"/usr/bin/podman --log-level debug run --conmon-pidfile %t/%n-pid --cidfile %t/%n-cid -d -d alpine",
+			false,
+		},
+		{"pid and cid files are replaced",
+			[]string{"podman", "run", "--conmon-pidfile", "/tmp/pid", "--cidfile=/tmp/cid", "alpine"},
+			"/usr/bin/podman run --conmon-pidfile %t/%n-pid --cidfile %t/%n-cid -d alpine",
+			false,
+		},
+		{"arguments of the container are kept",
+			[]string{"podman", "run", "--rm", "-it", "--name", "foo", "-e=A=1", "-v/a:/b", "alpine", "podman", "run", "--cidfile", "/tmp/cid", "busybox"},
+			"/usr/bin/podman run --conmon-pidfile %t/%n-pid --cidfile %t/%n-cid -d --rm -it --name foo -e=A=1 -v/a:/b alpine podman run --cidfile /tmp/cid busybox",
+			false,
+		},
+		{"options end at --",
+			[]string{"podman", "run", "--cidfile", "/tmp/cid", "--", "alpine", "--cidfile=/tmp/cid"},
+			"/usr/bin/podman run --conmon-pidfile %t/%n-pid --cidfile %t/%n-cid -d -- alpine --cidfile=/tmp/cid",
+			false,
+		},
+		{"arguments are escaped",
+			[]string{"podman", "run", "-e", "FOO=$HOME", "alpine", "sh", "-c", "echo 100% done"},
+			"/usr/bin/podman run --conmon-pidfile %t/%n-pid --cidfile %t/%n-cid -d -e FOO=$$HOME alpine sh -c \"echo 100%% done\"",
+			false,
+		},
+		{"no image",
+			[]string{"podman", "run"},
+			"",
+			true,
+		},
+		{"not a podman command",
+			[]string{"docker", "run", "alpine"},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		test := tt
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := createSystemdUnitAsString(tt.args.exe, tt.args.name, tt.args.cid, tt.args.restart, tt.args.pidFile, tt.args.stopTimeout)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("CreateSystemdUnitAsString() error = %v, wantErr %v", err, tt.wantErr)
+			got, err := createRunCommand("/usr/bin/podman", test.createCommand)
+			if (err != nil) != test.wantErr {
+				t.Errorf("createRunCommand() error = %v, wantErr %v", err, test.wantErr)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("CreateSystemdUnitAsString() = %v, want %v", got, tt.want)
+			if got != test.want {
+				t.Errorf("createRunCommand() = %v, want %v", got, test.want)
 			}
 		})
 	}
@@ -701,21 +701,15 @@ func (i *LibpodAPI) Spec(call iopodman.VarlinkCall, name string) error {
 }
 
 // GetContainersLogs is the varlink endpoint to obtain one or more container logs
-func (i *LibpodAPI) GetContainersLogs(call iopodman.VarlinkCall, names []string, follow, latest bool, since string, tail int64, timestamps bool) error {
+func (i *LibpodAPI) GetContainersLogs(call iopodman.VarlinkCall, names []string, follow, latest bool, since string, tail int64, timestamps bool, until, stream string) error {
 	var wg sync.WaitGroup
 	if call.WantsMore() {
 		call.Continues = true
 	}
-	sinceTime, err := time.Parse(time.RFC3339Nano, since)
+	options, err := newLogOptions(follow, since, tail, timestamps, until, stream)
 	if err != nil {
 		return call.ReplyErrorOccurred(err.Error())
 	}
-	options := logs.LogOptions{
-		Follow:     follow,
-		Since:      sinceTime,
-		Tail:       uint64(tail),
-		Timestamps: timestamps,
-	}
 
 	options.WaitGroup = &wg
 	if len(names) > 1 {
@@ -726,7 +720,7 @@ func (i *LibpodAPI) GetContainersLogs(call iopodman.VarlinkCall, names []string,
 	if err != nil {
 		return call.ReplyErrorOccurred(err.Error())
 	}
-	if err := i.Runtime.Log(containers, &options, logChannel); err != nil {
+	if err := i.Runtime.Log(containers, options, logChannel); err != nil {
 		return err
 	}
 	go func() {
@@ -743,6 +737,39 @@ func (i *LibpodAPI) GetContainersLogs(call iopodman.VarlinkCall, names []string,
 	return call.ReplyGetContainersLogs(iopodman.LogLine{})
 }
 
+// newLogOptions returns the options to read logs with from the arguments of
+// the varlink logs endpoints
+func newLogOptions(follow bool, since string, tail int64, timestamps bool, until, stream string) (*logs.LogOptions, error) {
+	var (
+		sinceTime time.Time
+		untilTime time.Time
+		err       error
+	)
+	if since != "" {
+		sinceTime, err = time.Parse(time.RFC3339Nano, since)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if until != "" {
+		untilTime, err = time.Parse(time.RFC3339Nano, until)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if stream != "" && stream != "stdout" && stream != "stderr" {
+		return nil, errors.Errorf("invalid stream %q: must be stdout or stderr", stream)
+	}
+	return &logs.LogOptions{
+		Follow:     follow,
+		Since:      sinceTime,
+		Until:      untilTime,
+		Stream:     stream,
+		Tail:       uint64(tail),
+		Timestamps: timestamps,
+	}, nil
+}
+
 func newPodmanLogLine(line *logs.LogLine) iopodman.LogLine {
 	return iopodman.LogLine{
 		Device:       line.Device,
@@ -750,6 +777,7 @@ func newPodmanLogLine(line *logs.LogLine) iopodman.LogLine {
 		Time:         line.Time.Format(time.RFC3339Nano),
 		Msg:          line.Msg,
 		Cid:          line.CID,
+		Cname:        line.CName,
 	}
 }
 
@@ -39,14 +39,18 @@ func (i *LibpodAPI) GetEvents(call iopodman.VarlinkCall, filter []string, since
 			call.Continues = false
 			break
 		}
-		call.ReplyGetEvents(iopodman.Event{
+		varlinkEvent := iopodman.Event{
 			Id:     event.ID,
 			Image:  event.Image,
 			Name:   event.Name,
 			Status: fmt.Sprintf("%s", event.Status),
 			Time:   event.Time.Format(time.RFC3339Nano),
 			Type:   fmt.Sprintf("%s", event.Type),
-		})
+		}
+		if event.HealthStatus != "" {
+			varlinkEvent.HealthStatus = &event.HealthStatus
+		}
+		call.ReplyGetEvents(varlinkEvent)
 		if !call.Continues {
 			// For a one-shot on events, we break out here
 			break
@@ -6,45 +6,50 @@ import (
 	"encoding/json"
 	"github.com/containers/libpod/cmd/podman/shared"
 	iopodman "github.com/containers/libpod/cmd/podman/varlink"
-	"github.com/containers/libpod/pkg/systemdgen"
+	v1 "k8s.io/api/core/v1"
 )
 
 // GenerateKube ...
 func (i *LibpodAPI) GenerateKube(call iopodman.VarlinkCall, name string, service bool) error {
-	pod, serv, err := shared.GenerateKube(name, service, i.Runtime)
+	objects, err := shared.GenerateKube([]string{name}, service, i.Runtime)
 	if err != nil {
 		return call.ReplyErrorOccurred(err.Error())
 	}
-	podB, err := json.Marshal(pod)
+	podB, err := json.Marshal(objects.Pods[0])
 	if err != nil {
 		return call.ReplyErrorOccurred(err.Error())
 	}
+	serv := &v1.Service{}
+	if service {
+		serv = objects.Services[0]
+	}
 	servB, err := json.Marshal(serv)
 	if err != nil {
 		return call.ReplyErrorOccurred(err.Error())
 	}
+	claims := make([]string, 0, len(objects.Claims))
+	for _, claim := range objects.Claims {
+		claimB, err := json.Marshal(claim)
+		if err != nil {
+			return call.ReplyErrorOccurred(err.Error())
+		}
+		claims = append(claims, string(claimB))
+	}
 
 	return call.ReplyGenerateKube(iopodman.KubePodService{
 		Pod:     string(podB),
 		Service: string(servB),
+		Claims:  claims,
 	})
 }
 
 // GenerateSystemd ...
 func (i *LibpodAPI) GenerateSystemd(call iopodman.VarlinkCall, nameOrID, restart string, stopTimeout int64, useName bool) error {
-	ctr, err := i.Runtime.LookupContainer(nameOrID)
-	if err != nil {
-		return call.ReplyErrorOccurred(err.Error())
-	}
-	timeout := int(ctr.StopTimeout())
-	if stopTimeout >= 0 {
-		timeout = int(stopTimeout)
-	}
-	name := ctr.ID()
-	if useName {
-		name = ctr.Name()
-	}
-	unit, err := systemdgen.CreateSystemdUnitAsString(name, ctr.ID(), restart, ctr.Config().StaticDir, timeout)
+	unit, err := shared.GenerateSystemd(i.Runtime, nameOrID, shared.GenerateSystemdOptions{
+		Name:          useName,
+		RestartPolicy: restart,
+		StopTimeout:   int(stopTimeout),
+	})
 	if err != nil {
 		return call.ReplyErrorOccurred(err.Error())
 	}
@@ -6,11 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/containers/libpod/pkg/adapter/shortcuts"
+	"sync"
 	"syscall"
 
 	"github.com/containers/libpod/cmd/podman/shared"
 	"github.com/containers/libpod/cmd/podman/varlink"
 	"github.com/containers/libpod/libpod"
+	"github.com/containers/libpod/libpod/logs"
 )
 
 // CreatePod ...
@@ -51,6 +53,63 @@ func (i *LibpodAPI) CreatePod(call iopodman.VarlinkCall, create iopodman.PodCrea
 		}
 		options = append(options, nsOptions...)
 	}
+	var (
+		cpus                float64
+		memory, blkioWeight string
+		pidsLimit           int64
+	)
+	if create.Cpus != nil {
+		cpus = *create.Cpus
+	}
+	if create.Memory != nil {
+		memory = *create.Memory
+	}
+	if create.PidsLimit != nil {
+		pidsLimit = *create.PidsLimit
+	}
+	if create.BlkioWeight != nil {
+		blkioWeight = *create.BlkioWeight
+	}
+	var (
+		hostname                string
+		dns, dnsSearch, addHost []string
+		noHosts                 bool
+	)
+	if create.Hostname != nil {
+		hostname = *create.Hostname
+	}
+	if create.Dns != nil {
+		dns = *create.Dns
+	}
+	if create.DnsSearch != nil {
+		dnsSearch = *create.DnsSearch
+	}
+	if create.AddHost != nil {
+		addHost = *create.AddHost
+	}
+	if create.NoHosts != nil {
+		noHosts = *create.NoHosts
+	}
+	if !create.Infra && (hostname != "" || len(dns) > 0 || len(dnsSearch) > 0 || len(addHost) > 0 || noHosts) {
+		return call.ReplyErrorOccurred("you must have an infra container to set the hostname, DNS or hosts configuration of the pod")
+	}
+	networkOptions, err := shared.GetPodNetworkOptions(hostname, dns, dnsSearch, addHost, noHosts)
+	if err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	options = append(options, networkOptions...)
+	if create.Volumes != nil {
+		volumeOptions, err := shared.GetPodVolumeOptions(*create.Volumes)
+		if err != nil {
+			return call.ReplyErrorOccurred(err.Error())
+		}
+		options = append(options, volumeOptions...)
+	}
+	resourceOptions, err := shared.GetPodResourceOptions(cpus, memory, pidsLimit, blkioWeight)
+	if err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	options = append(options, resourceOptions...)
 	options = append(options, libpod.WithPodCgroups())
 
 	pod, err := i.Runtime.NewPod(getContext(), options...)
@@ -350,3 +409,46 @@ func (i *LibpodAPI) TopPod(call iopodman.VarlinkCall, name string, latest bool,
 	}
 	return call.ReplyTopPod(reply)
 }
+
+// GetPodLogs is the varlink endpoint to obtain the logs of the containers in a pod
+func (i *LibpodAPI) GetPodLogs(call iopodman.VarlinkCall, name string, latest, follow bool, since string, tail int64, timestamps bool, until, stream string) error {
+	var (
+		pod *libpod.Pod
+		err error
+		wg  sync.WaitGroup
+	)
+	if call.WantsMore() {
+		call.Continues = true
+	}
+	options, err := newLogOptions(follow, since, tail, timestamps, until, stream)
+	if err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	if latest {
+		name = "latest"
+		pod, err = i.Runtime.GetLatestPod()
+	} else {
+		pod, err = i.Runtime.LookupPod(name)
+	}
+	if err != nil {
+		return call.ReplyPodNotFound(name, err.Error())
+	}
+
+	options.WaitGroup = &wg
+	options.Multi = true
+	logChannel := make(chan *logs.LogLine, int(tail)+1)
+	if err := pod.ReadLog(options, logChannel); err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	go func() {
+		wg.Wait()
+		close(logChannel)
+	}()
+	for line := range logChannel {
+		call.ReplyGetPodLogs(newPodmanLogLine(line))
+		if !call.Continues {
+			break
+		}
+	}
+	return call.ReplyGetPodLogs(iopodman.LogLine{})
+}
@@ -3,8 +3,12 @@
 package varlinkapi
 
 import (
+	"io/ioutil"
+	"os"
+
 	"github.com/containers/libpod/cmd/podman/varlink"
 	"github.com/containers/libpod/libpod"
+	"github.com/sirupsen/logrus"
 )
 
 // VolumeCreate creates a libpod volume based on input from a varlink connection
@@ -47,6 +51,9 @@ func (i *LibpodAPI) GetVolumes(call iopodman.VarlinkCall, args []string, all boo
 		volumes []iopodman.Volume
 	)
 	if all {
+		if err := i.Runtime.AddPluginVolumes(); err != nil {
+			return call.ReplyErrorOccurred(err.Error())
+		}
 		reply, err = i.Runtime.GetAllVolumes()
 	} else {
 		for _, v := range args {
@@ -70,6 +77,19 @@ func (i *LibpodAPI) GetVolumes(call iopodman.VarlinkCall, args []string, all boo
 			Options:    v.Options(),
 			Scope:      v.Scope(),
 		}
+		quota, err := v.Quota()
+		if err != nil {
+			return call.ReplyErrorOccurred(err.Error())
+		}
+		if quota != nil {
+			newVol.Quota = &iopodman.VolumeQuota{
+				Size: int64(quota.Size),
+			}
+			if quota.Used != nil {
+				used := int64(*quota.Used)
+				newVol.Quota.Used = &used
+			}
+		}
 		volumes = append(volumes, newVol)
 	}
 	return call.ReplyGetVolumes(volumes)
@@ -90,3 +110,44 @@ func (i *LibpodAPI) VolumesPrune(call iopodman.VarlinkCall) error {
 	}
 	return call.ReplyVolumesPrune(prunedNames, errs)
 }
+
+// VolumeExport writes the contents of a volume to a tar archive on the host
+func (i *LibpodAPI) VolumeExport(call iopodman.VarlinkCall, name, outPath string) error {
+	vol, err := i.Runtime.GetVolume(name)
+	if err != nil {
+		return call.ReplyVolumeNotFound(name, err.Error())
+	}
+	if outPath == "" {
+		outputFile, err := ioutil.TempFile("", "varlink_recv")
+		if err != nil {
+			return call.ReplyErrorOccurred(err.Error())
+		}
+		outputFile.Close()
+		outPath = outputFile.Name()
+	}
+	if err := vol.Export(outPath); err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	return call.ReplyVolumeExport(outPath)
+}
+
+// VolumeImport extracts a tar archive on the host into a volume
+func (i *LibpodAPI) VolumeImport(call iopodman.VarlinkCall, name, inputFile string, deleteFile bool) error {
+	// The archive was sent over by the client, do not leave it behind when
+	// the import fails
+	if deleteFile {
+		defer func() {
+			if err := os.Remove(inputFile); err != nil {
+				logrus.Errorf("unable to remove volume archive %s: %v", inputFile, err)
+			}
+		}()
+	}
+	vol, err := i.Runtime.GetVolume(name)
+	if err != nil {
+		return call.ReplyVolumeNotFound(name, err.Error())
+	}
+	if err := vol.Import(inputFile); err != nil {
+		return call.ReplyErrorOccurred(err.Error())
+	}
+	return call.ReplyVolumeImport()
+}
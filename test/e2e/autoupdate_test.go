@@ -0,0 +1,80 @@
+// +build !remoteclient
+
+package integration
+
+import (
+	"os"
+
+	. "github.com/containers/libpod/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman auto-update", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman auto-update with no auto-update containers", func() {
+		session := podmanTest.Podman([]string{"auto-update"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(BeEmpty())
+	})
+
+	It("podman create with an invalid auto-update policy fails", func() {
+		session := podmanTest.Podman([]string{"create", "--label", "io.containers.autoupdate=foobar", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+		Expect(session.ErrorToString()).To(ContainSubstring("invalid auto-update policy"))
+	})
+
+	It("podman create with auto-update policy image requires a fully-qualified image", func() {
+		session := podmanTest.Podman([]string{"create", "--label", "io.containers.autoupdate=image", "alpine"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+		Expect(session.ErrorToString()).To(ContainSubstring("fully-qualified"))
+	})
+
+	It("podman auto-update --dry-run lists containers without updating", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "autoupdatectr", "--label", "io.containers.autoupdate=image", "--label", "PODMAN_SYSTEMD_UNIT=autoupdatectr.service", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"auto-update", "--dry-run"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.LineInOutputContains("autoupdatectr.service")).To(BeTrue())
+		Expect(session.LineInOutputContains(ALPINE)).To(BeTrue())
+	})
+
+	It("podman auto-update fails for containers without a systemd unit", func() {
+		session := podmanTest.Podman([]string{"create", "--label", "io.containers.autoupdate=image", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"auto-update", "--dry-run"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+		Expect(session.ErrorToString()).To(ContainSubstring("PODMAN_SYSTEMD_UNIT"))
+	})
+})
@@ -5,6 +5,7 @@ package integration
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
 	. "github.com/containers/libpod/test/utils"
 	"github.com/ghodss/yaml"
@@ -238,4 +239,84 @@ var _ = Describe("Podman generate kube", func() {
 		Expect(inspect.ExitCode()).To(Equal(0))
 		Expect(inspect.OutputToString()).To(ContainSubstring(vol1))
 	})
+
+	It("podman generate kube and play kube keep volume names which are not claim names", func() {
+		for _, name := range []string{"my_data", "my.data"} {
+			vol := podmanTest.Podman([]string{"volume", "create", name})
+			vol.WaitWithDefaultTimeout()
+			Expect(vol.ExitCode()).To(Equal(0))
+		}
+
+		session := podmanTest.Podman([]string{"run", "-d", "--pod", "new:test1", "--name", "test-ctr", "-v", "my_data:/data1", "-v", "my.data:/data2", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		outputFile := filepath.Join(podmanTest.RunRoot, "pod.yaml")
+		kube := podmanTest.Podman([]string{"generate", "kube", "test1", "-f", outputFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		rm := podmanTest.Podman([]string{"pod", "rm", "-f", "test1"})
+		rm.WaitWithDefaultTimeout()
+		Expect(rm.ExitCode()).To(Equal(0))
+
+		play := podmanTest.Podman([]string{"play", "kube", outputFile})
+		play.WaitWithDefaultTimeout()
+		Expect(play.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{range .Mounts}}{{.Name}} {{end}}", "test-ctr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToStringArray()[0]).To(ContainSubstring("my_data"))
+		Expect(inspect.OutputToStringArray()[0]).To(ContainSubstring("my.data"))
+
+		volumes := podmanTest.Podman([]string{"volume", "ls", "-q"})
+		volumes.WaitWithDefaultTimeout()
+		Expect(volumes.ExitCode()).To(Equal(0))
+		Expect(len(volumes.OutputToStringArray())).To(Equal(2))
+	})
+
+	It("podman generate kube on multiple containers and pods with named volume", func() {
+		vol := podmanTest.Podman([]string{"volume", "create", "--label", "app=web", "--opt", "type=tmpfs", "--opt", "o=size=512m", "html"})
+		vol.WaitWithDefaultTimeout()
+		Expect(vol.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"create", "--name", "webserver", "-v", "html:/var/www/html", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		_, rc, _ := podmanTest.CreatePod("toppod")
+		Expect(rc).To(Equal(0))
+		session = podmanTest.RunTopContainerInPod("topcontainer", "toppod")
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		kube := podmanTest.Podman([]string{"generate", "kube", "webserver", "toppod"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		documents := strings.Split(string(kube.Out.Contents()), "---\n")
+		Expect(len(documents)).To(Equal(3))
+
+		claim := new(v1.PersistentVolumeClaim)
+		err := yaml.Unmarshal([]byte(documents[0]), claim)
+		Expect(err).To(BeNil())
+		Expect(claim.Name).To(Equal("html"))
+		Expect(claim.Labels["app"]).To(Equal("web"))
+		storage := claim.Spec.Resources.Requests[v1.ResourceStorage]
+		Expect(storage.String()).To(Equal("512Mi"))
+
+		pod := new(v1.Pod)
+		err = yaml.Unmarshal([]byte(documents[1]), pod)
+		Expect(err).To(BeNil())
+		Expect(pod.Name).To(Equal("webserver"))
+		Expect(len(pod.Spec.Volumes)).To(Equal(1))
+		Expect(pod.Spec.Volumes[0].PersistentVolumeClaim).To(Not(BeNil()))
+		Expect(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("html"))
+		Expect(pod.Spec.Volumes[0].HostPath).To(BeNil())
+
+		err = yaml.Unmarshal([]byte(documents[2]), pod)
+		Expect(err).To(BeNil())
+		Expect(pod.Name).To(Equal("toppod"))
+	})
 })
@@ -71,4 +71,81 @@ var _ = Describe("Podman generate systemd", func() {
 		Expect(session.ExitCode()).To(Equal(0))
 	})
 
+	It("podman generate systemd pod --name", func() {
+		n := podmanTest.Podman([]string{"pod", "create", "--name", "foo"})
+		n.WaitWithDefaultTimeout()
+		Expect(n.ExitCode()).To(Equal(0))
+
+		n = podmanTest.Podman([]string{"create", "--pod", "foo", "--name", "foo-1", ALPINE, "top"})
+		n.WaitWithDefaultTimeout()
+		Expect(n.ExitCode()).To(Equal(0))
+
+		n = podmanTest.Podman([]string{"create", "--pod", "foo", "--name", "foo-2", ALPINE, "top"})
+		n.WaitWithDefaultTimeout()
+		Expect(n.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"generate", "systemd", "--timeout", "42", "--name", "foo"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		output := session.OutputToString()
+		// the pod unit requires the container units ...
+		Expect(output).To(ContainSubstring("# pod-foo.service"))
+		Expect(output).To(ContainSubstring("Requires=container-foo-1.service container-foo-2.service"))
+		Expect(output).To(ContainSubstring("Before=container-foo-1.service container-foo-2.service"))
+		// ... which are bound to it
+		Expect(output).To(ContainSubstring("# container-foo-1.service"))
+		Expect(output).To(ContainSubstring("# container-foo-2.service"))
+		Expect(output).To(ContainSubstring("BindsTo=pod-foo.service"))
+		Expect(output).To(ContainSubstring("After=pod-foo.service"))
+		Expect(output).To(ContainSubstring(" stop -t 42 foo-1"))
+	})
+
+	It("podman generate systemd pod --name --files", func() {
+		n := podmanTest.Podman([]string{"pod", "create", "--name", "foo"})
+		n.WaitWithDefaultTimeout()
+		Expect(n.ExitCode()).To(Equal(0))
+
+		n = podmanTest.Podman([]string{"create", "--pod", "foo", "--name", "foo-1", ALPINE, "top"})
+		n.WaitWithDefaultTimeout()
+		Expect(n.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"generate", "systemd", "--name", "--files", "foo"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		for _, file := range []string{"pod-foo.service", "container-foo-1.service"} {
+			Expect(session.LineInOutputContains(file)).To(BeTrue())
+			os.Remove(file)
+		}
+	})
+
+	It("podman generate systemd --new", func() {
+		n := podmanTest.Podman([]string{"create", "--name", "foo", ALPINE, "top"})
+		n.WaitWithDefaultTimeout()
+		Expect(n.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"generate", "systemd", "-t", "42", "--name", "--new", "foo"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		output := session.OutputToString()
+		Expect(output).To(ContainSubstring("Environment=PODMAN_SYSTEMD_UNIT=%n"))
+		Expect(output).To(ContainSubstring(" run --conmon-pidfile %t/%n-pid --cidfile %t/%n-cid -d "))
+		Expect(output).To(ContainSubstring("--name foo " + ALPINE + " top"))
+		Expect(output).To(ContainSubstring(" stop --ignore --cidfile %t/%n-cid -t 42"))
+		Expect(output).To(ContainSubstring(" rm --ignore -f --cidfile %t/%n-cid"))
+		Expect(output).To(ContainSubstring("PIDFile=%t/%n-pid"))
+	})
+
+	It("podman generate systemd --new on a pod fails", func() {
+		n := podmanTest.Podman([]string{"pod", "create", "--name", "foo"})
+		n.WaitWithDefaultTimeout()
+		Expect(n.ExitCode()).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"generate", "systemd", "--new", "foo"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
 })
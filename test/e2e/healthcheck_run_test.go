@@ -187,4 +187,48 @@ var _ = Describe("Podman healthcheck run", func() {
 		inspect = podmanTest.InspectContainer("hc")
 		Expect(inspect[0].State.Healthcheck.Status).To(Equal("healthy"))
 	})
+	It("podman healthcheck with --health-on-failure stop", func() {
+		session := podmanTest.Podman([]string{"run", "-dt", "--name", "hc", "--health-retries", "1", "--health-on-failure", "stop", "--health-cmd", "ls /foo || exit 1", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		hc := podmanTest.Podman([]string{"healthcheck", "run", "hc"})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc.ExitCode()).To(Equal(1))
+
+		inspect := podmanTest.InspectContainer("hc")
+		Expect(inspect[0].State.Healthcheck.Status).To(Equal("unhealthy"))
+		Expect(inspect[0].State.Running).To(BeFalse())
+
+		events := podmanTest.Podman([]string{"events", "--stream=false", "--filter", "event=health_status"})
+		events.WaitWithDefaultTimeout()
+		Expect(events.ExitCode()).To(Equal(0))
+		Expect(events.OutputToString()).To(ContainSubstring("health_status=unhealthy"))
+	})
+
+	It("podman healthcheck with --health-on-failure restart", func() {
+		session := podmanTest.Podman([]string{"run", "-dt", "--name", "hc", "--health-retries", "1", "--health-on-failure", "restart", "--health-cmd", "ls /foo || exit 1", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		startedAt := podmanTest.InspectContainer("hc")[0].State.StartedAt
+
+		hc := podmanTest.Podman([]string{"healthcheck", "run", "hc"})
+		hc.WaitWithDefaultTimeout()
+		Expect(hc.ExitCode()).To(Equal(1))
+
+		inspect := podmanTest.InspectContainer("hc")
+		Expect(inspect[0].State.Running).To(BeTrue())
+		Expect(inspect[0].State.StartedAt).To(Not(Equal(startedAt)))
+		Expect(inspect[0].State.Healthcheck.Status).To(Equal("starting"))
+	})
+
+	It("podman healthcheck with invalid --health-on-failure", func() {
+		session := podmanTest.Podman([]string{"create", "--health-on-failure", "bogus", "--health-cmd", "ls", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+
+		session = podmanTest.Podman([]string{"create", "--health-on-failure", "kill", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
 })
@@ -1,8 +1,12 @@
 package integration
 
 import (
+	"fmt"
+	"net"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	. "github.com/containers/libpod/test/utils"
 	. "github.com/onsi/ginkgo"
@@ -235,4 +239,168 @@ var _ = Describe("Podman logs", func() {
 		logc.WaitWithDefaultTimeout()
 		Expect(logc.ExitCode()).To(Equal(0))
 	})
+
+	It("podman logs of one stream", func() {
+		logc := podmanTest.Podman([]string{"run", "--name", "streams", ALPINE, "sh", "-c", "echo out; echo err >&2; echo out"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Equal(0))
+
+		results := podmanTest.Podman([]string{"logs", "--stream", "stderr", "streams"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"err"}))
+
+		results = podmanTest.Podman([]string{"logs", "--stream", "stdout", "--tail", "1", "streams"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"out"}))
+
+		results = podmanTest.Podman([]string{"logs", "--stream", "stdin", "streams"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(125))
+	})
+
+	It("podman logs until a time", func() {
+		logc := podmanTest.Podman([]string{"run", "--name", "until", ALPINE, "sh", "-c", "echo before; sleep 2; echo after"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Equal(0))
+
+		results := podmanTest.Podman([]string{"logs", "-t", "until"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		lines := results.OutputToStringArray()
+		Expect(len(lines)).To(Equal(2))
+		until := strings.Fields(lines[0])[0]
+
+		results = podmanTest.Podman([]string{"logs", "--until", until, "until"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"before"}))
+
+		// The tail is taken from the lines logged until the given time
+		results = podmanTest.Podman([]string{"logs", "--tail", "1", "--until", until, "until"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"before"}))
+	})
+
+	It("podman logs follow stops at until time", func() {
+		logc := podmanTest.Podman([]string{"run", "-d", "--name", "follow-until", ALPINE, "sh", "-c", "echo podman; sleep 100"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Equal(0))
+
+		results := podmanTest.Podman([]string{"logs", "-f", "--until", "2s", "follow-until"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+
+		results = podmanTest.Podman([]string{"logs", "-f", "--until", time.Now().Add(3 * time.Second).Format(time.RFC3339Nano), "follow-until"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"podman"}))
+	})
+
+	It("podman logs of two containers merged by time", func() {
+		logc := podmanTest.Podman([]string{"run", "-d", "--name", "merge1", ALPINE, "sh", "-c", "echo a1; sleep 2; echo a2"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Equal(0))
+		logc = podmanTest.Podman([]string{"run", "-d", "--name", "merge2", ALPINE, "sh", "-c", "sleep 1; echo b1; sleep 2; echo b2"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Equal(0))
+
+		wait := podmanTest.Podman([]string{"wait", "merge1", "merge2"})
+		wait.WaitWithDefaultTimeout()
+		Expect(wait.ExitCode()).To(Equal(0))
+
+		results := podmanTest.Podman([]string{"logs", "merge2", "merge1"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"merge1 a1", "merge2 b1", "merge1 a2", "merge2 b2"}))
+	})
+
+	It("podman logs with rotated and compressed log files", func() {
+		SkipIfRemote()
+		logc := podmanTest.Podman([]string{"run", "--name", "rotated", "--log-opt", "max-size=1k,max-file=3,compress=true", ALPINE, "sh", "-c", "i=10; while [ $i -lt 50 ]; do echo line$i 0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef; i=$((i+1)); sleep 0.1; done"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.LogPath}} {{.HostConfig.LogConfig.Config}}", "rotated"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		fields := strings.SplitN(inspect.OutputToString(), " ", 2)
+		Expect(fields[1]).To(Equal("map[compress:true max-file:3 max-size:1024]"))
+		logPath := fields[0]
+		_, err := os.Stat(logPath + ".1.gz")
+		Expect(err).To(BeNil())
+		_, err = os.Stat(logPath + ".3.gz")
+		Expect(os.IsNotExist(err)).To(BeTrue())
+
+		results := podmanTest.Podman([]string{"logs", "rotated"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		lines := results.OutputToStringArray()
+		// Older lines were removed by the rotation, the remaining ones are
+		// read in order across the log files
+		Expect(len(lines)).To(BeNumerically("<", 40))
+		Expect(sort.StringsAreSorted(lines)).To(BeTrue())
+		Expect(lines[len(lines)-1]).To(HavePrefix("line49 "))
+
+		results = podmanTest.Podman([]string{"logs", "--tail", "20", "rotated"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal(lines[len(lines)-20:]))
+	})
+
+	It("podman logs with the syslog log driver", func() {
+		SkipIfRemote()
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).To(BeNil())
+		defer conn.Close()
+
+		address := fmt.Sprintf("syslog-address=udp://%s", conn.LocalAddr().String())
+		logc := podmanTest.Podman([]string{"run", "--name", "syslog", "--label", "team=web", "--log-driver", "syslog", "--log-opt", address, "--log-opt", "tag=myapp,syslog-facility=local0", ALPINE, "sh", "-c", "echo out; sleep 0.5; echo err >&2"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.ID}}", "syslog"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		cid := inspect.OutputToString()
+
+		// Lines are forwarded as RFC5424 messages, in order
+		var messages []string
+		buf := make([]byte, 4096)
+		Expect(conn.SetReadDeadline(time.Now().Add(10 * time.Second))).To(BeNil())
+		for len(messages) < 2 {
+			n, _, err := conn.ReadFrom(buf)
+			Expect(err).To(BeNil())
+			messages = append(messages, string(buf[:n]))
+		}
+		data := fmt.Sprintf(`myapp - - [podman@2312 id="%s" label.team="web" name="syslog"]`, cid)
+		Expect(messages[0]).To(HavePrefix("<134>1 "))
+		Expect(messages[0]).To(HaveSuffix(data + " out"))
+		Expect(messages[1]).To(HavePrefix("<131>1 "))
+		Expect(messages[1]).To(HaveSuffix(data + " err"))
+
+		// podman logs reads the local copy of the log
+		results := podmanTest.Podman([]string{"logs", "syslog"})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"out", "err"}))
+	})
+
+	It("podman run syslog log options require the syslog log driver", func() {
+		logc := podmanTest.Podman([]string{"create", "--log-opt", "syslog-address=udp://127.0.0.1", ALPINE, "true"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Not(Equal(0)))
+
+		logc = podmanTest.Podman([]string{"create", "--log-driver", "syslog", "--log-opt", "syslog-address=http://127.0.0.1", ALPINE, "true"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman run log rotation requires max-size", func() {
+		logc := podmanTest.Podman([]string{"create", "--log-opt", "max-file=3", ALPINE, "true"})
+		logc.WaitWithDefaultTimeout()
+		Expect(logc.ExitCode()).To(Not(Equal(0)))
+	})
 })
@@ -0,0 +1,174 @@
+// +build !remoteclient
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/containers/libpod/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman network", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		SkipIfRootless()
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	removeNetwork := func(name string) {
+		os.Remove(filepath.Join(podmanTest.CNIConfigDir, name+".conflist"))
+	}
+
+	It("podman network create, ls and rm", func() {
+		defer removeNetwork("podmantestnet1")
+		session := podmanTest.Podman([]string{"network", "create", "--subnet", "10.251.0.0/24", "podmantestnet1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal(filepath.Join(podmanTest.CNIConfigDir, "podmantestnet1.conflist")))
+
+		session = podmanTest.Podman([]string{"network", "ls", "--quiet"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.LineInOutputContains("podmantestnet1")).To(BeTrue())
+
+		session = podmanTest.Podman([]string{"network", "inspect", "podmantestnet1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.IsJSONOutputValid()).To(BeTrue())
+		Expect(session.OutputToString()).To(ContainSubstring("10.251.0.0/24"))
+
+		session = podmanTest.Podman([]string{"network", "rm", "podmantestnet1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"network", "ls", "--quiet"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.LineInOutputContains("podmantestnet1")).To(BeFalse())
+	})
+
+	It("podman network create adds a default route of the subnet family", func() {
+		defer removeNetwork("podmantestnet6")
+		defer removeNetwork("podmantestnet7")
+		session := podmanTest.Podman([]string{"network", "create", "--subnet", "10.250.0.0/24", "podmantestnet6"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"network", "inspect", "podmantestnet6"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring(`"dst": "0.0.0.0/0"`))
+
+		session = podmanTest.Podman([]string{"network", "create", "--subnet", "fd00:250::/64", "podmantestnet7"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"network", "inspect", "podmantestnet7"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring(`"dst": "::/0"`))
+		Expect(session.OutputToString()).To(Not(ContainSubstring("0.0.0.0/0")))
+	})
+
+	It("podman network create with overlapping subnet fails", func() {
+		defer removeNetwork("podmantestnet2")
+		session := podmanTest.Podman([]string{"network", "create", "--subnet", "10.252.0.0/24", "podmantestnet2"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"network", "create", "--subnet", "10.252.0.0/16", "podmantestnet3"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman network rm refuses to remove a network in use", func() {
+		defer removeNetwork("podmantestnet4")
+		session := podmanTest.Podman([]string{"network", "create", "--subnet", "10.253.0.0/24", "podmantestnet4"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--network", "podmantestnet4", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		cid := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"network", "rm", "podmantestnet4"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+		Expect(session.ErrorToString()).To(ContainSubstring(cid))
+
+		session = podmanTest.Podman([]string{"network", "rm", "--force", "podmantestnet4"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainers()).To(Equal(0))
+	})
+
+	It("podman network connect and disconnect a running container", func() {
+		defer removeNetwork("podmantestnet5")
+		session := podmanTest.Podman([]string{"network", "create", "--subnet", "10.254.0.0/24", "podmantestnet5"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "-dt", "--name", "nettest", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"network", "connect", "nettest", "podmantestnet5"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"exec", "nettest", "ip", "addr", "show", "eth1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("10.254.0."))
+
+		inspect := podmanTest.InspectContainer("nettest")
+		Expect(len(inspect)).To(Equal(1))
+		Expect(inspect[0].NetworkSettings.Networks).To(HaveKey("podmantestnet5"))
+		Expect(inspect[0].NetworkSettings.Networks["podmantestnet5"].IPAddress).To(ContainSubstring("10.254.0."))
+
+		// connecting twice fails
+		session = podmanTest.Podman([]string{"network", "connect", "nettest", "podmantestnet5"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		// the network is joined again after a restart
+		session = podmanTest.Podman([]string{"restart", "nettest"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		inspect = podmanTest.InspectContainer("nettest")
+		Expect(inspect[0].NetworkSettings.Networks["podmantestnet5"].IPAddress).To(ContainSubstring("10.254.0."))
+
+		session = podmanTest.Podman([]string{"network", "disconnect", "nettest", "podmantestnet5"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"exec", "nettest", "ip", "addr", "show", "eth1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		inspect = podmanTest.InspectContainer("nettest")
+		Expect(inspect[0].NetworkSettings.Networks).ToNot(HaveKey("podmantestnet5"))
+	})
+})
@@ -3,8 +3,10 @@
 package integration
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	. "github.com/containers/libpod/test/utils"
@@ -64,6 +66,194 @@ spec:
 status: {}
 `
 
+var deploymentYaml = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  color: blue
+---
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: {{ .Name }}-data
+  labels:
+    app: {{ .Name }}
+spec:
+  accessModes:
+  - ReadWriteOnce
+  resources:
+    requests:
+      storage: 1Gi
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Name }}
+spec:
+  replicas: {{ .Replicas }}
+  selector:
+    matchLabels:
+      app: {{ .Name }}
+  template:
+    metadata:
+      labels:
+        app: {{ .Name }}
+    spec:
+      containers:
+      - command:
+        - top
+        env:
+        - name: COLOR
+          valueFrom:
+            configMapKeyRef:
+              name: settings
+              key: color
+        envFrom:
+        - configMapRef:
+            name: {{ .ConfigMap }}
+          prefix: EXTRA_
+        image: {{ .Image }}
+        name: ctr
+        volumeMounts:
+        - mountPath: /data
+          name: data
+        - mountPath: /settings
+          name: settings
+      volumes:
+      - name: data
+        persistentVolumeClaim:
+          claimName: {{ .Name }}-data
+      - name: settings
+        configMap:
+          name: settings
+`
+
+var configMapYaml = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: extra
+data:
+  shape: circle
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+stringData:
+  password: secret
+`
+
+var podSettingsYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: settings
+spec:
+  hostname: myhost
+  restartPolicy: OnFailure
+  shareProcessNamespace: true
+  securityContext:
+    runAsUser: 1000
+    runAsGroup: 1001
+  containers:
+  - command:
+    - top
+    image: ALPINE_IMAGE
+    name: settingsctr
+    resources:
+      limits:
+        cpu: 500m
+        memory: 100Mi
+`
+
+var podInitYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: initpod
+spec:
+  initContainers:
+  - command:
+    - touch
+    - /data/ready
+    image: ALPINE_IMAGE
+    name: setup
+    volumeMounts:
+    - mountPath: /data
+      name: data
+  containers:
+  - command:
+    - top
+    image: ALPINE_IMAGE
+    name: app
+    volumeMounts:
+    - mountPath: /data
+      name: data
+  volumes:
+  - name: data
+    persistentVolumeClaim:
+      claimName: initdata
+`
+
+var podsFailingYaml = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: goodpod
+spec:
+  containers:
+  - command:
+    - top
+    image: ALPINE_IMAGE
+    name: goodctr
+    volumeMounts:
+    - mountPath: /data
+      name: data
+  volumes:
+  - name: data
+    persistentVolumeClaim:
+      claimName: gooddata
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: badpod
+spec:
+  containers:
+  - command:
+    - top
+    image: ALPINE_IMAGE
+    name: badctr
+  volumes:
+  - name: scratch
+    emptyDir: {}
+`
+
+type Deployment struct {
+	Name      string
+	Replicas  int
+	ConfigMap string
+	Image     string
+}
+
+func generateDeploymentKubeYaml(deployment Deployment, fileName string) error {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	t, err := template.New("deployment").Parse(deploymentYaml)
+	if err != nil {
+		return err
+	}
+
+	return t.Execute(f, deployment)
+}
+
 type Pod struct {
 	Name       string
 	Containers []Container
@@ -140,6 +330,24 @@ var _ = Describe("Podman generate kube", func() {
 		Expect(inspect.OutputToString()).To(ContainSubstring(ctrCmd[0]))
 	})
 
+	It("podman play kube without restart policy uses always", func() {
+		ctrName := "testCtr"
+		testContainer := Container{[]string{"top"}, ALPINE, ctrName, false, nil, nil}
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+
+		err := generateKubeYaml([]Container{testContainer}, tempFile)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.HostConfig.RestartPolicy.Name}}", ctrName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("always"))
+	})
+
 	It("podman play kube cap add", func() {
 		ctrName := "testCtr"
 		ctrCmd := []string{"cat", "/proc/self/status"}
@@ -179,4 +387,148 @@ var _ = Describe("Podman generate kube", func() {
 		Expect(inspect.ExitCode()).To(Equal(0))
 		Expect(inspect.OutputToString()).To(ContainSubstring(capDrop))
 	})
+
+	It("podman play kube deployment with configmaps and claims", func() {
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		err := generateDeploymentKubeYaml(Deployment{"web", 2, "extra", ALPINE}, tempFile)
+		Expect(err).To(BeNil())
+		configMapFile := filepath.Join(podmanTest.TempDir, "configmap.yaml")
+		err = ioutil.WriteFile(configMapFile, []byte(configMapYaml), 0644)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", "--configmap", configMapFile, tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		for _, pod := range []string{"web-pod-0", "web-pod-1"} {
+			inspect := podmanTest.Podman([]string{"pod", "inspect", pod})
+			inspect.WaitWithDefaultTimeout()
+			Expect(inspect.ExitCode()).To(Equal(0))
+
+			env := podmanTest.Podman([]string{"exec", pod + "-ctr", "env"})
+			env.WaitWithDefaultTimeout()
+			Expect(env.ExitCode()).To(Equal(0))
+			Expect(env.OutputToString()).To(ContainSubstring("COLOR=blue"))
+			Expect(env.OutputToString()).To(ContainSubstring("EXTRA_shape=circle"))
+
+			settings := podmanTest.Podman([]string{"exec", pod + "-ctr", "cat", "/settings/color"})
+			settings.WaitWithDefaultTimeout()
+			Expect(settings.ExitCode()).To(Equal(0))
+			Expect(settings.OutputToString()).To(Equal("blue"))
+		}
+
+		volume := podmanTest.Podman([]string{"volume", "inspect", "--format", "{{.Labels.app}}", "web-data"})
+		volume.WaitWithDefaultTimeout()
+		Expect(volume.ExitCode()).To(Equal(0))
+		Expect(volume.OutputToString()).To(Equal("web"))
+	})
+
+	It("podman play kube with missing configmap", func() {
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		err := generateDeploymentKubeYaml(Deployment{"web", 1, "extra", ALPINE}, tempFile)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman play kube removes the played pods and volumes when a later pod fails", func() {
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		err := ioutil.WriteFile(tempFile, []byte(strings.Replace(podsFailingYaml, "ALPINE_IMAGE", ALPINE, -1)), 0644)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Not(Equal(0)))
+
+		pods := podmanTest.Podman([]string{"pod", "ps", "-q"})
+		pods.WaitWithDefaultTimeout()
+		Expect(pods.ExitCode()).To(Equal(0))
+		Expect(len(pods.OutputToStringArray())).To(Equal(0))
+
+		volumes := podmanTest.Podman([]string{"volume", "ls", "-q"})
+		volumes.WaitWithDefaultTimeout()
+		Expect(volumes.ExitCode()).To(Equal(0))
+		Expect(len(volumes.OutputToStringArray())).To(Equal(0))
+	})
+
+	It("podman play kube with pod settings", func() {
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		err := ioutil.WriteFile(tempFile, []byte(strings.Replace(podSettingsYaml, "ALPINE_IMAGE", ALPINE, 1)), 0644)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.Config.User}} {{.Config.Hostname}} {{.HostConfig.RestartPolicy.Name}} {{.HostConfig.Memory}}", "settingsctr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("1000:1001 myhost on-failure 104857600"))
+
+		hostname := podmanTest.Podman([]string{"exec", "settingsctr", "hostname"})
+		hostname.WaitWithDefaultTimeout()
+		Expect(hostname.ExitCode()).To(Equal(0))
+		Expect(hostname.OutputToString()).To(Equal("myhost"))
+
+		// the infra container is visible when the pid namespace is shared
+		ps := podmanTest.Podman([]string{"exec", "settingsctr", "ps"})
+		ps.WaitWithDefaultTimeout()
+		Expect(ps.ExitCode()).To(Equal(0))
+		Expect(ps.OutputToString()).To(ContainSubstring("pause"))
+	})
+
+	It("podman play kube --down", func() {
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		err := generateDeploymentKubeYaml(Deployment{"web", 2, "extra", ALPINE}, tempFile)
+		Expect(err).To(BeNil())
+		configMapFile := filepath.Join(podmanTest.TempDir, "configmap.yaml")
+		err = ioutil.WriteFile(configMapFile, []byte(configMapYaml), 0644)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", "--configmap", configMapFile, tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		down := podmanTest.Podman([]string{"play", "kube", "--down", "--volumes", tempFile})
+		down.WaitWithDefaultTimeout()
+		Expect(down.ExitCode()).To(Equal(0))
+		Expect(len(down.OutputToStringArray())).To(Equal(2))
+
+		pods := podmanTest.Podman([]string{"pod", "ps", "-q"})
+		pods.WaitWithDefaultTimeout()
+		Expect(pods.ExitCode()).To(Equal(0))
+		Expect(len(pods.OutputToStringArray())).To(Equal(0))
+
+		volumes := podmanTest.Podman([]string{"volume", "ls", "-q"})
+		volumes.WaitWithDefaultTimeout()
+		Expect(volumes.ExitCode()).To(Equal(0))
+		Expect(len(volumes.OutputToStringArray())).To(Equal(0))
+
+		// tearing down again skips the pods which are gone
+		down = podmanTest.Podman([]string{"play", "kube", "--down", tempFile})
+		down.WaitWithDefaultTimeout()
+		Expect(down.ExitCode()).To(Equal(0))
+	})
+
+	It("podman play kube with init containers and pod volume", func() {
+		tempFile := filepath.Join(podmanTest.TempDir, "kube.yaml")
+		err := ioutil.WriteFile(tempFile, []byte(strings.Replace(podInitYaml, "ALPINE_IMAGE", ALPINE, -1)), 0644)
+		Expect(err).To(BeNil())
+
+		kube := podmanTest.Podman([]string{"play", "kube", tempFile})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube.ExitCode()).To(Equal(0))
+
+		ls := podmanTest.Podman([]string{"exec", "app", "ls", "/data"})
+		ls.WaitWithDefaultTimeout()
+		Expect(ls.ExitCode()).To(Equal(0))
+		Expect(ls.OutputToString()).To(Equal("ready"))
+
+		generate := podmanTest.Podman([]string{"generate", "kube", "initpod"})
+		generate.WaitWithDefaultTimeout()
+		Expect(generate.ExitCode()).To(Equal(0))
+		Expect(generate.OutputToString()).To(ContainSubstring("initContainers"))
+	})
 })
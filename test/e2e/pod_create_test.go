@@ -117,4 +117,130 @@ var _ = Describe("Podman pod create", func() {
 		session.WaitWithDefaultTimeout()
 		Expect(session.ExitCode()).To(Equal(125))
 	})
+
+	It("podman create pod with resource limits", func() {
+		SkipIfRootless()
+		session := podmanTest.Podman([]string{"pod", "create", "--cpus", "1.5", "--memory", "64m", "--pids-limit", "100", "--blkio-weight", "300"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		pod := session.OutputToString()
+
+		inspect := podmanTest.Podman([]string{"pod", "inspect", pod})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		podData := inspect.InspectPodToJSON()
+		resources := podData.Config.Resources
+		Expect(resources).To(Not(BeNil()))
+		Expect(*resources.CPU.Quota).To(Equal(int64(150000)))
+		Expect(*resources.CPU.Period).To(Equal(uint64(100000)))
+		Expect(*resources.Memory.Limit).To(Equal(int64(64 * 1024 * 1024)))
+		Expect(resources.Pids.Limit).To(Equal(int64(100)))
+		Expect(*resources.BlockIO.Weight).To(Equal(uint16(300)))
+
+		session = podmanTest.Podman([]string{"run", "--pod", pod, ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+	})
+
+	It("podman create pod with invalid resource limits should fail", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--blkio-weight", "5"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+
+		session = podmanTest.Podman([]string{"pod", "create", "--memory", "lots"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+
+		session = podmanTest.Podman([]string{"pod", "create", "--cpus", "-1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman create pod with DNS, hosts and hostname", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--hostname", "foobar", "--dns", "1.2.3.4", "--dns-search", "foobar.com", "--add-host", "foobar.local:1.1.1.1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		pod := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"run", "--pod", pod, ALPINE, "cat", "/etc/resolv.conf"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.LineInOutputContains("nameserver 1.2.3.4")).To(BeTrue())
+		Expect(session.LineInOutputContains("search foobar.com")).To(BeTrue())
+
+		session = podmanTest.Podman([]string{"run", "--pod", pod, ALPINE, "cat", "/etc/hosts"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.LineInOutputContains("1.1.1.1 foobar.local")).To(BeTrue())
+
+		session = podmanTest.Podman([]string{"run", "--pod", pod, ALPINE, "hostname"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("foobar"))
+	})
+
+	It("podman create pod with --no-hosts", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--no-hosts"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		pod := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"run", "--pod", pod, ALPINE, "cat", "/etc/hosts"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		image := podmanTest.Podman([]string{"run", "--rm", "--no-hosts", ALPINE, "cat", "/etc/hosts"})
+		image.WaitWithDefaultTimeout()
+		Expect(image.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal(image.OutputToString()))
+	})
+
+	It("podman create pod with invalid DNS and hosts options should fail", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--no-hosts", "--add-host", "foobar.local:1.1.1.1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+
+		session = podmanTest.Podman([]string{"pod", "create", "--infra=false", "--dns", "1.2.3.4"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+
+		session = podmanTest.Podman([]string{"pod", "create", "--dns", "notanip"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
+
+	It("podman run with --dns in a pod sharing the network namespace should warn and use the pod DNS", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--dns", "1.2.3.4"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		pod := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"run", "--pod", pod, "--dns", "5.6.7.8", ALPINE, "cat", "/etc/resolv.conf"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("nameserver 1.2.3.4"))
+		Expect(session.OutputToString()).To(Not(ContainSubstring("5.6.7.8")))
+		Expect(session.ErrorToString()).To(ContainSubstring("DNS options are ignored"))
+	})
+
+	It("podman create pod with volume", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--name", "volpod", "--volume", "podvol:/data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--pod", "volpod", ALPINE, "touch", "/data/shared"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--pod", "volpod", ALPINE, "ls", "/data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(Equal("shared"))
+	})
+
+	It("podman create pod with host directory volume fails", func() {
+		session := podmanTest.Podman([]string{"pod", "create", "--volume", "/tmp:/data"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
 })
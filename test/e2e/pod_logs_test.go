@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"os"
+	"time"
+
+	. "github.com/containers/libpod/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman pod logs", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.CleanupPod()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman pod logs without pod name or id", func() {
+		result := podmanTest.Podman([]string{"pod", "logs"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(125))
+	})
+
+	It("podman pod logs on bogus pod", func() {
+		result := podmanTest.Podman([]string{"pod", "logs", "1234"})
+		result.WaitWithDefaultTimeout()
+		Expect(result.ExitCode()).To(Equal(125))
+	})
+
+	It("podman pod logs of the containers in a pod", func() {
+		_, ec, podid := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"run", "--pod", podid, "--name", "first", ALPINE, "sh", "-c", "echo one; sleep 1; echo three"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		session = podmanTest.Podman([]string{"create", "--pod", podid, "--name", "unstarted", ALPINE, "echo", "never"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		results := podmanTest.Podman([]string{"pod", "logs", podid})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"first one", "first three"}))
+
+		results = podmanTest.Podman([]string{"pod", "logs", "--tail", "1", podid})
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"first three"}))
+	})
+
+	It("podman pod logs follows containers added to the pod", func() {
+		_, ec, podid := podmanTest.CreatePod("")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"run", "-d", "--pod", podid, "--name", "first", ALPINE, "sh", "-c", "echo one; sleep 100"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		until := time.Now().Add(8 * time.Second).Format(time.RFC3339Nano)
+		results := podmanTest.Podman([]string{"pod", "logs", "-f", "--until", until, podid})
+
+		time.Sleep(2 * time.Second)
+		session = podmanTest.Podman([]string{"run", "--pod", podid, "--name", "second", ALPINE, "echo", "two"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		results.WaitWithDefaultTimeout()
+		Expect(results.ExitCode()).To(Equal(0))
+		Expect(results.OutputToStringArray()).To(Equal([]string{"first one", "second two"}))
+	})
+})
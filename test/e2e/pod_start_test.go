@@ -135,4 +135,109 @@ var _ = Describe("Podman pod start", func() {
 		session.WaitWithDefaultTimeout()
 		Expect(session.ExitCode()).To(Equal(125))
 	})
+
+	It("podman pod start runs init containers first", func() {
+		_, ec, _ := podmanTest.CreatePod("initpod")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"create", "--pod", "initpod", "--init-ctr", "--name", "initctr", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--pod", "initpod", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"pod", "start", "initpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(1))
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.State.Status}}", "initctr"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("exited"))
+	})
+
+	It("podman pod start runs init containers once per start of the pod", func() {
+		_, ec, _ := podmanTest.CreatePod("initpod")
+		Expect(ec).To(Equal(0))
+
+		// The init container records every run in the volume
+		session := podmanTest.Podman([]string{"create", "--pod", "initpod", "--init-ctr", "-v", "initvol:/vol", ALPINE, "sh", "-c", "echo run >> /vol/runs"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--pod", "initpod", "--name", "initpodtop", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"pod", "start", "initpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		// Containers of the pod are running
+		session = podmanTest.Podman([]string{"pod", "start", "initpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		// The infra container is still running, the init container
+		// already completed for this start of the pod
+		session = podmanTest.Podman([]string{"stop", "initpodtop"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"pod", "start", "initpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		runs := podmanTest.Podman([]string{"run", "--rm", "-v", "initvol:/vol", ALPINE, "cat", "/vol/runs"})
+		runs.WaitWithDefaultTimeout()
+		Expect(runs.ExitCode()).To(Equal(0))
+		Expect(len(runs.OutputToStringArray())).To(Equal(1))
+
+		// A new start of the pod runs the init container again
+		session = podmanTest.Podman([]string{"pod", "stop", "initpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"pod", "start", "initpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		runs = podmanTest.Podman([]string{"run", "--rm", "-v", "initvol:/vol", ALPINE, "cat", "/vol/runs"})
+		runs.WaitWithDefaultTimeout()
+		Expect(runs.ExitCode()).To(Equal(0))
+		Expect(len(runs.OutputToStringArray())).To(Equal(2))
+	})
+
+	It("podman pod start with failing init container", func() {
+		_, ec, _ := podmanTest.CreatePod("initpod")
+		Expect(ec).To(Equal(0))
+
+		session := podmanTest.Podman([]string{"create", "--pod", "initpod", "--init-ctr", ALPINE, "false"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"create", "--pod", "initpod", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"pod", "start", "initpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman init container requires a pod and create", func() {
+		session := podmanTest.Podman([]string{"create", "--init-ctr", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+
+		_, ec, _ := podmanTest.CreatePod("initpod")
+		Expect(ec).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--pod", "initpod", "--init-ctr", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(125))
+	})
 })
@@ -838,4 +838,36 @@ USER mail`
 		}
 		Expect(found).To(BeTrue())
 	})
+	It("podman run with restart-policy unless-stopped backs off and stays stopped", func() {
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "crashloop", "--restart", "unless-stopped", ALPINE, "false"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		restarted := false
+		for i := 0; i < 10; i++ {
+			time.Sleep(1 * time.Second)
+			inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.RestartCount}}", "crashloop"})
+			inspect.WaitWithDefaultTimeout()
+			Expect(inspect.ExitCode()).To(Equal(0))
+			if inspect.OutputToString() != "0" {
+				restarted = true
+				break
+			}
+		}
+		Expect(restarted).To(BeTrue())
+
+		stop := podmanTest.Podman([]string{"stop", "crashloop"})
+		stop.WaitWithDefaultTimeout()
+
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.RestartCount}}", "crashloop"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		count := inspect.OutputToString()
+
+		time.Sleep(3 * time.Second)
+		inspect = podmanTest.Podman([]string{"inspect", "--format", "{{.RestartCount}} {{.State.Running}}", "crashloop"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal(count + " false"))
+	})
 })
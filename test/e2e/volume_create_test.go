@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"io/ioutil"
 	"os"
 
 	. "github.com/containers/libpod/test/utils"
@@ -57,4 +58,65 @@ var _ = Describe("Podman volume create", func() {
 		Expect(match).To(BeTrue())
 		Expect(len(check.OutputToStringArray())).To(Equal(1))
 	})
+
+	It("podman create volume with invalid local driver option", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "foo=bar", "badvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"volume", "create", "--opt", "type=ext4", "nodevvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman create tmpfs volume mounted by containers", func() {
+		SkipIfRootless()
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "type=tmpfs", "--opt", "o=size=2m", "tmpvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "tmpvol:/data", ALPINE, "grep", "/data", "/proc/self/mounts"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToString()).To(ContainSubstring("tmpfs"))
+		Expect(session.OutputToString()).To(ContainSubstring("size=2048k"))
+
+		// Unmounted again once the last container is gone
+		session = podmanTest.Podman([]string{"volume", "inspect", "--format", "{{.MountPoint}}", "tmpvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		mounts, err := ioutil.ReadFile("/proc/self/mounts")
+		Expect(err).To(BeNil())
+		Expect(string(mounts)).To(Not(ContainSubstring(session.OutputToString())))
+	})
+
+	It("podman create volume with invalid size", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "size=foo", "badsize"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+
+		session = podmanTest.Podman([]string{"volume", "create", "--opt", "size=1G", "--opt", "type=tmpfs", "badsize"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman create volume with size", func() {
+		SkipIfRootless()
+		session := podmanTest.Podman([]string{"volume", "create", "--opt", "size=10m", "sizedvol"})
+		session.WaitWithDefaultTimeout()
+		if session.ExitCode() != 0 {
+			// Only XFS and ext4 with project quotas support sizes
+			Expect(session.ErrorToString()).To(ContainSubstring("does not support project quotas"))
+			Skip("volume path does not support project quotas")
+		}
+
+		inspect := podmanTest.Podman([]string{"volume", "inspect", "--format", "{{.Quota}}", "sizedvol"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect.ExitCode()).To(Equal(0))
+		Expect(inspect.OutputToString()).To(Equal("10.49MB"))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "sizedvol:/data", ALPINE, "dd", "if=/dev/zero", "of=/data/file", "bs=1M", "count=20"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
 })
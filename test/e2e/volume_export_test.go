@@ -0,0 +1,82 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/containers/libpod/test/utils"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman volume export and import", func() {
+	var (
+		tempdir    string
+		err        error
+		podmanTest *PodmanTestIntegration
+	)
+
+	BeforeEach(func() {
+		tempdir, err = CreateTempDirInTempDir()
+		if err != nil {
+			os.Exit(1)
+		}
+		podmanTest = PodmanTestCreate(tempdir)
+		podmanTest.Setup()
+		podmanTest.SeedImages()
+	})
+
+	AfterEach(func() {
+		podmanTest.Cleanup()
+		podmanTest.CleanupVolume()
+		f := CurrentGinkgoTestDescription()
+		processTestResult(f)
+
+	})
+
+	It("podman volume export and import round trip", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "srcvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "srcvol:/data", ALPINE, "sh", "-c", "mkdir /data/dir && echo hello > /data/dir/file && chown -R 1000:1001 /data/dir"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		outfile := filepath.Join(podmanTest.TempDir, "srcvol.tar")
+		session = podmanTest.Podman([]string{"volume", "export", "-o", outfile, "srcvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		_, err := os.Stat(outfile)
+		Expect(err).To(BeNil())
+
+		session = podmanTest.Podman([]string{"volume", "create", "dstvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"volume", "import", "dstvol", outfile})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "-v", "dstvol:/data", ALPINE, "sh", "-c", "cat /data/dir/file && stat -c %u:%g /data/dir/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(session.OutputToStringArray()).To(Equal([]string{"hello", "1000:1001"}))
+	})
+
+	It("podman volume export of nonexistent volume", func() {
+		session := podmanTest.Podman([]string{"volume", "export", "-o", filepath.Join(podmanTest.TempDir, "none.tar"), "novol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+
+	It("podman volume import of nonexistent file", func() {
+		session := podmanTest.Podman([]string{"volume", "create", "myvol"})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = podmanTest.Podman([]string{"volume", "import", "myvol", filepath.Join(podmanTest.TempDir, "none.tar")})
+		session.WaitWithDefaultTimeout()
+		Expect(session.ExitCode()).To(Not(Equal(0)))
+	})
+})